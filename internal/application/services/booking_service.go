@@ -13,6 +13,19 @@ import (
 
 type RazorpayClient interface {
 	CreateOrder(amount int, currency, receipt string) (string, error)
+	VerifyPaymentSignature(orderID, paymentID, signature string) error
+}
+
+// verifyRazorpayPayment checks that a payment was made against the order created
+// for a booking, using the signature Razorpay's checkout returns
+func verifyRazorpayPayment(client RazorpayClient, bookingOrderID, orderID, paymentID, signature string) error {
+	if orderID == "" || paymentID == "" || signature == "" {
+		return errors.New("razorpay order ID, payment ID and signature are required")
+	}
+	if bookingOrderID == "" || orderID != bookingOrderID {
+		return errors.New("the order does not belong to this booking")
+	}
+	return client.VerifyPaymentSignature(orderID, paymentID, signature)
 }
 
 type BookingService struct {
@@ -192,16 +205,10 @@ func (s *BookingService) VerifyPayment(ctx context.Context, bookingID, razorpayO
 		return errors.New("booking not found")
 	}
 
-	// Verify the payment with Razorpay
-	// Note: In a real implementation, you would verify the signature using Razorpay's secret
-	// This is a simplified example
-	if razorpaySignature == "" {
-		// Update booking status to failed
-		err = s.bookingRepo.UpdatePaymentStatus(ctx, bookingID, "failed", razorpayOrderID, razorpayPaymentID)
-		if err != nil {
-			return fmt.Errorf("payment verification failed: invalid signature, failed to update booking status: %w", err)
-		}
-		return errors.New("invalid payment signature")
+	// Verify the payment with Razorpay. A bad signature leaves the booking as it
+	// is, so nobody can fail someone else's booking with one.
+	if err := verifyRazorpayPayment(s.razorpayClient, booking.RazorpayOrderID, razorpayOrderID, razorpayPaymentID, razorpaySignature); err != nil {
+		return err
 	}
 
 	// Update booking status to paid
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+// Clinic hours, holidays and booking days are in Indian Standard Time. IST has
+// no daylight saving, so a fixed zone is used rather than the tz database.
+var clinicTimezone = time.FixedZone("IST", 5*60*60+30*60)
+
+const (
+	defaultClinicSlotMinutes = 30
+	clinicDateLayout         = "2006-01-02"
+)
+
+// parseClinicDate parses a YYYY-MM-DD date as midnight in clinic time
+func parseClinicDate(date string) (time.Time, error) {
+	day, err := time.ParseInLocation(clinicDateLayout, date, clinicTimezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD", date)
+	}
+	return day, nil
+}
+
+// clinicDay is midnight in clinic time at the start of the day containing t
+func clinicDay(t time.Time) time.Time {
+	t = t.In(clinicTimezone)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, clinicTimezone)
+}
+
+// parseClockTime reads "HH:MM", or a 12-hour time such as "9:30 AM", as
+// minutes after midnight
+func parseClockTime(value string) (int, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	for _, layout := range []string{"15:04", "3:04 PM", "3:04PM"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Hour()*60 + t.Minute(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time %q, use HH:MM", value)
+}
+
+func formatClockTime(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func slotMinutes(offering *entities.ClinicOffering) int {
+	if offering.DurationMinutes > 0 {
+		return offering.DurationMinutes
+	}
+	return defaultClinicSlotMinutes
+}
+
+// clinicSlotTimes lists the start times, in minutes after midnight, of the slots
+// for an offering on a day. Slots run back to back through each opening range
+// and must end by closing time. The reason is set when the clinic is closed.
+func clinicSlotTimes(clinic *entities.Clinic, offering *entities.ClinicOffering, day time.Time) ([]int, string, error) {
+	date := day.Format(clinicDateLayout)
+	for _, holiday := range clinic.Holidays {
+		if holiday == date {
+			return nil, "holiday", nil
+		}
+	}
+
+	duration := slotMinutes(offering)
+	seen := map[int]bool{}
+	var starts []int
+	for _, hours := range clinic.OpeningHours {
+		if hours.Weekday != int(day.Weekday()) {
+			continue
+		}
+		open, err := parseClockTime(hours.Open)
+		if err != nil {
+			return nil, "", fmt.Errorf("clinic opening hours: %w", err)
+		}
+		closing, err := parseClockTime(hours.Close)
+		if err != nil {
+			return nil, "", fmt.Errorf("clinic opening hours: %w", err)
+		}
+		for start := open; start+duration <= closing; start += duration {
+			if !seen[start] {
+				seen[start] = true
+				starts = append(starts, start)
+			}
+		}
+	}
+	if len(starts) == 0 {
+		return nil, "closed", nil
+	}
+
+	sort.Ints(starts)
+	return starts, "", nil
+}
+
+// clinicAvailability works out which slots of an offering are free on a day,
+// given the clinic's active bookings that day. A slot is taken by a booking of
+// the same service at the same time, and slots that have started are not
+// offered. Once the daily capacity is booked no slot is available.
+func clinicAvailability(clinic *entities.Clinic, offering *entities.ClinicOffering, day time.Time, bookings []*entities.ClinicBooking, now time.Time) (*entities.ClinicAvailability, error) {
+	availability := &entities.ClinicAvailability{
+		ClinicID: clinic.ID,
+		Service:  offering.Name,
+		Date:     day.Format(clinicDateLayout),
+		Slots:    []entities.ClinicSlot{},
+	}
+
+	starts, reason, err := clinicSlotTimes(clinic, offering, day)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		availability.Reason = reason
+		return availability, nil
+	}
+	availability.Open = true
+
+	taken := map[string]bool{}
+	for _, booking := range bookings {
+		if !strings.EqualFold(booking.Service, offering.Name) {
+			continue
+		}
+		availability.Booked++
+		slot := booking.TimeSlot
+		if minutes, err := parseClockTime(slot); err == nil {
+			slot = formatClockTime(minutes)
+		}
+		taken[slot] = true
+	}
+
+	availability.Capacity = len(starts)
+	if offering.DailyCapacity > 0 {
+		availability.Capacity = offering.DailyCapacity
+	}
+	if availability.Remaining = availability.Capacity - availability.Booked; availability.Remaining <= 0 {
+		availability.Remaining = 0
+		availability.Reason = "fully_booked"
+	}
+
+	for _, start := range starts {
+		slot := formatClockTime(start)
+		begins := day.Add(time.Duration(start) * time.Minute)
+		availability.Slots = append(availability.Slots, entities.ClinicSlot{
+			Time:      slot,
+			Available: availability.Remaining > 0 && !taken[slot] && begins.After(now),
+		})
+	}
+
+	return availability, nil
+}
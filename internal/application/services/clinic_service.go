@@ -2,35 +2,293 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
 
+	"github.com/anshjamwal15/hsb_backend/internal/domain"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	ErrClinicNotFound        = errors.New("clinic not found")
+	ErrClinicSlotUnavailable = errors.New("time slot is not available")
+	ErrClinicBookingExpired  = errors.New("the booking was not paid in time and its slot was released, book again")
+)
+
+// An unpaid booking holds its slot this long, then another booking may take it
+const clinicPaymentHold = 15 * time.Minute
+
 type ClinicService struct {
-	clinicRepo repositories.ClinicRepository
+	clinicRepo     repositories.ClinicRepository
+	razorpayClient RazorpayClient
+	media          *MediaService
 }
 
-func NewClinicService(clinicRepo repositories.ClinicRepository) *ClinicService {
+func NewClinicService(clinicRepo repositories.ClinicRepository, razorpayClient RazorpayClient, media *MediaService) *ClinicService {
 	return &ClinicService{
-		clinicRepo: clinicRepo,
+		clinicRepo:     clinicRepo,
+		razorpayClient: razorpayClient,
+		media:          media,
+	}
+}
+
+// SearchClinics returns a page of clinics matching the query and the total
+// number of matches
+func (s *ClinicService) SearchClinics(ctx context.Context, query entities.GeoQuery) ([]*entities.Clinic, int64, error) {
+	if err := normalizeGeoQuery(&query); err != nil {
+		return nil, 0, err
+	}
+	clinics, total, err := s.clinicRepo.Search(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.signImages(ctx, clinics...)
+	return clinics, total, nil
+}
+
+// GetClinic returns a clinic with its catalog, opening hours and holidays
+func (s *ClinicService) GetClinic(ctx context.Context, clinicID string) (*entities.Clinic, error) {
+	clinic, err := s.findClinic(ctx, clinicID)
+	if err != nil {
+		return nil, err
 	}
+	s.signImages(ctx, clinic)
+	return clinic, nil
 }
 
-func (s *ClinicService) GetClinics(ctx context.Context) ([]*entities.Clinic, error) {
-	return s.clinicRepo.FindAll(ctx)
+func (s *ClinicService) signImages(ctx context.Context, clinics ...*entities.Clinic) {
+	for _, clinic := range clinics {
+		clinic.ImageURL, clinic.ThumbnailURL = s.media.ImageURLs(ctx, clinic.Image)
+	}
 }
 
-func (s *ClinicService) CreateBooking(ctx context.Context, booking *entities.ClinicBooking) error {
-	booking.PaymentStatus = "pending"
-	booking.Status = "pending"
-	return s.clinicRepo.CreateBooking(ctx, booking)
+// GetAvailability lists the slots of a clinic service on a YYYY-MM-DD date in
+// clinic time, marking those already booked or in the past as unavailable
+func (s *ClinicService) GetAvailability(ctx context.Context, clinicID, service, date string) (*entities.ClinicAvailability, error) {
+	if service == "" || date == "" {
+		return nil, errors.New("service and date are required")
+	}
+	day, err := parseClinicDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	clinic, offering, err := s.findOffering(ctx, clinicID, service)
+	if err != nil {
+		return nil, err
+	}
+	return s.availability(ctx, clinic, offering, day, time.Now())
+}
+
+// CreateBooking books a service at a clinic, charging the price from the clinic's
+// catalog, and creates the Razorpay order the client pays against
+func (s *ClinicService) CreateBooking(ctx context.Context, userID, clinicID, service string, date int64, timeSlot string) (*entities.ClinicBooking, error) {
+	if clinicID == "" || service == "" || timeSlot == "" {
+		return nil, errors.New("clinic ID, service and time slot are required")
+	}
+	now := time.Now()
+	day := clinicDay(time.Unix(date, 0))
+	if day.Before(clinicDay(now)) {
+		return nil, errors.New("booking date must not be in the past")
+	}
+	start, err := parseClockTime(timeSlot)
+	if err != nil {
+		return nil, err
+	}
+	slot := formatClockTime(start)
+
+	clinic, offering, err := s.findOffering(ctx, clinicID, service)
+	if err != nil {
+		return nil, err
+	}
+	if offering.Price <= 0 {
+		return nil, fmt.Errorf("service %q has no price set", offering.Name)
+	}
+
+	from, to := day.Unix(), day.AddDate(0, 0, 1).Unix()
+	unpaidSince := now.Add(-clinicPaymentHold).Unix()
+	if err := s.clinicRepo.ExpireUnpaidBookings(ctx, clinicID, from, to, unpaidSince); err != nil {
+		return nil, fmt.Errorf("error releasing unpaid bookings: %w", err)
+	}
+
+	availability, err := s.availability(ctx, clinic, offering, day, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSlotAvailable(availability, slot); err != nil {
+		return nil, err
+	}
+
+	// Bookings are stored against the start of the day in clinic time, with
+	// the slot in 24-hour form, so they line up with the availability slots
+	booking := &entities.ClinicBooking{
+		UserID:        userID,
+		ClinicID:      clinicID,
+		Service:       offering.Name,
+		Date:          day.Unix(),
+		TimeSlot:      slot,
+		Amount:        offering.Price,
+		PaymentStatus: "pending",
+		Status:        "pending",
+		SlotHold:      fmt.Sprintf("%s|%s|%d|%s", clinicID, strings.ToLower(offering.Name), day.Unix(), slot),
+	}
+	// The slot hold is unique, so of two requests for the same slot only one is stored
+	if err := s.clinicRepo.CreateBooking(ctx, booking); err != nil {
+		if errors.Is(err, domain.ErrDuplicateKey) {
+			return nil, fmt.Errorf("%s on %s: %w", slot, availability.Date, ErrClinicSlotUnavailable)
+		}
+		return nil, fmt.Errorf("failed to create booking: %w", err)
+	}
+
+	// Bookings for different slots can still race past the daily capacity, so
+	// the count is checked again now this one is stored
+	booked, err := s.clinicRepo.CountActiveBookings(ctx, clinicID, offering.Name, from, to, unpaidSince)
+	if err == nil && booked > int64(availability.Capacity) {
+		err = fmt.Errorf("%s is fully booked on %s: %w", availability.Service, availability.Date, ErrClinicSlotUnavailable)
+	}
+	if err != nil {
+		if deleteErr := s.clinicRepo.DeleteBooking(ctx, booking.ID); deleteErr != nil {
+			log.Printf("Failed to remove clinic booking %s over capacity: %v", booking.ID, deleteErr)
+		}
+		return nil, err
+	}
+
+	// The booking is stored first so the receipt can name it. Without an order
+	// it could never be paid, so it is removed again if the order fails.
+	orderID, err := s.razorpayClient.CreateOrder(booking.Amount*100, "INR", "clinic_"+booking.ID)
+	if err == nil {
+		err = s.clinicRepo.SetBookingOrder(ctx, booking.ID, orderID)
+	}
+	if err != nil {
+		if deleteErr := s.clinicRepo.DeleteBooking(ctx, booking.ID); deleteErr != nil {
+			log.Printf("Failed to remove unpayable clinic booking %s: %v", booking.ID, deleteErr)
+		}
+		return nil, fmt.Errorf("failed to create Razorpay order: %w", err)
+	}
+	booking.RazorpayOrderID = orderID
+
+	return booking, nil
 }
 
 func (s *ClinicService) GetMyBookings(ctx context.Context, userID string) ([]*entities.ClinicBooking, error) {
 	return s.clinicRepo.FindBookingsByUserID(ctx, userID)
 }
 
-func (s *ClinicService) VerifyPayment(ctx context.Context, bookingID, paymentID string) error {
-	return s.clinicRepo.UpdateBookingPayment(ctx, bookingID, paymentID, "completed")
+// VerifyPayment checks the Razorpay payment against the booking's order and
+// confirms the booking while its slot is still held for it
+func (s *ClinicService) VerifyPayment(ctx context.Context, bookingID, orderID, paymentID, signature string) error {
+	if !primitive.IsValidObjectID(bookingID) {
+		return errors.New("booking not found")
+	}
+	booking, err := s.clinicRepo.FindBookingByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+	if booking == nil {
+		return errors.New("booking not found")
+	}
+	if err := verifyRazorpayPayment(s.razorpayClient, booking.RazorpayOrderID, orderID, paymentID, signature); err != nil {
+		return err
+	}
+
+	updated, err := s.clinicRepo.UpdateBookingPayment(ctx, bookingID, paymentID, "completed")
+	if err != nil {
+		return err
+	}
+	if updated {
+		return nil
+	}
+
+	// No longer pending: see what happened to it meanwhile
+	booking, err = s.clinicRepo.FindBookingByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case booking == nil:
+		return errors.New("booking not found")
+	case booking.PaymentStatus == "completed":
+		return nil // already verified
+	case booking.Status == "expired":
+		return ErrClinicBookingExpired
+	}
+	return fmt.Errorf("booking is %s and cannot be paid", booking.Status)
+}
+
+func (s *ClinicService) findClinic(ctx context.Context, clinicID string) (*entities.Clinic, error) {
+	if !primitive.IsValidObjectID(clinicID) {
+		return nil, ErrClinicNotFound
+	}
+	clinic, err := s.clinicRepo.FindByID(ctx, clinicID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching clinic: %w", err)
+	}
+	if clinic == nil {
+		return nil, ErrClinicNotFound
+	}
+	return clinic, nil
+}
+
+func (s *ClinicService) findOffering(ctx context.Context, clinicID, service string) (*entities.Clinic, *entities.ClinicOffering, error) {
+	clinic, err := s.findClinic(ctx, clinicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	offering := clinicOffering(clinic, service)
+	if offering == nil {
+		return nil, nil, fmt.Errorf("service %q is not offered by this clinic", service)
+	}
+	return clinic, offering, nil
+}
+
+func (s *ClinicService) availability(ctx context.Context, clinic *entities.Clinic, offering *entities.ClinicOffering, day, now time.Time) (*entities.ClinicAvailability, error) {
+	unpaidSince := now.Add(-clinicPaymentHold).Unix()
+	bookings, err := s.clinicRepo.FindActiveBookings(ctx, clinic.ID, day.Unix(), day.AddDate(0, 0, 1).Unix(), unpaidSince)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching clinic bookings: %w", err)
+	}
+	return clinicAvailability(clinic, offering, day, bookings, now)
+}
+
+// checkSlotAvailable explains why a slot cannot be booked, or returns nil
+func checkSlotAvailable(availability *entities.ClinicAvailability, slot string) error {
+	switch availability.Reason {
+	case "holiday":
+		return fmt.Errorf("clinic is closed for a holiday on %s", availability.Date)
+	case "closed":
+		return fmt.Errorf("clinic is closed on %s", availability.Date)
+	case "fully_booked":
+		return fmt.Errorf("%s is fully booked on %s: %w", availability.Service, availability.Date, ErrClinicSlotUnavailable)
+	}
+	for _, s := range availability.Slots {
+		if s.Time != slot {
+			continue
+		}
+		if !s.Available {
+			return fmt.Errorf("%s on %s: %w", slot, availability.Date, ErrClinicSlotUnavailable)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s is not a slot for %s on %s", slot, availability.Service, availability.Date)
+}
+
+// clinicOffering finds a service in the clinic's catalog by name, ignoring case
+func clinicOffering(clinic *entities.Clinic, service string) *entities.ClinicOffering {
+	for i := range clinic.Catalog {
+		if strings.EqualFold(clinic.Catalog[i].Name, strings.TrimSpace(service)) {
+			return &clinic.Catalog[i]
+		}
+	}
+	return nil
+}
+
+// startOfToday is midnight at the start of the current day, local time
+func startOfToday() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 }
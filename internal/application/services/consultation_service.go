@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+	"github.com/anshjamwal15/hsb_backend/pkg/agora"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrCallsNotConfigured  = errors.New("calls are not set up on this server")
+	ErrCallBookingNotFound = errors.New("booking not found")
+	ErrNotCallParticipant  = errors.New("only the patient and doctor of a booking can join its call")
+	ErrNotACall            = errors.New("only video and audio call bookings have a call")
+	ErrCallNotConfirmed    = errors.New("only confirmed, paid bookings have a call")
+	ErrCallNotOpen         = errors.New("the call is not open yet")
+	ErrCallClosed          = errors.New("the call has ended")
+	ErrNotInCall           = errors.New("you have not joined the call")
+	ErrInvalidCallEvent    = errors.New("type must be joined or left")
+)
+
+const (
+	// The call opens a little before the slot and closes a while after it, so
+	// a late start can still run its full length
+	callOpensBefore = 10 * time.Minute
+	callClosesAfter = 15 * time.Minute
+
+	// Calls are settled from attendance only this far back. Older bookings,
+	// such as those from before calls were held in the app, are left as they are.
+	callSettleLookback = 3 * 24 * time.Hour
+
+	patientCallUID uint32 = 1
+	doctorCallUID  uint32 = 2
+)
+
+type ConsultationService struct {
+	consultationRepo repositories.ConsultationRepository
+	bookingRepo      repositories.BookingRepository
+	doctorService    *DoctorService
+	agoraAppID       string
+	agoraAppCert     string
+}
+
+func NewConsultationService(
+	consultationRepo repositories.ConsultationRepository,
+	bookingRepo repositories.BookingRepository,
+	doctorService *DoctorService,
+	agoraAppID, agoraAppCert string,
+) *ConsultationService {
+	return &ConsultationService{
+		consultationRepo: consultationRepo,
+		bookingRepo:      bookingRepo,
+		doctorService:    doctorService,
+		agoraAppID:       agoraAppID,
+		agoraAppCert:     agoraAppCert,
+	}
+}
+
+// IssueToken gives the patient or doctor of a confirmed, paid call booking an
+// Agora token for its channel. Tokens are only issued while the call is open
+// and expire when it closes.
+func (s *ConsultationService) IssueToken(ctx context.Context, userID, bookingID string) (*entities.ConsultationToken, error) {
+	if s.agoraAppID == "" || s.agoraAppCert == "" {
+		return nil, ErrCallsNotConfigured
+	}
+	booking, role, err := s.callBooking(ctx, userID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	opens, closes, err := callWindow(booking)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := checkCallOpen(opens, closes, now); err != nil {
+		return nil, err
+	}
+
+	uid := patientCallUID
+	if role == "doctor" {
+		uid = doctorCallUID
+	}
+	channel := callChannel(booking)
+	token, err := agora.RTCToken(s.agoraAppID, s.agoraAppCert, channel, uid, closes.Sub(now))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build call token: %w", err)
+	}
+
+	return &entities.ConsultationToken{
+		AppID:     s.agoraAppID,
+		Channel:   channel,
+		UID:       uid,
+		Token:     token,
+		Role:      role,
+		OpensAt:   opens,
+		ExpiresAt: closes,
+	}, nil
+}
+
+// RecordEvent records the user joining or leaving a booking's call. Joining
+// needs the call to be open; leaving needs an earlier join and records how
+// long the user was in the call, up to its close.
+func (s *ConsultationService) RecordEvent(ctx context.Context, userID, bookingID, eventType string) (*entities.ConsultationEvent, error) {
+	if eventType != "joined" && eventType != "left" {
+		return nil, ErrInvalidCallEvent
+	}
+	booking, role, err := s.callBooking(ctx, userID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	opens, closes, err := callWindow(booking)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	last, err := s.consultationRepo.FindLastEvent(ctx, booking.ID, role)
+	if err != nil {
+		return nil, err
+	}
+	if eventType == "joined" {
+		if err := checkCallOpen(opens, closes, now); err != nil {
+			return nil, err
+		}
+	} else if last == nil || last.Type != "joined" {
+		return nil, ErrNotInCall
+	}
+
+	userOID, _ := primitive.ObjectIDFromHex(userID)
+	event := &entities.ConsultationEvent{
+		BookingID: booking.ID,
+		UserID:    userOID,
+		Role:      role,
+		Type:      eventType,
+		At:        now,
+	}
+	if eventType == "left" {
+		event.DurationSeconds = callSeconds(last.At, now, closes)
+	}
+	if err := s.consultationRepo.CreateEvent(ctx, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// GetAttendance returns a booking's call events and how long the patient and
+// doctor have been in the call, for either of them
+func (s *ConsultationService) GetAttendance(ctx context.Context, userID, bookingID string) (*entities.ConsultationAttendance, error) {
+	booking, err := s.findBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.participantRole(ctx, userID, booking); err != nil {
+		return nil, err
+	}
+	if booking.SessionType != entities.SessionVideoCall && booking.SessionType != entities.SessionAudioCall {
+		return nil, ErrNotACall
+	}
+	opens, closes, err := callWindow(booking)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.consultationRepo.FindEvents(ctx, booking.ID)
+	if err != nil {
+		return nil, err
+	}
+	attendance := &entities.ConsultationAttendance{
+		BookingID: booking.ID,
+		Status:    booking.Status,
+		NoShowBy:  booking.NoShowBy,
+		OpensAt:   opens,
+		ClosesAt:  closes,
+		Events:    events,
+	}
+	for _, event := range events {
+		if event.Role == "doctor" {
+			attendance.DoctorSeconds += event.DurationSeconds
+		} else {
+			attendance.PatientSeconds += event.DurationSeconds
+		}
+	}
+	return attendance, nil
+}
+
+// SettleCalls marks the paid calls that have closed as completed, when both
+// the patient and the doctor joined, or as a no-show naming who did not. Anyone
+// still in the call is recorded as leaving when it closed.
+func (s *ConsultationService) SettleCalls(ctx context.Context) (int, error) {
+	now := time.Now()
+	from := now.Add(-callSettleLookback).UTC().Truncate(24 * time.Hour)
+	bookings, err := s.bookingRepo.FindUnsettledCalls(ctx, from, now)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, booking := range bookings {
+		_, closes, err := callWindow(booking)
+		if err != nil {
+			log.Printf("Failed to settle call %s: %v", booking.ID.Hex(), err)
+			continue
+		}
+		if now.Before(closes) {
+			continue
+		}
+
+		events, err := s.consultationRepo.FindEvents(ctx, booking.ID)
+		if err != nil {
+			log.Printf("Failed to load events of call %s: %v", booking.ID.Hex(), err)
+			continue
+		}
+		for _, open := range openCallEvents(events) {
+			left := &entities.ConsultationEvent{
+				BookingID:       booking.ID,
+				UserID:          open.UserID,
+				Role:            open.Role,
+				Type:            "left",
+				At:              closes,
+				DurationSeconds: callSeconds(open.At, closes, closes),
+			}
+			if err := s.consultationRepo.CreateEvent(ctx, left); err != nil {
+				log.Printf("Failed to close the %s's time in call %s: %v", open.Role, booking.ID.Hex(), err)
+			}
+		}
+
+		status, noShowBy := callOutcome(events)
+		ok, err := s.bookingRepo.SettleCall(ctx, booking.ID.Hex(), status, noShowBy)
+		if err != nil {
+			log.Printf("Failed to settle call %s: %v", booking.ID.Hex(), err)
+			continue
+		}
+		if ok {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+// Run settles closed calls at the given interval until the context is done
+func (s *ConsultationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if settled, err := s.SettleCalls(ctx); err != nil {
+			log.Printf("Failed to settle calls: %v", err)
+		} else if settled > 0 {
+			log.Printf("Settled %d calls", settled)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// callBooking loads a call booking that can still be joined, with the user's
+// role in it
+func (s *ConsultationService) callBooking(ctx context.Context, userID, bookingID string) (*entities.Booking, string, error) {
+	booking, err := s.findBooking(ctx, bookingID)
+	if err != nil {
+		return nil, "", err
+	}
+	role, err := s.participantRole(ctx, userID, booking)
+	if err != nil {
+		return nil, "", err
+	}
+	if booking.SessionType != entities.SessionVideoCall && booking.SessionType != entities.SessionAudioCall {
+		return nil, "", ErrNotACall
+	}
+	switch {
+	case booking.Status == "completed" || booking.Status == "no_show":
+		return nil, "", ErrCallClosed
+	case booking.PaymentStatus != "paid" || (booking.Status != "pending" && booking.Status != "confirmed"):
+		return nil, "", ErrCallNotConfirmed
+	}
+	return booking, role, nil
+}
+
+func (s *ConsultationService) findBooking(ctx context.Context, bookingID string) (*entities.Booking, error) {
+	if !primitive.IsValidObjectID(bookingID) {
+		return nil, ErrCallBookingNotFound
+	}
+	booking, err := s.bookingRepo.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil {
+		return nil, ErrCallBookingNotFound
+	}
+	return booking, nil
+}
+
+// participantRole is patient for the user who booked, or doctor for the user
+// whose account the booked doctor's profile is linked to
+func (s *ConsultationService) participantRole(ctx context.Context, userID string, booking *entities.Booking) (string, error) {
+	if booking.UserID.Hex() == userID {
+		return "patient", nil
+	}
+	doctor, err := s.doctorService.DoctorForUser(ctx, userID)
+	if errors.Is(err, ErrNoDoctorProfile) {
+		return "", ErrNotCallParticipant
+	}
+	if err != nil {
+		return "", err
+	}
+	if doctor.ID != booking.DoctorID.Hex() {
+		return "", ErrNotCallParticipant
+	}
+	return "doctor", nil
+}
+
+// callWindow is when a booking's call is open. Slots are times in IST, such as
+// "10:00" or "10:00-10:30", on the booking's day; a slot without an end lasts
+// the usual consultation length.
+func callWindow(booking *entities.Booking) (opens, closes time.Time, err error) {
+	start, end, _ := strings.Cut(booking.TimeSlot, "-")
+	startMinutes, err := parseClockTime(start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("booking time slot: %w", err)
+	}
+	endMinutes := startMinutes + entities.ConsultationSlotMinutes
+	if strings.TrimSpace(end) != "" {
+		if endMinutes, err = parseClockTime(end); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("booking time slot: %w", err)
+		}
+	}
+
+	date := booking.Date.UTC()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, clinicTimezone)
+	opens = day.Add(time.Duration(startMinutes)*time.Minute - callOpensBefore)
+	closes = day.Add(time.Duration(endMinutes)*time.Minute + callClosesAfter)
+	return opens, closes, nil
+}
+
+func checkCallOpen(opens, closes, now time.Time) error {
+	if now.Before(opens) {
+		return fmt.Errorf("%w, it opens at %s", ErrCallNotOpen, opens.In(clinicTimezone).Format("2006-01-02 15:04 MST"))
+	}
+	if !now.Before(closes) {
+		return ErrCallClosed
+	}
+	return nil
+}
+
+func callChannel(booking *entities.Booking) string {
+	return "booking_" + booking.ID.Hex()
+}
+
+// callSeconds is the time from joined to left, counting no further than the
+// call's close
+func callSeconds(joined, left, closes time.Time) int {
+	if left.After(closes) {
+		left = closes
+	}
+	if !left.After(joined) {
+		return 0
+	}
+	return int(left.Sub(joined) / time.Second)
+}
+
+// openCallEvents returns the joins of participants who have not left since
+func openCallEvents(events []*entities.ConsultationEvent) []*entities.ConsultationEvent {
+	last := map[string]*entities.ConsultationEvent{}
+	for _, event := range events {
+		last[event.Role] = event
+	}
+	var open []*entities.ConsultationEvent
+	for _, role := range []string{"patient", "doctor"} {
+		if event := last[role]; event != nil && event.Type == "joined" {
+			open = append(open, event)
+		}
+	}
+	return open
+}
+
+// callOutcome is completed when both the patient and the doctor joined the
+// call, otherwise no_show with who did not join
+func callOutcome(events []*entities.ConsultationEvent) (status, noShowBy string) {
+	joined := map[string]bool{}
+	for _, event := range events {
+		if event.Type == "joined" {
+			joined[event.Role] = true
+		}
+	}
+	switch {
+	case joined["patient"] && joined["doctor"]:
+		return "completed", ""
+	case joined["patient"]:
+		return "no_show", "doctor"
+	case joined["doctor"]:
+		return "no_show", "patient"
+	}
+	return "no_show", "both"
+}
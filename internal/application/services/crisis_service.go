@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+)
+
+// Notifier delivers a notification to a channel such as the care team
+type Notifier interface {
+	Notify(ctx context.Context, notification entities.Notification) error
+}
+
+const (
+	EscalationSeverityCritical = "critical"
+	EscalationSeverityHigh     = "high"
+)
+
+// crisisHelplines returns the helplines shown to users with a critical or
+// high-alert result, described in the given locale
+func crisisHelplines(locale string) []entities.CrisisHelpline {
+	return []entities.CrisisHelpline{
+		{Name: "Tele-MANAS", Phone: "14416", Availability: "24x7",
+			Description: i18n.Message(locale, i18n.MsgHelplineTeleMANAS)},
+		{Name: "Emergency services", Phone: "112", Availability: "24x7",
+			Description: i18n.Message(locale, i18n.MsgHelplineEmergency)},
+	}
+}
+
+type CrisisService struct {
+	crisisRepo      repositories.CrisisRepository
+	notifier        Notifier
+	careTeamChannel string
+}
+
+func NewCrisisService(crisisRepo repositories.CrisisRepository, notifier Notifier, careTeamChannel string) *CrisisService {
+	return &CrisisService{
+		crisisRepo:      crisisRepo,
+		notifier:        notifier,
+		careTeamChannel: careTeamChannel,
+	}
+}
+
+// Escalate records an escalation event and notifies the care team when a stored result
+// answered a critical item above 0 or fell in a high-alert band. It returns the crisis
+// information to show the user, or nil when the result needs no escalation. Failures
+// to record or notify are logged rather than returned so the user always gets the
+// helpline information. Messages are in the locale of the result.
+func (s *CrisisService) Escalate(ctx context.Context, test *entities.MentalHealthTest, result *entities.TestResult) *entities.CrisisResponse {
+	escalation := &entities.CrisisEscalation{
+		UserID:      result.UserID,
+		ResultID:    result.ID,
+		TestName:    result.TestName,
+		TestVersion: result.TestVersion,
+		Score:       result.ObtainedScore,
+		Level:       result.Level,
+		Status:      "open",
+		Channel:     s.careTeamChannel,
+	}
+
+	response := &entities.CrisisResponse{Helplines: crisisHelplines(result.Locale)}
+
+	switch {
+	case result.Critical:
+		escalation.Severity = EscalationSeverityCritical
+		escalation.CriticalItems = answeredCriticalItems(test, result)
+		escalation.Reason = fmt.Sprintf("critical item answered: %s", strings.Join(escalation.CriticalItems, ", "))
+		response.Message = result.CriticalNote
+		if response.Message == "" {
+			response.Message = i18n.Message(result.Locale, i18n.MsgCrisisCritical)
+		}
+	case result.AlertLevel == "high":
+		escalation.Severity = EscalationSeverityHigh
+		escalation.Reason = fmt.Sprintf("score %d in %s band", result.ObtainedScore, result.Level)
+		response.Message = i18n.Message(result.Locale, i18n.MsgCrisisHigh)
+	default:
+		return nil
+	}
+	response.Severity = escalation.Severity
+
+	if err := s.crisisRepo.Create(ctx, escalation); err != nil {
+		log.Printf("Failed to record crisis escalation for result %s: %v", result.ID.Hex(), err)
+		return response
+	}
+	response.EscalationID = escalation.ID.Hex()
+
+	notification := entities.Notification{
+		Channel: s.careTeamChannel,
+		Subject: fmt.Sprintf("%s escalation: %s", strings.ToUpper(escalation.Severity), escalation.TestName),
+		Body:    escalation.Reason,
+		Data: map[string]string{
+			"escalationId": escalation.ID.Hex(),
+			"userId":       escalation.UserID.Hex(),
+			"resultId":     escalation.ResultID.Hex(),
+			"test":         escalation.TestName,
+			"score":        strconv.Itoa(escalation.Score),
+			"level":        escalation.Level,
+		},
+	}
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		escalation.Status = "notify_failed"
+		escalation.NotifyError = err.Error()
+	} else {
+		now := time.Now()
+		escalation.Status = "notified"
+		escalation.NotifiedAt = &now
+	}
+
+	if err := s.crisisRepo.Update(ctx, escalation); err != nil {
+		log.Printf("Failed to update crisis escalation %s: %v", escalation.ID.Hex(), err)
+	}
+
+	return response
+}
+
+func answeredCriticalItems(test *entities.MentalHealthTest, result *entities.TestResult) []string {
+	var items []string
+	for _, item := range test.CriticalItems {
+		key, ok := item.(string)
+		if !ok {
+			continue
+		}
+		if answer, ok := result.Answers[key].(float64); ok && answer > 0 {
+			items = append(items, key)
+		}
+	}
+	return items
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrNotLab                  = errors.New("only lab accounts can manage home collections")
+	ErrCollectionNotFound      = errors.New("home collection not found")
+	ErrCollectionStatusChanged = errors.New("home collection status changed, reload and try again")
+)
+
+// Home collections move through these statuses in order, one step at a time
+var collectionStatuses = []string{"scheduled", "en_route", "collected", "in_lab", "report_ready"}
+
+// A window can be booked until this long before it starts, so the lab has
+// time to send someone
+const homeCollectionLeadTime = time.Hour
+
+// HomeCollectionAvailability reports whether a lab collects samples at a
+// pincode and which of its windows on a YYYY-MM-DD date can still be booked
+func (s *DiagnosticService) HomeCollectionAvailability(ctx context.Context, labID, pincode, date string) (*entities.HomeCollectionAvailability, error) {
+	if pincode == "" || date == "" {
+		return nil, errors.New("pincode and date are required")
+	}
+	day, err := parseClinicDate(date)
+	if err != nil {
+		return nil, err
+	}
+	lab, err := s.GetLab(ctx, labID)
+	if err != nil {
+		return nil, err
+	}
+
+	availability := &entities.HomeCollectionAvailability{
+		LabID:   labID,
+		Pincode: pincode,
+		Date:    date,
+		Windows: []entities.CollectionWindowSlot{},
+	}
+	if !collectsAt(lab, pincode) {
+		return availability, nil
+	}
+	availability.Serviceable = true
+	availability.Fee = lab.HomeCollection.Fee
+
+	windows, err := collectionWindows(lab.HomeCollection, day)
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().Add(homeCollectionLeadTime)
+	for _, window := range windows {
+		availability.Windows = append(availability.Windows, entities.CollectionWindowSlot{
+			Window:    window.label,
+			Available: window.start.After(cutoff),
+		})
+	}
+	return availability, nil
+}
+
+// AddPhlebotomist adds a sample collector to the signed-in lab
+func (s *DiagnosticService) AddPhlebotomist(ctx context.Context, userID, name, phone string) (*entities.Phlebotomist, error) {
+	lab, err := s.labForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) == "" || strings.TrimSpace(phone) == "" {
+		return nil, errors.New("name and phone are required")
+	}
+
+	phlebotomist := &entities.Phlebotomist{
+		LabID:    lab.ID,
+		Name:     strings.TrimSpace(name),
+		Phone:    strings.TrimSpace(phone),
+		IsActive: true,
+	}
+	if err := s.labRepo.CreatePhlebotomist(ctx, phlebotomist); err != nil {
+		return nil, err
+	}
+	return phlebotomist, nil
+}
+
+func (s *DiagnosticService) GetPhlebotomists(ctx context.Context, userID string) ([]*entities.Phlebotomist, error) {
+	lab, err := s.labForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.labRepo.FindPhlebotomists(ctx, lab.ID)
+}
+
+// GetHomeCollections lists the signed-in lab's home collections with a window
+// on a YYYY-MM-DD date
+func (s *DiagnosticService) GetHomeCollections(ctx context.Context, userID, date string) ([]*entities.DiagnosticsBooking, error) {
+	lab, err := s.labForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	day, err := parseClinicDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.diagnosticRepo.FindHomeCollections(ctx, lab.ID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	if bookings == nil {
+		bookings = []*entities.DiagnosticsBooking{}
+	}
+	return bookings, nil
+}
+
+// AssignPhlebotomist assigns one of the lab's active phlebotomists to a home
+// collection that has not been collected yet
+func (s *DiagnosticService) AssignPhlebotomist(ctx context.Context, userID, bookingID, phlebotomistID string) (*entities.DiagnosticsBooking, error) {
+	lab, err := s.labForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := s.labHomeCollection(ctx, lab, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if status := booking.HomeCollection.Status; status != "scheduled" && status != "en_route" {
+		return nil, fmt.Errorf("a phlebotomist cannot be assigned once the sample is %s", strings.ReplaceAll(status, "_", " "))
+	}
+
+	if !primitive.IsValidObjectID(phlebotomistID) {
+		return nil, errors.New("phlebotomist not found")
+	}
+	phlebotomist, err := s.labRepo.FindPhlebotomistByID(ctx, phlebotomistID)
+	if err != nil {
+		return nil, err
+	}
+	if phlebotomist == nil || phlebotomist.LabID != lab.ID || !phlebotomist.IsActive {
+		return nil, errors.New("phlebotomist not found")
+	}
+
+	if err := s.diagnosticRepo.AssignPhlebotomist(ctx, bookingID, phlebotomist.ID); err != nil {
+		return nil, err
+	}
+	booking.HomeCollection.PhlebotomistID = phlebotomist.ID
+	return booking, nil
+}
+
+// UpdateCollectionStatus moves a home collection to the next status. It can
+// only leave "scheduled" once paid and assigned, and the booking is completed
+// when the report is ready.
+func (s *DiagnosticService) UpdateCollectionStatus(ctx context.Context, userID, bookingID, status, note string) (*entities.DiagnosticsBooking, error) {
+	lab, err := s.labForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := s.labHomeCollection(ctx, lab, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := booking.HomeCollection.Status
+	if next := nextCollectionStatus(current); status != next {
+		if next == "" {
+			return nil, fmt.Errorf("the collection is already %s", current)
+		}
+		return nil, fmt.Errorf("the next status after %s is %s", current, next)
+	}
+	if current == "scheduled" {
+		if booking.PaymentStatus != "completed" {
+			return nil, errors.New("the booking has not been paid")
+		}
+		if booking.HomeCollection.PhlebotomistID.IsZero() {
+			return nil, errors.New("assign a phlebotomist first")
+		}
+	}
+
+	change := entities.CollectionStatusChange{
+		Status: status,
+		Note:   strings.TrimSpace(note),
+		At:     time.Now(),
+	}
+	bookingStatus := ""
+	if status == "report_ready" {
+		bookingStatus = "completed"
+	}
+	updated, err := s.diagnosticRepo.UpdateCollectionStatus(ctx, bookingID, current, change, bookingStatus)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrCollectionStatusChanged
+	}
+
+	booking.HomeCollection.Status = status
+	booking.HomeCollection.History = append(booking.HomeCollection.History, change)
+	if bookingStatus != "" {
+		booking.Status = bookingStatus
+	}
+	return booking, nil
+}
+
+// labForUser resolves the lab linked to the user's account
+func (s *DiagnosticService) labForUser(ctx context.Context, userID string) (*entities.DiagnosticsUser, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	lab, err := s.labRepo.FindByUserID(ctx, userOID)
+	if err != nil {
+		return nil, err
+	}
+	if lab == nil {
+		return nil, ErrNotLab
+	}
+	return lab, nil
+}
+
+// labHomeCollection loads a home collection booking at the lab
+func (s *DiagnosticService) labHomeCollection(ctx context.Context, lab *entities.DiagnosticsUser, bookingID string) (*entities.DiagnosticsBooking, error) {
+	if !primitive.IsValidObjectID(bookingID) {
+		return nil, ErrCollectionNotFound
+	}
+	booking, err := s.diagnosticRepo.FindBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil || booking.LabID != lab.ID || booking.HomeCollection == nil || booking.Status == "cancelled" {
+		return nil, ErrCollectionNotFound
+	}
+	return booking, nil
+}
+
+// scheduleHomeCollection turns a booking into a home collection in one of the
+// lab's windows on the booked day, adding the collection fee
+func scheduleHomeCollection(booking *entities.DiagnosticsBooking, lab *entities.DiagnosticsUser, offering *entities.LabOffering, address *entities.ServiceAddress, now time.Time) error {
+	if !offering.HomeCollection {
+		return fmt.Errorf("%s cannot be collected at home", offering.TestName)
+	}
+	if strings.TrimSpace(address.Line1) == "" || strings.TrimSpace(address.City) == "" || strings.TrimSpace(address.Phone) == "" {
+		return errors.New("address line1, city and phone are required for home collection")
+	}
+	if !collectsAt(lab, address.Pincode) {
+		return fmt.Errorf("this lab does not collect samples at pincode %s", address.Pincode)
+	}
+
+	windows, err := collectionWindows(lab.HomeCollection, clinicDay(booking.Date))
+	if err != nil {
+		return err
+	}
+	var chosen *collectionWindow
+	for i := range windows {
+		if windows[i].label == normalizeWindowLabel(booking.Time) {
+			chosen = &windows[i]
+			break
+		}
+	}
+	if chosen == nil {
+		return fmt.Errorf("%q is not one of this lab's collection windows", booking.Time)
+	}
+	if !chosen.start.After(now.Add(homeCollectionLeadTime)) {
+		return errors.New("that collection window can no longer be booked")
+	}
+
+	address.Pincode = strings.TrimSpace(address.Pincode)
+	booking.CollectionType = "home"
+	booking.Date = chosen.start
+	booking.Time = chosen.label
+	booking.CollectionFee = lab.HomeCollection.Fee
+	booking.Amount += lab.HomeCollection.Fee
+	booking.HomeCollection = &entities.HomeCollection{
+		Address:     *address,
+		WindowStart: chosen.start,
+		WindowEnd:   chosen.end,
+		Status:      collectionStatuses[0],
+		History: []entities.CollectionStatusChange{
+			{Status: collectionStatuses[0], At: now},
+		},
+	}
+	return nil
+}
+
+// collectsAt reports whether the lab collects samples at home at a pincode
+func collectsAt(lab *entities.DiagnosticsUser, pincode string) bool {
+	if lab.HomeCollection == nil {
+		return false
+	}
+	pincode = strings.TrimSpace(pincode)
+	for _, serviceable := range lab.HomeCollection.Pincodes {
+		if serviceable == pincode {
+			return true
+		}
+	}
+	return false
+}
+
+type collectionWindow struct {
+	label      string
+	start, end time.Time
+}
+
+// collectionWindows places the lab's daily windows on a day in IST
+func collectionWindows(settings *entities.HomeCollectionSettings, day time.Time) ([]collectionWindow, error) {
+	windows := make([]collectionWindow, 0, len(settings.Windows))
+	for _, window := range settings.Windows {
+		start, err := parseClockTime(window.Start)
+		if err != nil {
+			return nil, fmt.Errorf("lab collection windows: %w", err)
+		}
+		end, err := parseClockTime(window.End)
+		if err != nil {
+			return nil, fmt.Errorf("lab collection windows: %w", err)
+		}
+		windows = append(windows, collectionWindow{
+			label: formatClockTime(start) + "-" + formatClockTime(end),
+			start: day.Add(time.Duration(start) * time.Minute),
+			end:   day.Add(time.Duration(end) * time.Minute),
+		})
+	}
+	return windows, nil
+}
+
+// normalizeWindowLabel rewrites a "7:00 - 9:00" style window as "07:00-09:00"
+func normalizeWindowLabel(label string) string {
+	start, end, found := strings.Cut(label, "-")
+	if !found {
+		return label
+	}
+	startMinutes, err := parseClockTime(start)
+	if err != nil {
+		return label
+	}
+	endMinutes, err := parseClockTime(end)
+	if err != nil {
+		return label
+	}
+	return formatClockTime(startMinutes) + "-" + formatClockTime(endMinutes)
+}
+
+func nextCollectionStatus(status string) string {
+	for i, s := range collectionStatuses {
+		if s == status && i+1 < len(collectionStatuses) {
+			return collectionStatuses[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrLabReportNotFound          = errors.New("lab report not found")
+	ErrDiagnosticsBookingNotFound = errors.New("booking not found")
+)
+
+// analyteSpec names an analyte the app knows, with the unit labs usually
+// report it in
+type analyteSpec struct {
+	code string
+	name string
+	unit string
+}
+
+// Known analytes by analyteKey of their code or common names. Other analytes
+// are accepted under the lab's own code and name.
+var knownAnalytes = map[string]analyteSpec{
+	"lh":                 {code: "LH", name: "Luteinizing hormone", unit: "mIU/mL"},
+	"luteinizinghormone": {code: "LH", name: "Luteinizing hormone", unit: "mIU/mL"},
+	"fsh":                {code: "FSH", name: "Follicle-stimulating hormone", unit: "mIU/mL"},
+	"amh":                {code: "AMH", name: "Anti-Müllerian hormone", unit: "ng/mL"},
+	"tsh":                {code: "TSH", name: "Thyroid-stimulating hormone", unit: "µIU/mL"},
+	"testosterone":       {code: "TESTOSTERONE", name: "Testosterone, total", unit: "ng/dL"},
+	"totaltestosterone":  {code: "TESTOSTERONE", name: "Testosterone, total", unit: "ng/dL"},
+	"hba1c":              {code: "HBA1C", name: "HbA1c", unit: "%"},
+	"glycatedhemoglobin": {code: "HBA1C", name: "HbA1c", unit: "%"},
+}
+
+// UploadReport stores a lab's PDF report for one of its paid bookings, with
+// any analyte values read from it, and completes the booking. Home collections
+// must have reached the lab, and move to report_ready. Uploading again
+// replaces the report.
+func (s *DiagnosticService) UploadReport(ctx context.Context, userID, bookingID string, report *entities.LabReport, file io.Reader) (*entities.LabReport, error) {
+	lab, err := s.labForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := s.labBooking(ctx, lab, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.PaymentStatus != "completed" {
+		return nil, errors.New("the booking has not been paid")
+	}
+	if collection := booking.HomeCollection; collection != nil && collection.Status != "in_lab" && collection.Status != "report_ready" {
+		return nil, errors.New("the sample has not reached the lab yet")
+	}
+
+	if err := normalizeAnalytes(report.Analytes); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.media.StoreDocument(ctx, "lab-reports/"+booking.UserID.Hex(), file)
+	if err != nil {
+		return nil, err
+	}
+
+	report.BookingID = booking.ID
+	report.UserID = booking.UserID
+	report.LabID = lab.ID
+	report.DiagnosticsID = booking.DiagnosticsID
+	report.TestName = booking.TestName
+	report.SampleDate = booking.Date
+	report.FileKey = stored.Key
+	report.FileName = reportFileName(report.FileName)
+	report.FileSize = stored.Size
+	report.Notes = strings.TrimSpace(report.Notes)
+	if report.Analytes == nil {
+		report.Analytes = []entities.AnalyteResult{}
+	}
+	report.AbnormalCount = 0
+	for _, analyte := range report.Analytes {
+		if analyte.Flag == "low" || analyte.Flag == "high" {
+			report.AbnormalCount++
+		}
+	}
+
+	existing, err := s.labReportRepo.FindByBookingID(ctx, booking.ID)
+	if err == nil {
+		if existing != nil {
+			report.ID = existing.ID
+			report.CreatedAt = existing.CreatedAt
+			err = s.labReportRepo.Replace(ctx, report)
+		} else {
+			err = s.labReportRepo.Create(ctx, report)
+		}
+	}
+	if err != nil {
+		s.media.Delete(ctx, stored.Key)
+		return nil, fmt.Errorf("failed to save report: %w", err)
+	}
+	if existing != nil {
+		s.media.Delete(ctx, existing.FileKey)
+	}
+	report.FileURL = stored.URL
+
+	if err := s.completeReportedBooking(ctx, booking); err != nil {
+		return nil, fmt.Errorf("report saved but the booking could not be completed: %w", err)
+	}
+	return report, nil
+}
+
+// GetMyReports lists the user's lab reports, most recent sample first
+func (s *DiagnosticService) GetMyReports(ctx context.Context, userID string) ([]*entities.LabReport, error) {
+	reports, err := s.labReportRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if reports == nil {
+		reports = []*entities.LabReport{}
+	}
+	for _, report := range reports {
+		report.FileURL = s.media.SignedURL(ctx, report.FileKey)
+	}
+	return reports, nil
+}
+
+// GetReport returns one of the user's lab reports
+func (s *DiagnosticService) GetReport(ctx context.Context, userID, reportID string) (*entities.LabReport, error) {
+	if !primitive.IsValidObjectID(reportID) {
+		return nil, ErrLabReportNotFound
+	}
+	report, err := s.labReportRepo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil || report.UserID.Hex() != userID {
+		return nil, ErrLabReportNotFound
+	}
+	report.FileURL = s.media.SignedURL(ctx, report.FileKey)
+	return report, nil
+}
+
+// OpenReportFile opens the PDF of one of the user's lab reports. The caller
+// closes it.
+func (s *DiagnosticService) OpenReportFile(ctx context.Context, userID, reportID string) (*entities.LabReport, io.ReadCloser, error) {
+	report, err := s.GetReport(ctx, userID, reportID)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := s.media.Open(ctx, report.FileKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open report: %w", err)
+	}
+	return report, file, nil
+}
+
+// GetAnalyteTrend returns one analyte's values across the user's reports,
+// oldest first, for charting. The analyte may be given by code or common name.
+func (s *DiagnosticService) GetAnalyteTrend(ctx context.Context, userID, analyte string, from, to *time.Time) (*entities.AnalyteTrend, error) {
+	code, name := analyteCode(analyte)
+	if code == "" {
+		return nil, errors.New("analyte is required")
+	}
+
+	points, err := s.labReportRepo.FindAnalyteHistory(ctx, userID, code, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if points == nil {
+		points = []entities.AnalyteTrendPoint{}
+	}
+	if name == "" && len(points) > 0 {
+		name = points[len(points)-1].Name
+	}
+	return &entities.AnalyteTrend{
+		Code:   code,
+		Name:   name,
+		Points: points,
+	}, nil
+}
+
+// labBooking loads a booking at the lab that has not been cancelled
+func (s *DiagnosticService) labBooking(ctx context.Context, lab *entities.DiagnosticsUser, bookingID string) (*entities.DiagnosticsBooking, error) {
+	if !primitive.IsValidObjectID(bookingID) {
+		return nil, ErrDiagnosticsBookingNotFound
+	}
+	booking, err := s.diagnosticRepo.FindBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil || booking.LabID != lab.ID || booking.Status == "cancelled" {
+		return nil, ErrDiagnosticsBookingNotFound
+	}
+	return booking, nil
+}
+
+// completeReportedBooking marks a booking completed once its report is in,
+// moving a home collection still in the lab to report_ready
+func (s *DiagnosticService) completeReportedBooking(ctx context.Context, booking *entities.DiagnosticsBooking) error {
+	bookingID := booking.ID.Hex()
+	if booking.HomeCollection != nil && booking.HomeCollection.Status == "in_lab" {
+		change := entities.CollectionStatusChange{
+			Status: "report_ready",
+			Note:   "Report uploaded",
+			At:     time.Now(),
+		}
+		updated, err := s.diagnosticRepo.UpdateCollectionStatus(ctx, bookingID, "in_lab", change, "completed")
+		if err != nil || updated {
+			return err
+		}
+		// Someone else moved it on meanwhile; still make sure it is completed
+	}
+	if booking.Status == "completed" {
+		return nil
+	}
+	return s.diagnosticRepo.UpdateBookingStatus(ctx, bookingID, "completed")
+}
+
+// reportFileName keeps the base of an uploaded file's name for downloads
+func reportFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, `\`, "/")))
+	name = strings.Map(func(r rune) rune {
+		if r == '"' || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "report.pdf"
+	}
+	return name
+}
+
+// normalizeAnalytes validates the analyte values, puts known analytes under
+// their standard code, name and unit, and flags values outside the reference
+// range
+func normalizeAnalytes(analytes []entities.AnalyteResult) error {
+	seen := make(map[string]bool, len(analytes))
+	for i := range analytes {
+		analyte := &analytes[i]
+		code, name := analyteCode(analyte.Code)
+		if code == "" {
+			return fmt.Errorf("analyte %d: code is required", i+1)
+		}
+		if seen[code] {
+			return fmt.Errorf("analyte %s is listed twice", code)
+		}
+		seen[code] = true
+
+		analyte.Code = code
+		if name != "" {
+			analyte.Name = name
+		}
+		analyte.Name = strings.TrimSpace(analyte.Name)
+		if analyte.Name == "" {
+			analyte.Name = code
+		}
+		analyte.Unit = strings.TrimSpace(analyte.Unit)
+		if analyte.Unit == "" {
+			analyte.Unit = knownAnalytes[analyteKey(code)].unit
+		}
+		if analyte.Unit == "" {
+			return fmt.Errorf("analyte %s: unit is required", code)
+		}
+		analyte.RefText = strings.TrimSpace(analyte.RefText)
+
+		if math.IsNaN(analyte.Value) || math.IsInf(analyte.Value, 0) {
+			return fmt.Errorf("analyte %s: value must be a number", code)
+		}
+		if analyte.RefLow != nil && analyte.RefHigh != nil && *analyte.RefLow > *analyte.RefHigh {
+			return fmt.Errorf("analyte %s: reference low is above reference high", code)
+		}
+		analyte.Flag = analyteFlag(*analyte)
+	}
+	return nil
+}
+
+// analyteFlag compares a value with its reference range
+func analyteFlag(analyte entities.AnalyteResult) string {
+	switch {
+	case analyte.RefLow != nil && analyte.Value < *analyte.RefLow:
+		return "low"
+	case analyte.RefHigh != nil && analyte.Value > *analyte.RefHigh:
+		return "high"
+	case analyte.RefLow != nil || analyte.RefHigh != nil:
+		return "normal"
+	}
+	return ""
+}
+
+// analyteCode resolves a code or common name to the stored code, with the
+// standard name when the analyte is known. Unknown codes are upper-cased with
+// other characters collapsed to underscores, so "Vitamin D" is VITAMIN_D.
+func analyteCode(value string) (code, name string) {
+	if spec, ok := knownAnalytes[analyteKey(value)]; ok {
+		return spec.code, spec.name
+	}
+
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.TrimSpace(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToUpper(r))
+			underscore = false
+			continue
+		}
+		underscore = true
+	}
+	return b.String(), ""
+}
+
+// analyteKey lower-cases a code or name and drops everything but letters and
+// digits, so "HbA1c" and "Hb A1c" match
+func analyteKey(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, value)
+}
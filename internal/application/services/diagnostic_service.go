@@ -2,35 +2,192 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var ErrLabNotFound = errors.New("lab not found")
+
 type DiagnosticService struct {
 	diagnosticRepo repositories.DiagnosticRepository
+	labRepo        repositories.LabRepository
+	labReportRepo  repositories.LabReportRepository
+	userRepo       repositories.UserRepository
+	razorpayClient RazorpayClient
+	media          *MediaService
 }
 
-func NewDiagnosticService(diagnosticRepo repositories.DiagnosticRepository) *DiagnosticService {
+func NewDiagnosticService(
+	diagnosticRepo repositories.DiagnosticRepository,
+	labRepo repositories.LabRepository,
+	labReportRepo repositories.LabReportRepository,
+	userRepo repositories.UserRepository,
+	razorpayClient RazorpayClient,
+	media *MediaService,
+) *DiagnosticService {
 	return &DiagnosticService{
 		diagnosticRepo: diagnosticRepo,
+		labRepo:        labRepo,
+		labReportRepo:  labReportRepo,
+		userRepo:       userRepo,
+		razorpayClient: razorpayClient,
+		media:          media,
 	}
 }
 
-func (s *DiagnosticService) GetDiagnostics(ctx context.Context) ([]*entities.Diagnostic, error) {
+// GetDiagnostics returns the active tests and packages in the catalog
+func (s *DiagnosticService) GetDiagnostics(ctx context.Context) ([]*entities.Diagnostics, error) {
 	return s.diagnosticRepo.FindAll(ctx)
 }
 
-func (s *DiagnosticService) CreateBooking(ctx context.Context, booking *entities.DiagnosticBooking) error {
-	booking.PaymentStatus = "pending"
-	booking.Status = "pending"
-	return s.diagnosticRepo.CreateBooking(ctx, booking)
+// SearchLabs returns a page of approved labs matching the query and the total
+// number of matches
+func (s *DiagnosticService) SearchLabs(ctx context.Context, query entities.GeoQuery) ([]*entities.DiagnosticsUser, int64, error) {
+	if err := normalizeGeoQuery(&query); err != nil {
+		return nil, 0, err
+	}
+	return s.labRepo.Search(ctx, query)
+}
+
+// GetLab returns an approved lab with the tests and packages it offers
+func (s *DiagnosticService) GetLab(ctx context.Context, labID string) (*entities.DiagnosticsUser, error) {
+	if !primitive.IsValidObjectID(labID) {
+		return nil, ErrLabNotFound
+	}
+	lab, err := s.labRepo.FindByID(ctx, labID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching lab: %w", err)
+	}
+	if lab == nil {
+		return nil, ErrLabNotFound
+	}
+	return lab, nil
 }
 
-func (s *DiagnosticService) GetDiagnosticsUsers(ctx context.Context, userID string) ([]*entities.DiagnosticBooking, error) {
+// CreateBooking books a catalog test or package at a lab, charging the lab's
+// price for it, and creates the Razorpay order the client pays against. With
+// an address the sample is collected at home: timeSlot must then be one of the
+// lab's collection windows, and the lab's collection fee is added.
+func (s *DiagnosticService) CreateBooking(ctx context.Context, userID, labID, diagnosticsID string, date int64, timeSlot string, address *entities.ServiceAddress) (*entities.DiagnosticsBooking, error) {
+	if labID == "" || diagnosticsID == "" || timeSlot == "" {
+		return nil, errors.New("lab ID, diagnostics ID and time slot are required")
+	}
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	if time.Unix(date, 0).Before(startOfToday()) {
+		return nil, errors.New("booking date must not be in the past")
+	}
+
+	lab, err := s.GetLab(ctx, labID)
+	if err != nil {
+		return nil, err
+	}
+	diagnostic, err := s.diagnosticRepo.FindByID(ctx, diagnosticsID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching diagnostic: %w", err)
+	}
+	if diagnostic == nil || !diagnostic.IsActive {
+		return nil, errors.New("diagnostic not found")
+	}
+
+	offering := labOffering(lab, diagnostic.ID)
+	if offering == nil {
+		return nil, fmt.Errorf("%s is not offered by this lab", diagnostic.TestName)
+	}
+	if offering.Price <= 0 {
+		return nil, fmt.Errorf("%s has no price set at this lab", diagnostic.TestName)
+	}
+
+	booking := &entities.DiagnosticsBooking{
+		UserID:         userOID,
+		LabID:          lab.ID,
+		DiagnosticsID:  diagnostic.ID,
+		TestName:       diagnostic.TestName,
+		Date:           time.Unix(date, 0),
+		Time:           timeSlot,
+		CollectionType: "lab",
+		Amount:         offering.Price,
+		PaymentStatus:  "pending",
+		Status:         "pending",
+	}
+	if address != nil {
+		if err := scheduleHomeCollection(booking, lab, offering, address, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.diagnosticRepo.CreateBooking(ctx, booking); err != nil {
+		return nil, fmt.Errorf("failed to create booking: %w", err)
+	}
+	bookingID := booking.ID.Hex()
+
+	// The booking is stored first so the receipt can name it. Without an order
+	// it could never be paid, so it is removed again if the order fails.
+	orderID, err := s.razorpayClient.CreateOrder(int(math.Round(booking.Amount*100)), "INR", "diagnostic_"+bookingID)
+	if err == nil {
+		err = s.diagnosticRepo.SetBookingOrder(ctx, bookingID, orderID)
+	}
+	if err != nil {
+		if deleteErr := s.diagnosticRepo.DeleteBooking(ctx, bookingID); deleteErr != nil {
+			log.Printf("Failed to remove unpayable diagnostic booking %s: %v", bookingID, deleteErr)
+		}
+		return nil, fmt.Errorf("failed to create Razorpay order: %w", err)
+	}
+	booking.RazorpayOrderID = orderID
+
+	return booking, nil
+}
+
+func (s *DiagnosticService) GetMyBookings(ctx context.Context, userID string) ([]*entities.DiagnosticsBooking, error) {
 	return s.diagnosticRepo.FindBookingsByUserID(ctx, userID)
 }
 
-func (s *DiagnosticService) VerifyPayment(ctx context.Context, bookingID, paymentID string) error {
+// VerifyPayment checks the Razorpay payment against the booking's order and
+// marks the booking paid
+func (s *DiagnosticService) VerifyPayment(ctx context.Context, bookingID, orderID, paymentID, signature string) error {
+	if !primitive.IsValidObjectID(bookingID) {
+		return errors.New("booking not found")
+	}
+	booking, err := s.diagnosticRepo.FindBookingByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+	if booking == nil {
+		return errors.New("booking not found")
+	}
+	if err := verifyRazorpayPayment(s.razorpayClient, booking.RazorpayOrderID, orderID, paymentID, signature); err != nil {
+		return err
+	}
+
 	return s.diagnosticRepo.UpdateBookingPayment(ctx, bookingID, paymentID, "completed")
 }
+
+// MigrateLegacy rewrites diagnostics documents stored in the old shape
+func (s *DiagnosticService) MigrateLegacy(ctx context.Context) error {
+	migrated, err := s.diagnosticRepo.MigrateLegacy(ctx)
+	if err != nil {
+		return err
+	}
+	if migrated > 0 {
+		log.Printf("Migrated %d legacy diagnostics documents", migrated)
+	}
+	return nil
+}
+
+// labOffering finds the lab's available offering of a catalog entry
+func labOffering(lab *entities.DiagnosticsUser, diagnosticsID primitive.ObjectID) *entities.LabOffering {
+	for i := range lab.Offerings {
+		if lab.Offerings[i].DiagnosticsID == diagnosticsID && lab.Offerings[i].IsAvailable {
+			return &lab.Offerings[i]
+		}
+	}
+	return nil
+}
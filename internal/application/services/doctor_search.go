@@ -0,0 +1,54 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+// normalizeDoctorQuery checks a doctor search and fills in the default order
+// and page size
+func normalizeDoctorQuery(query *entities.DoctorQuery, now time.Time) error {
+	switch query.SessionType {
+	case "", entities.SessionVideoCall, entities.SessionAudioCall, entities.SessionInClinic:
+	default:
+		return fmt.Errorf("%w: sessionType must be %s, %s or %s", ErrInvalidSearch,
+			entities.SessionVideoCall, entities.SessionAudioCall, entities.SessionInClinic)
+	}
+	if query.MinFee < 0 || query.MaxFee < 0 {
+		return fmt.Errorf("%w: fees cannot be negative", ErrInvalidSearch)
+	}
+	if query.MaxFee > 0 && query.MinFee > query.MaxFee {
+		return fmt.Errorf("%w: minFee cannot be more than maxFee", ErrInvalidSearch)
+	}
+	if query.MinRating < 0 || query.MinRating > 5 {
+		return fmt.Errorf("%w: minRating must be between 0 and 5", ErrInvalidSearch)
+	}
+	if query.AvailableOn != nil {
+		day := time.Date(query.AvailableOn.Year(), query.AvailableOn.Month(), query.AvailableOn.Day(), 0, 0, 0, 0, time.UTC)
+		today := now.UTC().Truncate(24 * time.Hour)
+		if day.Before(today) {
+			return fmt.Errorf("%w: date cannot be in the past", ErrInvalidSearch)
+		}
+		query.AvailableOn = &day
+	}
+	switch query.SortBy {
+	case "":
+		query.SortBy = "rating"
+	case "rating", "fee", "experience":
+	default:
+		return fmt.Errorf("%w: sort must be rating, fee or experience", ErrInvalidSearch)
+	}
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+	return nil
+}
@@ -3,19 +3,24 @@ package services
 import (
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
 )
 
+var ErrNoDoctorProfile = errors.New("no doctor profile is linked to your account")
+
 type DoctorService struct {
 	doctorRepo repositories.DoctorRepository
+	media      *MediaService
 }
 
-func NewDoctorService(doctorRepo repositories.DoctorRepository) *DoctorService {
+func NewDoctorService(doctorRepo repositories.DoctorRepository, media *MediaService) *DoctorService {
 	return &DoctorService{
 		doctorRepo: doctorRepo,
+		media:      media,
 	}
 }
 
@@ -57,7 +62,12 @@ func (s *DoctorService) GetDoctorByID(ctx context.Context, id string) (*entities
 	if id == "" {
 		return nil, errors.New("doctor ID is required")
 	}
-	return s.doctorRepo.FindByID(ctx, id)
+	doctor, err := s.doctorRepo.FindByID(ctx, id)
+	if err != nil || doctor == nil {
+		return doctor, err
+	}
+	s.signImages(ctx, doctor)
+	return doctor, nil
 }
 
 // UpdateDoctor updates an existing doctor
@@ -115,11 +125,6 @@ func (s *DoctorService) DeleteDoctor(ctx context.Context, id string) error {
 	return s.doctorRepo.Delete(ctx, id)
 }
 
-// ListDoctors retrieves a list of doctors with optional filters
-func (s *DoctorService) ListDoctors(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entities.Doctor, error) {
-	return s.doctorRepo.FindAll(ctx, filters, limit, offset)
-}
-
 // SetAvailability updates a doctor's availability
 func (s *DoctorService) SetAvailability(ctx context.Context, doctorID string, slots []entities.TimeSlot) error {
 	if doctorID == "" {
@@ -161,56 +166,74 @@ func (s *DoctorService) GetAvailableSlots(ctx context.Context, doctorID string,
 	return availableSlots, nil
 }
 
-// GetDoctorsBySpecialization retrieves doctors by their specialization
-func (s *DoctorService) GetDoctorsBySpecialization(ctx context.Context, specialization string) ([]*entities.Doctor, error) {
-	if specialization == "" {
-		return nil, errors.New("specialization is required")
+// SearchDoctors returns a page of approved doctors matching the query, the
+// total number of matches and facet counts over them
+func (s *DoctorService) SearchDoctors(ctx context.Context, query entities.DoctorQuery) (*entities.DoctorSearchResult, error) {
+	if err := normalizeDoctorQuery(&query, time.Now()); err != nil {
+		return nil, err
 	}
-
-	return s.doctorRepo.FindBySpecialization(ctx, specialization)
+	result, err := s.doctorRepo.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s.signImages(ctx, result.Doctors...)
+	return result, nil
 }
 
-// GetDoctors retrieves a paginated list of doctors with optional search
-func (s *DoctorService) GetDoctors(ctx context.Context, page, limit int, search string) ([]*entities.Doctor, int64, error) {
-	// Validate pagination parameters
-	if page < 1 {
-		page = 1
+// SearchInClinic returns a page of doctors who see patients in person, matching
+// the query, and the total number of matches
+func (s *DoctorService) SearchInClinic(ctx context.Context, query entities.GeoQuery) ([]*entities.Doctor, int64, error) {
+	if err := normalizeGeoQuery(&query); err != nil {
+		return nil, 0, err
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	doctors, total, err := s.doctorRepo.SearchInClinic(ctx, query)
+	if err != nil {
+		return nil, 0, err
 	}
+	s.signImages(ctx, doctors...)
+	return doctors, total, nil
+}
 
-	// Calculate offset
-	offset := (page - 1) * limit
-
-	// Build filters
-	filters := make(map[string]interface{})
-	
-	// Only show approved and non-deleted doctors
-	isApproved := true
-	isDeleted := false
-	filters["isApproved"] = &isApproved
-	filters["isDeleted"] = &isDeleted
-
-	// Add search filter if provided
-	if search != "" {
-		filters["search"] = search
+// SetImage uploads a new image for the doctor profile linked to the user's
+// account, removing the one it replaces
+func (s *DoctorService) SetImage(ctx context.Context, userID string, file io.Reader) (*entities.Doctor, error) {
+	doctor, err := s.DoctorForUser(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get doctors
-	doctors, err := s.doctorRepo.FindAll(ctx, filters, limit, offset)
+	image, err := s.media.StoreImage(ctx, "doctors/"+doctor.ID, file)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	previousImage := doctor.Image
+	doctor.Image = image.Key
+	doctor.UpdatedAt = time.Now()
+	updated, err := s.doctorRepo.Update(ctx, doctor.ID, doctor)
+	if err != nil {
+		s.media.Delete(ctx, image.Key)
+		return nil, err
 	}
+	s.media.Delete(ctx, previousImage)
 
-	// Get total count for pagination
-	// Note: This is a simplified approach. In production, you'd want a separate Count method
-	total := int64(len(doctors))
-	if len(doctors) == limit {
-		// If we got a full page, there might be more
-		// This is an approximation - ideally you'd have a Count method in the repository
-		total = int64((page + 1) * limit)
+	s.signImages(ctx, updated)
+	return updated, nil
+}
+
+// DoctorForUser returns the doctor profile linked to the user's account
+func (s *DoctorService) DoctorForUser(ctx context.Context, userID string) (*entities.Doctor, error) {
+	doctor, err := s.doctorRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
+	if doctor == nil {
+		return nil, ErrNoDoctorProfile
+	}
+	return doctor, nil
+}
 
-	return doctors, total, nil
+func (s *DoctorService) signImages(ctx context.Context, doctors ...*entities.Doctor) {
+	for _, doctor := range doctors {
+		doctor.ImageURL, doctor.ThumbnailURL = s.media.ImageURLs(ctx, doctor.Image)
+	}
 }
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+)
+
+const (
+	// Coverline (3-over-6) rule: three temperatures above the highest of the
+	// previous six, the third at least 0.2°C above the coverline
+	coverlineLowDays  = 6
+	coverlineHighDays = 3
+	coverlineMinRise  = 0.2
+
+	// Temperatures that stay high this long after ovulation suggest pregnancy
+	pregnancyHighTemperatureDays = 18
+)
+
+type FertilityService struct {
+	fertilityRepo repositories.FertilityRepository
+	periodRepo    repositories.PeriodRepository
+}
+
+func NewFertilityService(fertilityRepo repositories.FertilityRepository, periodRepo repositories.PeriodRepository) *FertilityService {
+	return &FertilityService{
+		fertilityRepo: fertilityRepo,
+		periodRepo:    periodRepo,
+	}
+}
+
+// AddLog validates and stores a fertility log. Temperatures are stored in Celsius.
+func (s *FertilityService) AddLog(ctx context.Context, log *entities.FertilityLog) error {
+	switch log.Type {
+	case "bbt":
+		switch log.TemperatureUnit {
+		case "", "C":
+		case "F":
+			log.Temperature = (log.Temperature - 32) * 5 / 9
+		default:
+			return fmt.Errorf("invalid temperature unit: %s", log.TemperatureUnit)
+		}
+		log.TemperatureUnit = "C"
+		if log.Temperature < 34 || log.Temperature > 39 {
+			return errors.New("temperature must be between 34°C and 39°C")
+		}
+	case "lh_test":
+		switch log.LHResult {
+		case "negative", "positive", "peak":
+		default:
+			return fmt.Errorf("invalid LH result: %s", log.LHResult)
+		}
+		if log.LHReading != nil && *log.LHReading < 0 {
+			return errors.New("LH reading cannot be negative")
+		}
+	case "cervical_mucus":
+		switch log.CervicalMucus {
+		case "dry", "sticky", "creamy", "watery", "egg_white":
+		default:
+			return fmt.Errorf("invalid cervical mucus: %s", log.CervicalMucus)
+		}
+	case "intercourse":
+	default:
+		return fmt.Errorf("invalid log type: %s", log.Type)
+	}
+
+	return s.fertilityRepo.Create(ctx, log)
+}
+
+func (s *FertilityService) GetLogs(ctx context.Context, userID, logType string) ([]*entities.FertilityLog, error) {
+	return s.fertilityRepo.FindByUserID(ctx, userID, logType, 100) // Last 100 logs
+}
+
+// GetOvulationStatus analyzes the logs of the current cycle, which starts at the
+// most recent period
+func (s *FertilityService) GetOvulationStatus(ctx context.Context, userID string) (*entities.OvulationStatus, error) {
+	cycles, err := s.periodRepo.FindByUserID(ctx, userID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(cycles) == 0 {
+		return nil, errors.New("no period logged yet, log your last period first")
+	}
+
+	return currentCycleOvulation(ctx, s.fertilityRepo, userID, cycles[0].StartDate)
+}
+
+func currentCycleOvulation(ctx context.Context, fertilityRepo repositories.FertilityRepository, userID string, cycleStart time.Time) (*entities.OvulationStatus, error) {
+	logs, err := fertilityRepo.FindByDateRange(ctx, userID, cycleStart, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return detectOvulation(cycleStart, logs), nil
+}
+
+// detectOvulation applies the coverline (3-over-6) rule to the cycle's basal body
+// temperatures. logs must be sorted by date, oldest first.
+func detectOvulation(cycleStart time.Time, logs []*entities.FertilityLog) *entities.OvulationStatus {
+	status := &entities.OvulationStatus{
+		CycleStart:   cycleStart,
+		Temperatures: []entities.TemperaturePoint{},
+		Notes:        []string{},
+	}
+
+	// One temperature per day: the first one taken, on waking
+	seenDays := map[string]bool{}
+	for _, log := range logs {
+		switch log.Type {
+		case "bbt":
+			day := log.Date.Format("2006-01-02")
+			if !seenDays[day] {
+				seenDays[day] = true
+				status.Temperatures = append(status.Temperatures, entities.TemperaturePoint{Date: log.Date, Temperature: log.Temperature})
+			}
+		case "lh_test":
+			if status.LHSurgeDate == nil && (log.LHResult == "positive" || log.LHResult == "peak") {
+				date := log.Date
+				status.LHSurgeDate = &date
+			}
+		}
+	}
+
+	temps := status.Temperatures
+	for i := coverlineLowDays; i+coverlineHighDays <= len(temps); i++ {
+		coverline := 0.0
+		for _, t := range temps[i-coverlineLowDays : i] {
+			if t.Temperature > coverline {
+				coverline = t.Temperature
+			}
+		}
+
+		high := true
+		for _, t := range temps[i : i+coverlineHighDays] {
+			if t.Temperature <= coverline {
+				high = false
+				break
+			}
+		}
+		if !high {
+			continue
+		}
+
+		// The third high temperature must clear the coverline by 0.2°C; otherwise
+		// a fourth temperature above the coverline is enough
+		confirmIdx := i + coverlineHighDays - 1
+		if temps[confirmIdx].Temperature < coverline+coverlineMinRise {
+			confirmIdx++
+			if confirmIdx >= len(temps) || temps[confirmIdx].Temperature <= coverline {
+				continue
+			}
+		}
+
+		ovulation := temps[i].Date.AddDate(0, 0, -1)
+		confirmedOn := temps[confirmIdx].Date
+		status.Confirmed = true
+		status.OvulationDate = &ovulation
+		status.ConfirmedOn = &confirmedOn
+		status.Coverline = &coverline
+		for j := range temps {
+			temps[j].AboveCoverline = j >= i && temps[j].Temperature > coverline
+			if temps[j].AboveCoverline {
+				status.HighTemperatureDays++
+			}
+		}
+		break
+	}
+
+	// Peak mucus: the last fertile-quality mucus day on or before ovulation
+	for _, log := range logs {
+		if log.Type != "cervical_mucus" || (log.CervicalMucus != "egg_white" && log.CervicalMucus != "watery") {
+			continue
+		}
+		if status.OvulationDate == nil || !log.Date.After(status.OvulationDate.AddDate(0, 0, 1)) {
+			date := log.Date
+			status.PeakMucusDate = &date
+		}
+	}
+
+	if status.Confirmed {
+		status.Notes = append(status.Notes, "Ovulation confirmed by a sustained temperature shift. Your fertile window for this cycle has closed.")
+		if status.LHSurgeDate == nil {
+			status.Notes = append(status.Notes, "No positive LH test was logged this cycle.")
+		}
+		if status.HighTemperatureDays >= pregnancyHighTemperatureDays {
+			status.Notes = append(status.Notes, "Your temperature has stayed high for 18 days or more. Consider taking a pregnancy test.")
+		}
+	} else if len(temps) < coverlineLowDays+coverlineHighDays {
+		status.Notes = append(status.Notes, "Log your temperature every morning; at least 9 readings are needed to detect ovulation.")
+	}
+
+	return status
+}
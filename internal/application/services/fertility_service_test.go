@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+var cycleStart = time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+// cycleDay is the morning of the given day of the cycle, counting from 0
+func cycleDay(day int) time.Time {
+	return cycleStart.AddDate(0, 0, day).Add(6 * time.Hour)
+}
+
+// bbtLogs is one temperature per morning, starting on the first day of the cycle
+func bbtLogs(temps ...float64) []*entities.FertilityLog {
+	logs := make([]*entities.FertilityLog, 0, len(temps))
+	for i, temp := range temps {
+		logs = append(logs, &entities.FertilityLog{Type: "bbt", Date: cycleDay(i), Temperature: temp})
+	}
+	return logs
+}
+
+// fertilityRepoStub keeps created logs in memory
+type fertilityRepoStub struct {
+	created []*entities.FertilityLog
+}
+
+func (r *fertilityRepoStub) Create(ctx context.Context, log *entities.FertilityLog) error {
+	r.created = append(r.created, log)
+	return nil
+}
+
+func (r *fertilityRepoStub) FindByUserID(ctx context.Context, userID string, logType string, limit int) ([]*entities.FertilityLog, error) {
+	return r.created, nil
+}
+
+func (r *fertilityRepoStub) FindByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*entities.FertilityLog, error) {
+	return r.created, nil
+}
+
+func TestDetectOvulation(t *testing.T) {
+	// A later, warmer reading on day 5 must not replace that morning's temperature
+	repeated := bbtLogs(36.2, 36.3, 36.1, 36.2, 36.3, 36.2)
+	repeated = append(repeated, &entities.FertilityLog{Type: "bbt", Date: cycleDay(5).Add(8 * time.Hour), Temperature: 36.9})
+	for i, temp := range []float64{36.4, 36.5, 36.6} {
+		repeated = append(repeated, &entities.FertilityLog{Type: "bbt", Date: cycleDay(6 + i), Temperature: temp})
+	}
+
+	tests := []struct {
+		name         string
+		logs         []*entities.FertilityLog
+		temperatures int
+		confirmed    bool
+		ovulationDay int
+		confirmedDay int
+		coverline    float64
+		highDays     int
+	}{
+		{
+			name:         "fewer than 9 readings",
+			logs:         bbtLogs(36.2, 36.3, 36.1, 36.2, 36.3, 36.2, 36.8, 36.8),
+			temperatures: 8,
+		},
+		{
+			name:         "no temperature shift",
+			logs:         bbtLogs(36.3, 36.3, 36.3, 36.3, 36.3, 36.3, 36.3, 36.3, 36.3, 36.3, 36.3, 36.3),
+			temperatures: 12,
+		},
+		{
+			name:         "third high temperature clears the coverline by 0.2",
+			logs:         bbtLogs(36.2, 36.3, 36.1, 36.2, 36.3, 36.2, 36.4, 36.5, 36.6),
+			temperatures: 9,
+			confirmed:    true,
+			ovulationDay: 5,
+			confirmedDay: 8,
+			coverline:    36.3,
+			highDays:     3,
+		},
+		{
+			name:         "fourth high temperature confirms a slow rise",
+			logs:         bbtLogs(36.2, 36.3, 36.1, 36.2, 36.3, 36.2, 36.4, 36.4, 36.4, 36.4),
+			temperatures: 10,
+			confirmed:    true,
+			ovulationDay: 5,
+			confirmedDay: 9,
+			coverline:    36.3,
+			highDays:     4,
+		},
+		{
+			name:         "fourth temperature back at the coverline",
+			logs:         bbtLogs(36.2, 36.3, 36.1, 36.2, 36.3, 36.2, 36.4, 36.4, 36.4, 36.3),
+			temperatures: 10,
+		},
+		{
+			name:         "one reading per day",
+			logs:         repeated,
+			temperatures: 9,
+			confirmed:    true,
+			ovulationDay: 5,
+			confirmedDay: 8,
+			coverline:    36.3,
+			highDays:     3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := detectOvulation(cycleStart, tt.logs)
+			if len(status.Temperatures) != tt.temperatures {
+				t.Errorf("temperatures = %d, want %d", len(status.Temperatures), tt.temperatures)
+			}
+			if status.Confirmed != tt.confirmed {
+				t.Fatalf("confirmed = %v, want %v", status.Confirmed, tt.confirmed)
+			}
+			if !tt.confirmed {
+				if status.OvulationDate != nil || status.Coverline != nil || status.HighTemperatureDays != 0 {
+					t.Errorf("unconfirmed cycle has ovulation %v, coverline %v, %d high days", status.OvulationDate, status.Coverline, status.HighTemperatureDays)
+				}
+				return
+			}
+			if want := cycleDay(tt.ovulationDay); !status.OvulationDate.Equal(want) {
+				t.Errorf("ovulation date = %v, want %v", status.OvulationDate, want)
+			}
+			if want := cycleDay(tt.confirmedDay); !status.ConfirmedOn.Equal(want) {
+				t.Errorf("confirmed on = %v, want %v", status.ConfirmedOn, want)
+			}
+			if *status.Coverline != tt.coverline {
+				t.Errorf("coverline = %v, want %v", *status.Coverline, tt.coverline)
+			}
+			if status.HighTemperatureDays != tt.highDays {
+				t.Errorf("high temperature days = %d, want %d", status.HighTemperatureDays, tt.highDays)
+			}
+		})
+	}
+}
+
+func TestAddLogTemperatureUnits(t *testing.T) {
+	tests := []struct {
+		name        string
+		temperature float64
+		unit        string
+		celsius     float64
+		wantErr     bool
+	}{
+		{name: "Fahrenheit", temperature: 98.6, unit: "F", celsius: 37},
+		{name: "Fahrenheit low", temperature: 97.7, unit: "F", celsius: 36.5},
+		{name: "Celsius", temperature: 36.5, unit: "C", celsius: 36.5},
+		{name: "unit omitted", temperature: 36.5, unit: "", celsius: 36.5},
+		{name: "Fahrenheit out of range", temperature: 104, unit: "F", wantErr: true},
+		{name: "Fahrenheit sent as Celsius", temperature: 98.6, unit: "C", wantErr: true},
+		{name: "unknown unit", temperature: 310, unit: "K", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fertilityRepoStub{}
+			service := NewFertilityService(repo, nil)
+			log := &entities.FertilityLog{Type: "bbt", Date: cycleDay(0), Temperature: tt.temperature, TemperatureUnit: tt.unit}
+
+			err := service.AddLog(context.Background(), log)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, stored %v°C", log.Temperature)
+				}
+				if len(repo.created) != 0 {
+					t.Errorf("rejected log was stored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(repo.created) != 1 {
+				t.Fatalf("stored %d logs, want 1", len(repo.created))
+			}
+			if math.Abs(log.Temperature-tt.celsius) > 1e-9 || log.TemperatureUnit != "C" {
+				t.Errorf("stored %v%s, want %vC", log.Temperature, log.TemperatureUnit, tt.celsius)
+			}
+		})
+	}
+}
@@ -2,86 +2,162 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"math"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
 )
 
+// A full-scale FSFI score of 26.55 or below indicates female sexual dysfunction (Wiegel et al., 2005)
+const fsfiCutoff = 26.55
+
 type FSFIService struct {
-	mentalHealthRepo repositories.MentalHealthRepository
+	fsfiRepo             repositories.FSFIRepository
+	questionnaireService *QuestionnaireService
 }
 
-func NewFSFIService(mentalHealthRepo repositories.MentalHealthRepository) *FSFIService {
+func NewFSFIService(fsfiRepo repositories.FSFIRepository, questionnaireService *QuestionnaireService) *FSFIService {
 	return &FSFIService{
-		mentalHealthRepo: mentalHealthRepo,
+		fsfiRepo:             fsfiRepo,
+		questionnaireService: questionnaireService,
 	}
 }
 
-func (s *FSFIService) GetTest() entities.MentalHealthTest {
-	// Common scale options for FSFI questions
-	scaleOptions1to5 := []entities.QuestionOption{
-		{Value: 1, Label: "1"},
-		{Value: 2, Label: "2"},
-		{Value: 3, Label: "3"},
-		{Value: 4, Label: "4"},
-		{Value: 5, Label: "5"},
+func (s *FSFIService) GetTest(ctx context.Context, locale string) (*entities.MentalHealthTest, error) {
+	test, err := s.questionnaireService.GetDefinition(ctx, "fsfi", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.questionnaireService.Localize(ctx, test, locale)
+}
+
+// SubmitTest validates the answers against the FSFI definition, computes the
+// factor-weighted domain scores and the full-scale score, and stores an FSFIResult
+func (s *FSFIService) SubmitTest(ctx context.Context, submission *entities.TestResult) (*entities.FSFIResult, error) {
+	submission.TestName = "fsfi"
+
+	test, err := s.questionnaireService.Score(ctx, submission)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := scoreFSFI(test, submission.Answers)
+	if err != nil {
+		return nil, err
 	}
+	result.UserID = submission.UserID
+	result.SubmittedAt = submission.TestDate
 
-	scaleOptions0to5 := append([]entities.QuestionOption{
-		{Value: 0, Label: "0"},
-	}, scaleOptions1to5...)
-
-	return entities.MentalHealthTest{
-		Name:        "fsfi",
-		DisplayName: "Female Sexual Function Index (FSFI)",
-		Description: "Assessment of female sexual function",
-		Questions: []entities.TestQuestion{
-			{
-				QuestionText:  "How often did you feel sexual desire or interest?",
-				ResponseType: "scale",
-				Options:      scaleOptions1to5,
-			},
-			{
-				QuestionText:  "How would you rate your level of sexual desire or interest?",
-				ResponseType: "scale",
-				Options:      scaleOptions1to5,
-			},
-			{
-				QuestionText:  "How often did you feel sexually aroused during sexual activity?",
-				ResponseType: "scale",
-				Options:      scaleOptions0to5,
-			},
-			{
-				QuestionText:  "How would you rate your level of sexual arousal?",
-				ResponseType: "scale",
-				Options:      scaleOptions0to5,
-			},
-		},
+	if err := s.fsfiRepo.Create(ctx, result); err != nil {
+		return nil, err
 	}
+
+	return result, nil
+}
+
+func (s *FSFIService) GetMyResults(ctx context.Context, userID string) ([]*entities.FSFIResult, error) {
+	return s.fsfiRepo.FindByUserID(ctx, userID, 50) // Last 50 results
 }
 
-func (s *FSFIService) SubmitTest(ctx context.Context, result *entities.TestResult) error {
-	result.TestName = "fsfi"
+// scoreFSFI computes domain scores (item sum times domain factor, to one decimal)
+// and the full-scale score (sum of domain scores) from validated answers
+func scoreFSFI(test *entities.MentalHealthTest, answers map[string]interface{}) (*entities.FSFIResult, error) {
+	result := &entities.FSFIResult{TestVersion: test.Version}
 
-	// Calculate score
-	score := 0
-	for _, answer := range result.Answers {
-		if val, ok := answer.(float64); ok {
-			score += int(val)
+	for _, item := range questionItems(test) {
+		result.Responses = append(result.Responses, answers[item.Key])
+	}
+
+	var total float64
+	for _, subscale := range test.Subscales {
+		sum := 0.0
+		for _, key := range subscale.Keys {
+			sum += answers[key].(float64)
 		}
+		score := roundTo(sum*subscale.Factor, 1)
+
+		switch subscale.Name {
+		case "desire":
+			result.DomainScores.Desire = score
+		case "arousal":
+			result.DomainScores.Arousal = score
+		case "lubrication":
+			result.DomainScores.Lubrication = score
+		case "orgasm":
+			result.DomainScores.Orgasm = score
+		case "satisfaction":
+			result.DomainScores.Satisfaction = score
+		case "pain":
+			result.DomainScores.Pain = score
+		default:
+			return nil, fmt.Errorf("unknown FSFI domain %s", subscale.Name)
+		}
+		total += score
 	}
-	result.Score = score
+	result.TotalScore = roundTo(total, 1)
 
-	// Determine level (FSFI score < 26.55 indicates sexual dysfunction)
-	if score >= 27 {
-		result.Level = "normal"
-	} else {
-		result.Level = "dysfunction"
+	switch {
+	case noSexualActivity(test, answers):
+		result.Diagnosis = "no sexual activity"
+		result.Recommendation = "You reported no sexual activity in the past 4 weeks, so the FSFI score cannot be interpreted. Take the test again after a period of sexual activity."
+	case fsfiDysfunction(result.TotalScore):
+		result.Diagnosis = "sexual dysfunction"
+		result.Recommendation = fmt.Sprintf("Your score suggests sexual dysfunction, with the lowest score in %s. Consider speaking to a gynaecologist or sexual health specialist.", lowestFSFIDomain(result.DomainScores))
+	default:
+		result.Diagnosis = "normal"
+		result.Recommendation = "Your score is in the normal range for sexual function."
 	}
 
-	return s.mentalHealthRepo.CreateResult(ctx, result)
+	return result, nil
+}
+
+// fsfiDysfunction reports whether a full-scale score is at or below the cutoff
+func fsfiDysfunction(total float64) bool {
+	return total <= fsfiCutoff
+}
+
+// noSexualActivity reports whether every item that offers a 0 "no activity" option was answered 0
+func noSexualActivity(test *entities.MentalHealthTest, answers map[string]interface{}) bool {
+	found := false
+	for _, item := range questionItems(test) {
+		for _, option := range item.Options {
+			if option.Value != 0 {
+				continue
+			}
+			found = true
+			if answers[item.Key].(float64) != 0 {
+				return false
+			}
+		}
+	}
+	return found
+}
+
+func lowestFSFIDomain(scores entities.DomainScores) string {
+	domains := []struct {
+		name  string
+		score float64
+	}{
+		{"desire", scores.Desire},
+		{"arousal", scores.Arousal},
+		{"lubrication", scores.Lubrication},
+		{"orgasm", scores.Orgasm},
+		{"satisfaction", scores.Satisfaction},
+		{"pain", scores.Pain},
+	}
+
+	lowest := domains[0]
+	for _, domain := range domains[1:] {
+		if domain.score < lowest.score {
+			lowest = domain
+		}
+	}
+	return lowest.name
 }
 
-func (s *FSFIService) GetMyResults(ctx context.Context, userID string) ([]*entities.TestResult, error) {
-	return s.mentalHealthRepo.FindResultsByUserID(ctx, userID, "fsfi")
+func roundTo(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
 }
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+// fsfiAnswers answers every item with value, then applies the overrides
+func fsfiAnswers(value float64, overrides map[string]float64) map[string]interface{} {
+	answers := make(map[string]interface{}, 19)
+	for i := 1; i <= 19; i++ {
+		answers[fmt.Sprintf("q%d", i)] = value
+	}
+	for key, v := range overrides {
+		answers[key] = v
+	}
+	return answers
+}
+
+func TestScoreFSFI(t *testing.T) {
+	test := fsfiTest()
+
+	tests := []struct {
+		name      string
+		answers   map[string]interface{}
+		domains   entities.DomainScores
+		total     float64
+		diagnosis string
+	}{
+		{
+			// Highest possible score (Rosen et al., 2000)
+			name:      "maximum",
+			answers:   fsfiAnswers(5, nil),
+			domains:   entities.DomainScores{Desire: 6, Arousal: 6, Lubrication: 6, Orgasm: 6, Satisfaction: 6, Pain: 6},
+			total:     36,
+			diagnosis: "normal",
+		},
+		{
+			// Lowest possible score: desire and satisfaction have no 0 option
+			name: "minimum",
+			answers: fsfiAnswers(0, map[string]float64{
+				"q1": 1, "q2": 1, "q15": 1, "q16": 1,
+			}),
+			domains:   entities.DomainScores{Desire: 1.2, Arousal: 0, Lubrication: 0, Orgasm: 0, Satisfaction: 0.8, Pain: 0},
+			total:     2,
+			diagnosis: "no sexual activity",
+		},
+		{
+			name:      "every item lowest with activity",
+			answers:   fsfiAnswers(1, nil),
+			domains:   entities.DomainScores{Desire: 1.2, Arousal: 1.2, Lubrication: 1.2, Orgasm: 1.2, Satisfaction: 1.2, Pain: 1.2},
+			total:     7.2,
+			diagnosis: "sexual dysfunction",
+		},
+		{
+			name: "just below the cutoff",
+			answers: fsfiAnswers(4, map[string]float64{
+				"q3": 3, "q11": 3, "q12": 3, "q17": 3, "q18": 3, "q19": 3,
+			}),
+			domains:   entities.DomainScores{Desire: 4.8, Arousal: 4.5, Lubrication: 4.8, Orgasm: 4, Satisfaction: 4.8, Pain: 3.6},
+			total:     26.5,
+			diagnosis: "sexual dysfunction",
+		},
+		{
+			name: "just above the cutoff",
+			answers: fsfiAnswers(4, map[string]float64{
+				"q1": 3, "q11": 3, "q17": 3, "q18": 3, "q19": 3,
+			}),
+			domains:   entities.DomainScores{Desire: 4.2, Arousal: 4.8, Lubrication: 4.8, Orgasm: 4.4, Satisfaction: 4.8, Pain: 3.6},
+			total:     26.6,
+			diagnosis: "normal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := scoreFSFI(&test, tt.answers)
+			if err != nil {
+				t.Fatalf("scoreFSFI: %v", err)
+			}
+			if result.DomainScores != tt.domains {
+				t.Errorf("domain scores = %+v, want %+v", result.DomainScores, tt.domains)
+			}
+			if result.TotalScore != tt.total {
+				t.Errorf("total = %v, want %v", result.TotalScore, tt.total)
+			}
+			if result.Diagnosis != tt.diagnosis {
+				t.Errorf("diagnosis = %q, want %q", result.Diagnosis, tt.diagnosis)
+			}
+		})
+	}
+}
+
+func TestFSFIDysfunctionCutoff(t *testing.T) {
+	tests := []struct {
+		total float64
+		want  bool
+	}{
+		{26.5, true},
+		{26.55, true},
+		{26.56, false},
+		{26.6, false},
+	}
+
+	for _, tt := range tests {
+		if got := fsfiDysfunction(tt.total); got != tt.want {
+			t.Errorf("fsfiDysfunction(%v) = %v, want %v", tt.total, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+// ErrInvalidSearch is returned for discovery searches with out-of-range parameters
+var ErrInvalidSearch = errors.New("invalid search")
+
+const (
+	defaultSearchRadiusKm = 10
+	maxSearchRadiusKm     = 100
+	defaultSearchLimit    = 10
+	maxSearchLimit        = 50
+)
+
+// normalizeGeoQuery checks a discovery search and fills in the default radius
+// and page size
+func normalizeGeoQuery(query *entities.GeoQuery) error {
+	if query.Near != nil {
+		lng, lat := query.Near.Coordinates[0], query.Near.Coordinates[1]
+		if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+			return fmt.Errorf("%w: lat must be between -90 and 90 and lng between -180 and 180", ErrInvalidSearch)
+		}
+		if query.RadiusKm == 0 {
+			query.RadiusKm = defaultSearchRadiusKm
+		}
+		if query.RadiusKm < 0 || query.RadiusKm > maxSearchRadiusKm {
+			return fmt.Errorf("%w: radiusKm must be between 0 and 100", ErrInvalidSearch)
+		}
+	}
+	if query.MinRating < 0 || query.MinRating > 5 {
+		return fmt.Errorf("%w: minRating must be between 0 and 5", ErrInvalidSearch)
+	}
+	switch query.SortBy {
+	case "", "rating":
+	case "distance":
+		if query.Near == nil {
+			return fmt.Errorf("%w: sorting by distance needs lat and lng", ErrInvalidSearch)
+		}
+	default:
+		return fmt.Errorf("%w: sort must be distance or rating", ErrInvalidSearch)
+	}
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+// instrumentSpec pins down a validated instrument: how many items it has, the value
+// range of every item and its published severity bands. A stored definition has to
+// match its spec before results are scored against it, so an edited definition
+// cannot silently change how PHQ-9 or GAD-7 are scored.
+type instrumentSpec struct {
+	items    int
+	minValue int
+	maxValue int
+	bands    []instrumentBand
+	critical []string
+}
+
+type instrumentBand struct {
+	min      int
+	max      int
+	severity string
+}
+
+var instrumentSpecs = map[string]instrumentSpec{
+	// Kroenke, Spitzer & Williams (2001)
+	"phq9": {
+		items:    9,
+		minValue: 0,
+		maxValue: 3,
+		bands: []instrumentBand{
+			{0, 4, "minimal"},
+			{5, 9, "mild"},
+			{10, 14, "moderate"},
+			{15, 19, "moderately severe"},
+			{20, 27, "severe"},
+		},
+		critical: []string{"q9"},
+	},
+	// Spitzer et al. (2006)
+	"gad7": {
+		items:    7,
+		minValue: 0,
+		maxValue: 3,
+		bands: []instrumentBand{
+			{0, 4, "minimal"},
+			{5, 9, "mild"},
+			{10, 14, "moderate"},
+			{15, 21, "severe"},
+		},
+	},
+}
+
+// scoreInstrument scores a result with the instrument's rules when the test is a
+// known instrument, and with the generic engine otherwise
+func scoreInstrument(test *entities.MentalHealthTest, result *entities.TestResult) error {
+	spec, ok := instrumentSpecs[test.Name]
+	if !ok {
+		return scoreTestResult(test, result)
+	}
+
+	if err := spec.check(test); err != nil {
+		return fmt.Errorf("%s definition version %d: %w", test.Name, test.Version, err)
+	}
+	if err := scoreTestResult(test, result); err != nil {
+		return err
+	}
+
+	if result.ObtainedScore < spec.bands[0].min || result.ObtainedScore > spec.bands[len(spec.bands)-1].max {
+		return fmt.Errorf("%s score %d is out of range", test.Name, result.ObtainedScore)
+	}
+	for _, key := range spec.critical {
+		if result.Answers[key].(float64) > 0 && !result.Critical {
+			result.Critical = true
+			result.CriticalNote = test.CriticalNote
+		}
+	}
+
+	return nil
+}
+
+// check verifies that a definition has the instrument's items, value range and
+// severity bands, and declares its critical items
+func (spec instrumentSpec) check(test *entities.MentalHealthTest) error {
+	items := questionItems(test)
+	if len(items) != spec.items {
+		return fmt.Errorf("expected %d items, found %d", spec.items, len(items))
+	}
+
+	for _, item := range items {
+		if item.ResponseType == "slider" {
+			return fmt.Errorf("item %s must use fixed options", item.Key)
+		}
+		values := map[int]bool{}
+		for _, option := range item.Options {
+			if option.Value < spec.minValue || option.Value > spec.maxValue {
+				return fmt.Errorf("item %s has option value %d outside %d-%d", item.Key, option.Value, spec.minValue, spec.maxValue)
+			}
+			values[option.Value] = true
+		}
+		for value := spec.minValue; value <= spec.maxValue; value++ {
+			if !values[value] {
+				return fmt.Errorf("item %s is missing option value %d", item.Key, value)
+			}
+		}
+	}
+
+	if len(test.Thresholds) != len(spec.bands) {
+		return fmt.Errorf("expected %d severity bands, found %d", len(spec.bands), len(test.Thresholds))
+	}
+	for i, band := range spec.bands {
+		threshold := test.Thresholds[i]
+		if threshold.Min != band.min || threshold.Max != band.max || threshold.Severity != band.severity {
+			return fmt.Errorf("severity band %d should be %d-%d %s", i+1, band.min, band.max, band.severity)
+		}
+		if threshold.Recommendation == "" {
+			return fmt.Errorf("severity band %s has no recommendation", band.severity)
+		}
+	}
+
+	for _, key := range spec.critical {
+		declared := false
+		for _, item := range test.CriticalItems {
+			if item == key {
+				declared = true
+			}
+		}
+		if !declared {
+			return fmt.Errorf("item %s must be declared critical", key)
+		}
+	}
+
+	return nil
+}
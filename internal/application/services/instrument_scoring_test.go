@@ -0,0 +1,127 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+// instrumentAnswers answers items q1..qN to add up to score, filling the
+// first items with the highest value first
+func instrumentAnswers(items, score int) map[string]interface{} {
+	answers := make(map[string]interface{}, items)
+	for i := 1; i <= items; i++ {
+		value := score
+		if value > 3 {
+			value = 3
+		}
+		score -= value
+		answers[fmt.Sprintf("q%d", i)] = float64(value)
+	}
+	return answers
+}
+
+func TestScoreInstrumentSeverityBands(t *testing.T) {
+	phq9, gad7 := phq9Test(), gad7Test()
+
+	tests := []struct {
+		test  *entities.MentalHealthTest
+		items int
+		score int
+		level string
+	}{
+		{&phq9, 9, 0, "minimal"},
+		{&phq9, 9, 4, "minimal"},
+		{&phq9, 9, 5, "mild"},
+		{&phq9, 9, 9, "mild"},
+		{&phq9, 9, 10, "moderate"},
+		{&phq9, 9, 14, "moderate"},
+		{&phq9, 9, 15, "moderately severe"},
+		{&phq9, 9, 19, "moderately severe"},
+		{&phq9, 9, 20, "severe"},
+		{&phq9, 9, 27, "severe"},
+		{&gad7, 7, 0, "minimal"},
+		{&gad7, 7, 4, "minimal"},
+		{&gad7, 7, 5, "mild"},
+		{&gad7, 7, 9, "mild"},
+		{&gad7, 7, 10, "moderate"},
+		{&gad7, 7, 14, "moderate"},
+		{&gad7, 7, 15, "severe"},
+		{&gad7, 7, 21, "severe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.test.Name, tt.score), func(t *testing.T) {
+			result := &entities.TestResult{Answers: instrumentAnswers(tt.items, tt.score)}
+			if err := scoreInstrument(tt.test, result); err != nil {
+				t.Fatalf("scoreInstrument: %v", err)
+			}
+			if result.ObtainedScore != tt.score {
+				t.Errorf("score = %d, want %d", result.ObtainedScore, tt.score)
+			}
+			if result.Level != tt.level {
+				t.Errorf("level = %q, want %q", result.Level, tt.level)
+			}
+		})
+	}
+}
+
+func TestScoreInstrumentCriticalItem(t *testing.T) {
+	phq9 := phq9Test()
+
+	result := &entities.TestResult{Answers: instrumentAnswers(9, 0)}
+	result.Answers["q9"] = 1.0
+	if err := scoreInstrument(&phq9, result); err != nil {
+		t.Fatalf("scoreInstrument: %v", err)
+	}
+	if !result.Critical || result.CriticalNote == "" {
+		t.Errorf("any answer above 0 to PHQ-9 item 9 should be critical, got critical=%v note=%q", result.Critical, result.CriticalNote)
+	}
+}
+
+func TestScoreInstrumentRejectsInvalidAnswers(t *testing.T) {
+	phq9, gad7 := phq9Test(), gad7Test()
+
+	tests := []struct {
+		name   string
+		test   *entities.MentalHealthTest
+		modify func(answers map[string]interface{})
+	}{
+		{"phq9 missing item", &phq9, func(a map[string]interface{}) { delete(a, "q9") }},
+		{"phq9 no answers", &phq9, func(a map[string]interface{}) {
+			for key := range a {
+				delete(a, key)
+			}
+		}},
+		{"phq9 above range", &phq9, func(a map[string]interface{}) { a["q1"] = 4.0 }},
+		{"phq9 below range", &phq9, func(a map[string]interface{}) { a["q1"] = -1.0 }},
+		{"phq9 fractional", &phq9, func(a map[string]interface{}) { a["q1"] = 1.5 }},
+		{"phq9 not a number", &phq9, func(a map[string]interface{}) { a["q1"] = "often" }},
+		{"gad7 missing item", &gad7, func(a map[string]interface{}) { delete(a, "q7") }},
+		{"gad7 above range", &gad7, func(a map[string]interface{}) { a["q7"] = 4.0 }},
+		{"gad7 below range", &gad7, func(a map[string]interface{}) { a["q7"] = -1.0 }},
+		{"gad7 unknown item", &gad7, func(a map[string]interface{}) { a["q8"] = 0.0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := len(questionItems(tt.test))
+			result := &entities.TestResult{Answers: instrumentAnswers(items, 0)}
+			tt.modify(result.Answers)
+			if err := scoreInstrument(tt.test, result); err == nil {
+				t.Errorf("expected an error, got level %q with score %d", result.Level, result.ObtainedScore)
+			}
+		})
+	}
+}
+
+func TestScoreInstrumentRejectsEditedDefinition(t *testing.T) {
+	phq9 := phq9Test()
+	phq9.Thresholds[1].Min = 6
+
+	result := &entities.TestResult{Answers: instrumentAnswers(9, 5)}
+	if err := scoreInstrument(&phq9, result); err == nil {
+		t.Error("expected a PHQ-9 definition with a moved band to be rejected")
+	}
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/imaging"
+	"github.com/gabriel-vasile/mimetype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ObjectStore keeps uploaded files under slash separated keys
+type ObjectStore interface {
+	Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// signedURLVerifier is implemented by stores whose signed URLs the API serves
+// itself, like the local filesystem
+type signedURLVerifier interface {
+	VerifySignedURL(key, expires, signature string) error
+}
+
+var (
+	ErrUnsupportedMedia = errors.New("unsupported file type")
+	ErrMediaTooLarge    = errors.New("file is too large")
+	ErrMediaNotFound    = errors.New("file not found")
+	ErrInvalidImageKey  = errors.New("image must be one you uploaded")
+)
+
+const (
+	MaxImageSize    = 5 << 20
+	MaxDocumentSize = 20 << 20
+
+	thumbnailSize = 320
+)
+
+// Image types accepted for upload. Thumbnails are made for the ones the
+// standard library decodes; WebP images are used as their own thumbnail.
+var imageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+type MediaService struct {
+	store     ObjectStore
+	urlExpiry time.Duration
+}
+
+func NewMediaService(store ObjectStore, urlExpiry time.Duration) *MediaService {
+	return &MediaService{
+		store:     store,
+		urlExpiry: urlExpiry,
+	}
+}
+
+// UploadImage stores an image in the user's uploads, for community posts or
+// a profile image
+func (s *MediaService) UploadImage(ctx context.Context, userID string, file io.Reader) (*entities.StoredObject, error) {
+	if !primitive.IsValidObjectID(userID) {
+		return nil, errors.New("invalid user ID")
+	}
+	return s.StoreImage(ctx, "uploads/"+userID, file)
+}
+
+// StoreImage stores a JPEG, PNG or WebP image under a prefix, with a JPEG
+// thumbnail beside it
+func (s *MediaService) StoreImage(ctx context.Context, prefix string, file io.Reader) (*entities.StoredObject, error) {
+	data, err := readUpload(file, MaxImageSize)
+	if err != nil {
+		return nil, err
+	}
+	detected := mimetype.Detect(data)
+	contentType := baseMediaType(detected.String())
+	if !imageTypes[contentType] {
+		return nil, fmt.Errorf("%w: images must be JPEG, PNG or WebP, not %s", ErrUnsupportedMedia, contentType)
+	}
+
+	key, err := newObjectKey(prefix, detected.Extension())
+	if err != nil {
+		return nil, err
+	}
+
+	var thumbnail []byte
+	if contentType != "image/webp" {
+		thumbnail, err = imaging.Thumbnail(data, thumbnailSize)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedMedia, err)
+		}
+	}
+
+	if err := s.store.Put(ctx, key, contentType, bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, fmt.Errorf("failed to store image: %w", err)
+	}
+	object := &entities.StoredObject{
+		Key:         key,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+	}
+	if thumbnail != nil {
+		object.ThumbnailKey = thumbnailKey(key)
+		if err := s.store.Put(ctx, object.ThumbnailKey, "image/jpeg", bytes.NewReader(thumbnail), int64(len(thumbnail))); err != nil {
+			s.Delete(ctx, key)
+			return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+		}
+	}
+	object.URL, object.ThumbnailURL = s.ImageURLs(ctx, key)
+	return object, nil
+}
+
+// StoreDocument stores a PDF under a prefix
+func (s *MediaService) StoreDocument(ctx context.Context, prefix string, file io.Reader) (*entities.StoredObject, error) {
+	data, err := readUpload(file, MaxDocumentSize)
+	if err != nil {
+		return nil, err
+	}
+	detected := mimetype.Detect(data)
+	if !detected.Is("application/pdf") {
+		return nil, fmt.Errorf("%w: documents must be PDF, not %s", ErrUnsupportedMedia, baseMediaType(detected.String()))
+	}
+
+	key, err := newObjectKey(prefix, ".pdf")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.Put(ctx, key, "application/pdf", bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, fmt.Errorf("failed to store document: %w", err)
+	}
+	return &entities.StoredObject{
+		Key:         key,
+		ContentType: "application/pdf",
+		Size:        int64(len(data)),
+		URL:         s.SignedURL(ctx, key),
+	}, nil
+}
+
+// Open reads a stored file. The caller closes it.
+func (s *MediaService) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := s.store.Open(ctx, key)
+	if errors.Is(err, domain.ErrRecordNotFound) {
+		return nil, ErrMediaNotFound
+	}
+	return file, err
+}
+
+// OpenSigned reads a file through a signed URL the API serves itself, with its
+// content type. Stores that serve their own URLs have nothing to open here.
+func (s *MediaService) OpenSigned(ctx context.Context, key, expires, signature string) (io.ReadCloser, string, error) {
+	verifier, ok := s.store.(signedURLVerifier)
+	if !ok {
+		return nil, "", ErrMediaNotFound
+	}
+	if err := verifier.VerifySignedURL(key, expires, signature); err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrMediaNotFound, err)
+	}
+
+	file, err := s.Open(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return file, contentType, nil
+}
+
+// Delete removes a file and its thumbnail. Failures are logged; a leftover
+// file is harmless.
+func (s *MediaService) Delete(ctx context.Context, key string) {
+	if !IsManagedKey(key) {
+		return
+	}
+	for _, k := range []string{key, thumbnailKey(key)} {
+		if err := s.store.Delete(ctx, k); err != nil {
+			log.Printf("Failed to delete stored file %s: %v", k, err)
+		}
+	}
+}
+
+// SignedURL returns an expiring URL for a stored file, or "" if it cannot be
+// signed
+func (s *MediaService) SignedURL(ctx context.Context, key string) string {
+	if key == "" {
+		return ""
+	}
+	url, err := s.store.SignedURL(ctx, key, s.urlExpiry)
+	if err != nil {
+		log.Printf("Failed to sign URL for %s: %v", key, err)
+		return ""
+	}
+	return url
+}
+
+// ImageURLs returns URLs for an image field and its thumbnail. Images saved
+// as full URLs before uploads were managed are returned as they are.
+func (s *MediaService) ImageURLs(ctx context.Context, key string) (url, thumbnailURL string) {
+	if key == "" {
+		return "", ""
+	}
+	if !IsManagedKey(key) {
+		return key, key
+	}
+	url = s.SignedURL(ctx, key)
+	if strings.EqualFold(path.Ext(key), ".webp") {
+		return url, url
+	}
+	return url, s.SignedURL(ctx, thumbnailKey(key))
+}
+
+// IsManagedKey tells object keys from image URLs stored before uploads were
+// managed
+func IsManagedKey(value string) bool {
+	return value != "" && !strings.Contains(value, "://")
+}
+
+// readUpload reads a whole upload, refusing ones over the limit
+func readUpload(file io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: the limit is %d MB", ErrMediaTooLarge, limit>>20)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("the file is empty")
+	}
+	return data, nil
+}
+
+// newObjectKey makes a random key under a prefix
+func newObjectKey(prefix, extension string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return prefix + "/" + hex.EncodeToString(id) + extension, nil
+}
+
+// thumbnailKey is where an image's thumbnail is kept
+func thumbnailKey(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_thumb.jpg"
+}
+
+// baseMediaType drops parameters such as the charset from a media type
+func baseMediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.TrimSpace(mediaType)
+}
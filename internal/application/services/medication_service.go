@@ -0,0 +1,472 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultMedicationTimezone = "Asia/Kolkata"
+
+	// An unlogged dose is counted as not taken once this much time has passed
+	doseGracePeriod = 2 * time.Hour
+)
+
+type MedicationService struct {
+	medicationRepo repositories.MedicationRepository
+}
+
+func NewMedicationService(medicationRepo repositories.MedicationRepository) *MedicationService {
+	return &MedicationService{
+		medicationRepo: medicationRepo,
+	}
+}
+
+// CreateRegimen validates a regimen, applies schedule defaults and stores it
+func (s *MedicationService) CreateRegimen(ctx context.Context, regimen *entities.MedicationRegimen) error {
+	if regimen.Name == "" {
+		return errors.New("medication name is required")
+	}
+	switch regimen.Category {
+	case "birth_control", "pcos", "prenatal", "other":
+	case "":
+		regimen.Category = "other"
+	default:
+		return fmt.Errorf("invalid category: %s", regimen.Category)
+	}
+
+	if regimen.Timezone == "" {
+		regimen.Timezone = defaultMedicationTimezone
+	}
+	loc, err := time.LoadLocation(regimen.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone: %s", regimen.Timezone)
+	}
+
+	if len(regimen.Times) == 0 {
+		return errors.New("at least one dose time is required")
+	}
+	for _, t := range regimen.Times {
+		if _, err := time.Parse("15:04", t); err != nil {
+			return fmt.Errorf("invalid dose time %q, use HH:MM", t)
+		}
+	}
+	sort.Strings(regimen.Times)
+
+	if regimen.StartDate.IsZero() {
+		now := time.Now().In(loc)
+		regimen.StartDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	}
+	if regimen.EndDate != nil && regimen.EndDate.Before(regimen.StartDate) {
+		return errors.New("end date must be after start date")
+	}
+
+	switch regimen.ScheduleType {
+	case "daily":
+	case "pill_pack":
+		if regimen.ActiveDays == 0 && regimen.BreakDays == 0 {
+			regimen.ActiveDays, regimen.BreakDays = 21, 7
+		}
+		if regimen.ActiveDays < 1 || regimen.BreakDays < 0 {
+			return errors.New("pill pack needs at least one active day")
+		}
+		switch regimen.BreakType {
+		case "placebo", "pill_free":
+		case "":
+			regimen.BreakType = "placebo"
+		default:
+			return fmt.Errorf("invalid break type: %s", regimen.BreakType)
+		}
+		if regimen.PackStartDate == nil {
+			packStart := regimen.StartDate
+			regimen.PackStartDate = &packStart
+		}
+	case "custom":
+		for _, day := range regimen.DaysOfWeek {
+			if day < 0 || day > 6 {
+				return errors.New("days of week must be between 0 (Sunday) and 6 (Saturday)")
+			}
+		}
+		if regimen.IntervalDays < 0 {
+			return errors.New("interval days cannot be negative")
+		}
+		if len(regimen.DaysOfWeek) == 0 && regimen.IntervalDays == 0 {
+			return errors.New("custom schedule needs days of week or an interval")
+		}
+	default:
+		return fmt.Errorf("invalid schedule type: %s", regimen.ScheduleType)
+	}
+
+	regimen.IsActive = true
+	return s.medicationRepo.CreateRegimen(ctx, regimen)
+}
+
+func (s *MedicationService) GetRegimens(ctx context.Context, userID string) ([]*entities.MedicationRegimen, error) {
+	return s.medicationRepo.FindRegimensByUserID(ctx, userID, false)
+}
+
+// StopRegimen deactivates a regimen so no further doses are scheduled
+func (s *MedicationService) StopRegimen(ctx context.Context, userID, regimenID string) error {
+	regimen, err := s.findUserRegimen(ctx, userID, regimenID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	regimen.IsActive = false
+	regimen.EndDate = &now
+	return s.medicationRepo.UpdateRegimen(ctx, regimen)
+}
+
+// LogDose records a scheduled dose as taken, missed or skipped
+func (s *MedicationService) LogDose(ctx context.Context, userID, regimenID string, scheduledAt time.Time, status string, takenAt *time.Time, notes string) (*entities.DoseLog, error) {
+	switch status {
+	case "taken", "missed", "skipped":
+	default:
+		return nil, fmt.Errorf("invalid dose status: %s", status)
+	}
+
+	regimen, err := s.findUserRegimen(ctx, userID, regimenID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dose *entities.ScheduledDose
+	for _, d := range scheduleDoses(regimen, scheduledAt.Add(-time.Minute), scheduledAt.Add(time.Minute)) {
+		if d.ScheduledAt.Equal(scheduledAt) {
+			dose = &d
+			break
+		}
+	}
+	if dose == nil {
+		return nil, errors.New("no dose is scheduled at this time")
+	}
+	if scheduledAt.After(time.Now().Add(24 * time.Hour)) {
+		return nil, errors.New("cannot log a dose more than 24 hours ahead")
+	}
+
+	if status == "taken" && takenAt == nil {
+		now := time.Now()
+		takenAt = &now
+	}
+	if status != "taken" {
+		takenAt = nil
+	}
+
+	log := &entities.DoseLog{
+		UserID:      regimen.UserID,
+		RegimenID:   regimen.ID,
+		ScheduledAt: dose.ScheduledAt,
+		Status:      status,
+		TakenAt:     takenAt,
+		IsPlacebo:   dose.IsPlacebo,
+		Notes:       notes,
+	}
+	if err := s.medicationRepo.UpsertDoseLog(ctx, log); err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// GetDoses returns the regimen's doses for the past days and the next 24 hours with their status
+func (s *MedicationService) GetDoses(ctx context.Context, userID, regimenID string, days int) ([]entities.ScheduledDose, error) {
+	if days < 1 || days > 90 {
+		days = 7
+	}
+
+	regimen, err := s.findUserRegimen(ctx, userID, regimenID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return s.dosesWithStatus(ctx, []*entities.MedicationRegimen{regimen}, now.AddDate(0, 0, -days), now.Add(24*time.Hour), now)
+}
+
+// GetUpcomingDoses returns the user's doses across all active regimens from 12 hours
+// ago until the given number of hours ahead
+func (s *MedicationService) GetUpcomingDoses(ctx context.Context, userID string, hours int) ([]entities.ScheduledDose, error) {
+	if hours < 1 || hours > 72 {
+		hours = 24
+	}
+
+	regimens, err := s.medicationRepo.FindRegimensByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return s.dosesWithStatus(ctx, regimens, now.Add(-12*time.Hour), now.Add(time.Duration(hours)*time.Hour), now)
+}
+
+// GetDueReminders returns the unlogged doses of every active regimen scheduled in
+// [from, to]. It is the query the notification worker polls to send reminders.
+func (s *MedicationService) GetDueReminders(ctx context.Context, from, to time.Time) ([]entities.ScheduledDose, error) {
+	regimens, err := s.medicationRepo.FindActiveRegimens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doses, err := s.dosesWithStatus(ctx, regimens, from, to, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	due := make([]entities.ScheduledDose, 0, len(doses))
+	for _, dose := range doses {
+		if dose.Status == "due" || dose.Status == "overdue" {
+			due = append(due, dose)
+		}
+	}
+
+	return due, nil
+}
+
+// GetAdherence computes adherence over the past days. Placebo pills are excluded.
+func (s *MedicationService) GetAdherence(ctx context.Context, userID, regimenID string, days int) (*entities.AdherenceStats, error) {
+	if days < 1 || days > 365 {
+		days = 30
+	}
+
+	regimen, err := s.findUserRegimen(ctx, userID, regimenID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -days)
+	doses, err := s.dosesWithStatus(ctx, []*entities.MedicationRegimen{regimen}, from, now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &entities.AdherenceStats{
+		RegimenID: regimen.ID,
+		From:      from,
+		To:        now,
+	}
+
+	streakOpen := true
+	// Doses are sorted newest first
+	for _, dose := range doses {
+		if dose.IsPlacebo || dose.Status == "due" {
+			continue
+		}
+		stats.Scheduled++
+		switch dose.Status {
+		case "taken":
+			stats.Taken++
+		case "missed":
+			stats.Missed++
+		case "skipped":
+			stats.Skipped++
+		case "overdue":
+			stats.Unlogged++
+		}
+		if streakOpen && dose.Status == "taken" {
+			stats.CurrentStreak++
+		} else {
+			streakOpen = false
+		}
+	}
+	if stats.Scheduled > 0 {
+		stats.AdherenceRate = float64(stats.Taken) / float64(stats.Scheduled)
+	}
+
+	return stats, nil
+}
+
+func (s *MedicationService) findUserRegimen(ctx context.Context, userID, regimenID string) (*entities.MedicationRegimen, error) {
+	regimen, err := s.medicationRepo.FindRegimenByID(ctx, regimenID)
+	if err != nil {
+		return nil, err
+	}
+	if regimen == nil || regimen.UserID.Hex() != userID {
+		return nil, errors.New("medication not found")
+	}
+	return regimen, nil
+}
+
+// dosesWithStatus schedules the regimens' doses in [from, to] and matches them
+// against the dose logs. Unlogged doses are "due" until the grace period passes,
+// then "overdue". Results are sorted newest first.
+func (s *MedicationService) dosesWithStatus(ctx context.Context, regimens []*entities.MedicationRegimen, from, to, now time.Time) ([]entities.ScheduledDose, error) {
+	if len(regimens) == 0 {
+		return []entities.ScheduledDose{}, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(regimens))
+	for i, regimen := range regimens {
+		ids[i] = regimen.ID
+	}
+	logs, err := s.medicationRepo.FindDoseLogs(ctx, ids, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	type doseKey struct {
+		regimenID primitive.ObjectID
+		at        int64
+	}
+	logged := make(map[doseKey]string, len(logs))
+	for _, log := range logs {
+		logged[doseKey{log.RegimenID, log.ScheduledAt.Unix()}] = log.Status
+	}
+
+	doses := []entities.ScheduledDose{}
+	for _, regimen := range regimens {
+		for _, dose := range scheduleDoses(regimen, from, to) {
+			if status, ok := logged[doseKey{dose.RegimenID, dose.ScheduledAt.Unix()}]; ok {
+				dose.Status = status
+			} else if now.Sub(dose.ScheduledAt) > doseGracePeriod {
+				dose.Status = "overdue"
+			}
+			doses = append(doses, dose)
+		}
+	}
+
+	sort.Slice(doses, func(i, j int) bool {
+		return doses[i].ScheduledAt.After(doses[j].ScheduledAt)
+	})
+
+	return doses, nil
+}
+
+// scheduleDoses expands a regimen's schedule into dose occurrences in [from, to]
+func scheduleDoses(regimen *entities.MedicationRegimen, from, to time.Time) []entities.ScheduledDose {
+	loc, err := time.LoadLocation(regimen.Timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(defaultMedicationTimezone)
+	}
+
+	first := from.In(loc)
+	if start := regimen.StartDate.In(loc); start.After(first) {
+		first = start
+	}
+	last := to.In(loc)
+	if regimen.EndDate != nil && regimen.EndDate.Before(to) {
+		last = regimen.EndDate.In(loc)
+	}
+
+	var doses []entities.ScheduledDose
+	for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc); !day.After(last); day = day.AddDate(0, 0, 1) {
+		scheduled, placebo, packDay := regimenDay(regimen, day)
+		if !scheduled {
+			continue
+		}
+		for _, t := range regimen.Times {
+			clock, err := time.Parse("15:04", t)
+			if err != nil {
+				continue
+			}
+			at := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+			if at.Before(from) || at.After(to) || at.Before(regimen.StartDate) || at.After(last) {
+				continue
+			}
+			doses = append(doses, entities.ScheduledDose{
+				RegimenID:   regimen.ID,
+				UserID:      regimen.UserID,
+				Name:        regimen.Name,
+				Dosage:      regimen.Dosage,
+				ScheduledAt: at.UTC(),
+				IsPlacebo:   placebo,
+				PackDay:     packDay,
+				Status:      "due",
+			})
+		}
+	}
+
+	return doses
+}
+
+// regimenDay reports whether the regimen has doses on the given day and, for pill
+// packs, whether they are placebo pills and which day of the pack it is
+func regimenDay(regimen *entities.MedicationRegimen, day time.Time) (scheduled, placebo bool, packDay int) {
+	switch regimen.ScheduleType {
+	case "pill_pack":
+		packStart := regimen.StartDate
+		if regimen.PackStartDate != nil {
+			packStart = *regimen.PackStartDate
+		}
+		elapsed := calendarDaysBetween(packStart.In(day.Location()), day)
+		packLength := regimen.ActiveDays + regimen.BreakDays
+		if elapsed < 0 || packLength == 0 {
+			return false, false, 0
+		}
+		position := elapsed % packLength
+		if position < regimen.ActiveDays {
+			return true, false, position + 1
+		}
+		return regimen.BreakType == "placebo", true, position + 1
+	case "custom":
+		if len(regimen.DaysOfWeek) > 0 {
+			found := false
+			for _, weekday := range regimen.DaysOfWeek {
+				if time.Weekday(weekday) == day.Weekday() {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false, false, 0
+			}
+		}
+		if regimen.IntervalDays > 1 && calendarDaysBetween(regimen.StartDate.In(day.Location()), day)%regimen.IntervalDays != 0 {
+			return false, false, 0
+		}
+		return true, false, 0
+	default:
+		return true, false, 0
+	}
+}
+
+// pillPackStatus reports the user's position in a pill pack on the given date
+func pillPackStatus(regimen *entities.MedicationRegimen, now time.Time) *entities.PillPackStatus {
+	loc, err := time.LoadLocation(regimen.Timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(defaultMedicationTimezone)
+	}
+	packStart := regimen.StartDate
+	if regimen.PackStartDate != nil {
+		packStart = *regimen.PackStartDate
+	}
+	packStart = packStart.In(loc)
+	packStart = time.Date(packStart.Year(), packStart.Month(), packStart.Day(), 0, 0, 0, 0, loc)
+
+	packLength := regimen.ActiveDays + regimen.BreakDays
+	elapsed := calendarDaysBetween(packStart, now.In(loc))
+	if packLength == 0 || elapsed < 0 {
+		return nil
+	}
+
+	position := elapsed % packLength
+	currentPackStart := packStart.AddDate(0, 0, elapsed-position)
+	nextBreak := currentPackStart.AddDate(0, 0, regimen.ActiveDays)
+	if position >= regimen.ActiveDays {
+		nextBreak = nextBreak.AddDate(0, 0, packLength)
+	}
+
+	return &entities.PillPackStatus{
+		RegimenID:      regimen.ID,
+		PackDay:        position + 1,
+		InBreak:        position >= regimen.ActiveDays,
+		BreakType:      regimen.BreakType,
+		NextBreakStart: nextBreak,
+		NextPackStart:  currentPackStart.AddDate(0, 0, packLength),
+	}
+}
+
+// calendarDaysBetween counts calendar days from a to b, ignoring time of day
+func calendarDaysBetween(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
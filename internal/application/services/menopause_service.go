@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+	"github.com/anshjamwal15/hsb_backend/pkg/auth"
+)
+
+const (
+	TrackingModePerimenopause = "perimenopause"
+	TrackingModePostmenopause = "postmenopause"
+
+	// Menopause is confirmed retrospectively after 12 consecutive months without a period
+	menopauseAmenorrheaMonths = 12
+)
+
+type MenopauseService struct {
+	userRepo         repositories.UserRepository
+	periodRepo       repositories.PeriodRepository
+	menopauseRepo    repositories.MenopauseRepository
+	mentalHealthRepo repositories.MentalHealthRepository
+}
+
+func NewMenopauseService(
+	userRepo repositories.UserRepository,
+	periodRepo repositories.PeriodRepository,
+	menopauseRepo repositories.MenopauseRepository,
+	mentalHealthRepo repositories.MentalHealthRepository,
+) *MenopauseService {
+	return &MenopauseService{
+		userRepo:         userRepo,
+		periodRepo:       periodRepo,
+		menopauseRepo:    menopauseRepo,
+		mentalHealthRepo: mentalHealthRepo,
+	}
+}
+
+// StartPerimenopause switches the user's profile to perimenopause mode
+func (s *MenopauseService) StartPerimenopause(ctx context.Context, userID string) (*entities.MenopauseStatus, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TrackingMode != TrackingModePerimenopause && user.TrackingMode != TrackingModePostmenopause {
+		user.TrackingMode = TrackingModePerimenopause
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.status(ctx, user)
+}
+
+// StopTracking turns menopause tracking off. Logged symptoms are kept.
+func (s *MenopauseService) StopTracking(ctx context.Context, userID string) error {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	user.TrackingMode = ""
+	return s.userRepo.Update(ctx, user)
+}
+
+// LogSymptom records a hot flash or night sweat
+func (s *MenopauseService) LogSymptom(ctx context.Context, log *entities.VasomotorLog) error {
+	switch log.Type {
+	case "hot_flash", "night_sweat":
+	default:
+		return fmt.Errorf("invalid symptom type: %s", log.Type)
+	}
+	switch log.Severity {
+	case "mild", "moderate", "severe":
+	default:
+		return fmt.Errorf("invalid severity: %s", log.Severity)
+	}
+	if log.DurationMinutes < 0 {
+		return errors.New("duration cannot be negative")
+	}
+	if log.OccurredAt.After(time.Now()) {
+		return errors.New("symptom time cannot be in the future")
+	}
+
+	return s.menopauseRepo.Create(ctx, log)
+}
+
+func (s *MenopauseService) GetSymptoms(ctx context.Context, userID, logType string) ([]*entities.VasomotorLog, error) {
+	return s.menopauseRepo.FindByUserID(ctx, userID, logType, 100) // Last 100 entries
+}
+
+// GetStatus returns the menopause status. When 12 consecutive months have passed
+// since the last logged period, the user is moved from perimenopause to postmenopause.
+func (s *MenopauseService) GetStatus(ctx context.Context, userID string) (*entities.MenopauseStatus, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TrackingMode != TrackingModePerimenopause && user.TrackingMode != TrackingModePostmenopause {
+		return nil, errors.New("perimenopause mode is not enabled")
+	}
+
+	return s.status(ctx, user)
+}
+
+func (s *MenopauseService) status(ctx context.Context, user *entities.User) (*entities.MenopauseStatus, error) {
+	userID := user.ID.Hex()
+	now := time.Now()
+
+	status := &entities.MenopauseStatus{
+		Mode:  user.TrackingMode,
+		Notes: []string{},
+	}
+
+	cycles, err := s.periodRepo.FindByUserID(ctx, userID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(cycles) > 0 {
+		lastPeriod := cycles[0].StartDate
+		if !cycles[0].EndDate.IsZero() && cycles[0].EndDate.After(lastPeriod) {
+			lastPeriod = cycles[0].EndDate
+		}
+		status.LastPeriodDate = &lastPeriod
+		status.MonthsSinceLastPeriod = monthsBetween(lastPeriod, now)
+
+		menopauseDate := lastPeriod.AddDate(0, menopauseAmenorrheaMonths, 0)
+		if !now.Before(menopauseDate) {
+			status.MenopauseReached = true
+			status.MenopauseDate = &menopauseDate
+		}
+	} else {
+		status.Notes = append(status.Notes, "Log your periods so we can detect when you reach menopause.")
+	}
+
+	switch {
+	case status.MenopauseReached && user.TrackingMode == TrackingModePerimenopause:
+		user.TrackingMode = TrackingModePostmenopause
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+		status.Mode = user.TrackingMode
+		status.Notes = append(status.Notes, "It has been 12 months since your last period, so you have reached menopause.")
+	case !status.MenopauseReached && status.LastPeriodDate != nil && user.TrackingMode == TrackingModePostmenopause:
+		status.Notes = append(status.Notes, "Bleeding after menopause should always be checked. Please consult a gynaecologist.")
+	}
+
+	logs, err := s.menopauseRepo.FindSince(ctx, userID, now.AddDate(0, 0, -7))
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range logs {
+		switch log.Type {
+		case "hot_flash":
+			status.HotFlashesLast7Days++
+		case "night_sweat":
+			status.NightSweatsLast7Days++
+		}
+	}
+
+	results, err := s.mentalHealthRepo.FindResultsByUserID(ctx, userID, "mrs")
+	if err != nil {
+		return nil, err
+	}
+	if len(results) > 0 {
+		status.LatestMRS = results[0]
+	} else {
+		status.Notes = append(status.Notes, "Take the Menopause Rating Scale (MRS) to measure how your symptoms affect you.")
+	}
+
+	return status, nil
+}
+
+func (s *MenopauseService) findUser(ctx context.Context, userID string) (*entities.User, error) {
+	objID, err := auth.ParseObjectID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.FindByID(ctx, objID)
+}
+
+// monthsBetween returns the number of whole calendar months from start to end
+func monthsBetween(start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
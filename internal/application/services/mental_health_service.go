@@ -2,101 +2,80 @@ package services
 
 import (
 	"context"
+	"log"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
 )
 
 type MentalHealthService struct {
-	mentalHealthRepo repositories.MentalHealthRepository
+	mentalHealthRepo     repositories.MentalHealthRepository
+	questionnaireService *QuestionnaireService
+	crisisService        *CrisisService
 }
 
-func NewMentalHealthService(mentalHealthRepo repositories.MentalHealthRepository) *MentalHealthService {
+func NewMentalHealthService(
+	mentalHealthRepo repositories.MentalHealthRepository,
+	questionnaireService *QuestionnaireService,
+	crisisService *CrisisService,
+) *MentalHealthService {
 	return &MentalHealthService{
-		mentalHealthRepo: mentalHealthRepo,
+		mentalHealthRepo:     mentalHealthRepo,
+		questionnaireService: questionnaireService,
+		crisisService:        crisisService,
 	}
 }
 
-func (s *MentalHealthService) GetTests() []entities.MentalHealthTest {
-	scaleOptions := []entities.QuestionOption{
-		{Value: 0, Label: "0"},
-		{Value: 1, Label: "1"},
-		{Value: 2, Label: "2"},
-		{Value: 3, Label: "3"},
+// GetTests returns the latest version of every questionnaire in the category, or all of them when category is empty,
+// with the text in the given locale
+func (s *MentalHealthService) GetTests(ctx context.Context, category, locale string) ([]*entities.MentalHealthTest, error) {
+	tests, err := s.questionnaireService.GetDefinitions(ctx, category)
+	if err != nil {
+		return nil, err
 	}
 
-	return []entities.MentalHealthTest{
-		{
-			Name:        "phq9",
-			DisplayName: "PHQ-9 Depression Test",
-			Description: "Patient Health Questionnaire for depression screening",
-			Questions: []entities.TestQuestion{
-				{
-					QuestionText: "Little interest or pleasure in doing things",
-					ResponseType: "scale",
-					Options:     scaleOptions,
-				},
-				{
-					QuestionText: "Feeling down, depressed, or hopeless",
-					ResponseType: "scale",
-					Options:     scaleOptions,
-				},
-			},
-		},
-		{
-			Name:        "gad7",
-			DisplayName: "GAD-7 Anxiety Test",
-			Description: "Generalized Anxiety Disorder 7-item scale",
-			Questions: []entities.TestQuestion{
-				{
-					QuestionText: "Feeling nervous, anxious, or on edge",
-					ResponseType: "scale",
-					Options:     scaleOptions,
-				},
-				{
-					QuestionText: "Not being able to stop or control worrying",
-					ResponseType: "scale",
-					Options:     scaleOptions,
-				},
-			},
-		},
-	}
+	return s.questionnaireService.LocalizeAll(ctx, tests, locale)
 }
 
-func (s *MentalHealthService) GetTestByName(testName string) *entities.MentalHealthTest {
-	tests := s.GetTests()
-	for _, test := range tests {
-		if test.Name == testName {
-			return &test
-		}
+func (s *MentalHealthService) GetTestByName(ctx context.Context, testName, locale string) (*entities.MentalHealthTest, error) {
+	test, err := s.questionnaireService.GetDefinition(ctx, testName, 0)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	return s.questionnaireService.Localize(ctx, test, locale)
 }
 
-func (s *MentalHealthService) SubmitTestResults(ctx context.Context, result *entities.TestResult) error {
-	// Calculate score
-	score := 0
-	for _, answer := range result.Answers {
-		if val, ok := answer.(float64); ok {
-			score += int(val)
-		}
+// SubmitTestResults scores the answers against the questionnaire definition and stores
+// the result. Critical and high-alert results are escalated. It returns the result
+// with its text in the locale the user answered in, and the crisis information to
+// show the user.
+func (s *MentalHealthService) SubmitTestResults(ctx context.Context, result *entities.TestResult) (*entities.TestResult, *entities.CrisisResponse, error) {
+	test, err := s.questionnaireService.Score(ctx, result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.mentalHealthRepo.CreateResult(ctx, result); err != nil {
+		return nil, nil, err
 	}
-	result.Score = score
 
-	// Determine level based on score
-	if score <= 4 {
-		result.Level = "minimal"
-	} else if score <= 9 {
-		result.Level = "mild"
-	} else if score <= 14 {
-		result.Level = "moderate"
+	// The result is stored, so a missing translation must not hide the crisis information
+	localized := result
+	if results, err := s.questionnaireService.LocalizeResults(ctx, []*entities.TestResult{result}, result.Locale); err != nil {
+		log.Printf("Failed to translate result %s: %v", result.ID.Hex(), err)
 	} else {
-		result.Level = "severe"
+		localized = results[0]
 	}
 
-	return s.mentalHealthRepo.CreateResult(ctx, result)
+	return localized, s.crisisService.Escalate(ctx, test, localized), nil
 }
 
-func (s *MentalHealthService) GetTestResults(ctx context.Context, userID string, testName string) ([]*entities.TestResult, error) {
-	return s.mentalHealthRepo.FindResultsByUserID(ctx, userID, testName)
+func (s *MentalHealthService) GetTestResults(ctx context.Context, userID, testName, locale string) ([]*entities.TestResult, error) {
+	results, err := s.mentalHealthRepo.FindResultsByUserID(ctx, userID, testName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.questionnaireService.LocalizeResults(ctx, results, locale)
 }
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+const (
+	// Cycles shorter than 21 or longer than 35 days suggest oligo/anovulation
+	minOvulatoryCycleDays = 21
+	maxOvulatoryCycleDays = 35
+	// No period for 90 days is treated as amenorrhea
+	amenorrheaDays = 90
+
+	// The 2023 international guideline puts the mFG cutoff at 4-6 depending on
+	// ethnicity; we use the upper end to avoid over-calling hirsutism
+	mfgHirsutismCutoff = 6
+	mfgMaxAreaScore    = 4
+
+	// Used when the lab did not report its own reference range
+	defaultTestosteroneUpperLimit = 60.0 // ng/dL
+	freeAndrogenIndexCutoff       = 5.0
+
+	// Polycystic ovarian morphology thresholds, per ovary
+	pcomFollicleCount = 20
+	pcomVolumeMl      = 10.0
+)
+
+// Tests recommended by the assessment, with the keywords used to match them
+// against the tests listed in diagnostics packages
+var pcosTestKeywords = map[string][]string{
+	"Pelvic ultrasound":      {"ultrasound", "usg", "sonography"},
+	"Total testosterone":     {"testosterone"},
+	"SHBG":                   {"shbg", "sex hormone binding"},
+	"TSH":                    {"tsh", "thyroid"},
+	"Prolactin":              {"prolactin"},
+	"17-OH progesterone":     {"17-oh", "17 oh", "hydroxyprogesterone"},
+	"Oral glucose tolerance": {"ogtt", "glucose tolerance", "glucose", "hba1c"},
+	"Lipid profile":          {"lipid", "cholesterol"},
+}
+
+// ovulatoryDysfunction derives the criterion from logged periods, falling back
+// to the self-reported answer when there are too few cycles to judge
+func ovulatoryDysfunction(cycles []*entities.PeriodCycle, selfReported *bool, now time.Time) entities.RotterdamCriterion {
+	starts := make([]time.Time, 0, len(cycles))
+	for _, cycle := range cycles {
+		starts = append(starts, cycle.StartDate)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	if len(starts) > 0 {
+		if days := int(now.Sub(starts[len(starts)-1]).Hours() / 24); days > amenorrheaDays {
+			return entities.RotterdamCriterion{
+				Status:  entities.CriterionMet,
+				Source:  "period_tracker",
+				Details: []string{fmt.Sprintf("No period logged in the last %d days", days)},
+			}
+		}
+	}
+
+	if len(starts) >= 3 {
+		irregular := 0
+		for i := 1; i < len(starts); i++ {
+			length := int(starts[i].Sub(starts[i-1]).Hours() / 24)
+			if length < minOvulatoryCycleDays || length > maxOvulatoryCycleDays {
+				irregular++
+			}
+		}
+		intervals := len(starts) - 1
+		detail := fmt.Sprintf("%d of %d logged cycles outside %d-%d days",
+			irregular, intervals, minOvulatoryCycleDays, maxOvulatoryCycleDays)
+
+		status := entities.CriterionNotMet
+		if irregular*2 > intervals {
+			status = entities.CriterionMet
+		} else if irregular > 0 {
+			status = entities.CriterionPossible
+		}
+		return entities.RotterdamCriterion{Status: status, Source: "period_tracker", Details: []string{detail}}
+	}
+
+	if selfReported != nil {
+		status := entities.CriterionNotMet
+		if *selfReported {
+			status = entities.CriterionMet
+		}
+		return entities.RotterdamCriterion{
+			Status:  status,
+			Source:  "self_reported",
+			Details: []string{"Log at least three periods to derive this from your cycles"},
+		}
+	}
+
+	return entities.RotterdamCriterion{Status: entities.CriterionUnknown}
+}
+
+// hyperandrogenism checks clinical signs (mFG hirsutism, acne, hair thinning)
+// and biochemical results. Acne or hair thinning alone are only suggestive.
+func hyperandrogenism(assessment *entities.PCOSAssessment) entities.RotterdamCriterion {
+	criterion := entities.RotterdamCriterion{Status: entities.CriterionUnknown}
+	var sources []string
+	assessed := false
+	suggestive := false
+
+	if mfg := assessment.FerrimanGallwey; mfg != nil {
+		assessed = true
+		sources = append(sources, "clinical")
+		total := mfg.Total()
+		if total >= mfgHirsutismCutoff {
+			criterion.Status = entities.CriterionMet
+			criterion.Details = append(criterion.Details, fmt.Sprintf("Hirsutism: mFG score %d (cutoff %d)", total, mfgHirsutismCutoff))
+		} else {
+			criterion.Details = append(criterion.Details, fmt.Sprintf("mFG score %d is below the hirsutism cutoff of %d", total, mfgHirsutismCutoff))
+		}
+	}
+	if assessment.Acne != nil || assessment.HairThinning != nil {
+		if assessment.FerrimanGallwey == nil {
+			sources = append(sources, "clinical")
+		}
+		if assessment.Acne != nil && *assessment.Acne {
+			suggestive = true
+			criterion.Details = append(criterion.Details, "Acne reported")
+		}
+		if assessment.HairThinning != nil && *assessment.HairThinning {
+			suggestive = true
+			criterion.Details = append(criterion.Details, "Hair thinning reported")
+		}
+	}
+
+	if labs := assessment.Labs; labs != nil && (labs.TotalTestosterone != nil || labs.FreeAndrogenIndex != nil) {
+		assessed = true
+		sources = append(sources, "biochemical")
+		if labs.TotalTestosterone != nil {
+			limit := defaultTestosteroneUpperLimit
+			if labs.TestosteroneUpperLimit != nil {
+				limit = *labs.TestosteroneUpperLimit
+			}
+			if *labs.TotalTestosterone > limit {
+				criterion.Status = entities.CriterionMet
+				criterion.Details = append(criterion.Details, fmt.Sprintf("Total testosterone %.1f ng/dL above %.1f", *labs.TotalTestosterone, limit))
+			}
+		}
+		if labs.FreeAndrogenIndex != nil && *labs.FreeAndrogenIndex > freeAndrogenIndexCutoff {
+			criterion.Status = entities.CriterionMet
+			criterion.Details = append(criterion.Details, fmt.Sprintf("Free androgen index %.1f above %.1f", *labs.FreeAndrogenIndex, freeAndrogenIndexCutoff))
+		}
+	}
+
+	criterion.Source = strings.Join(sources, ",")
+	if criterion.Status == entities.CriterionMet {
+		return criterion
+	}
+	if suggestive {
+		criterion.Status = entities.CriterionPossible
+	} else if assessed {
+		criterion.Status = entities.CriterionNotMet
+	}
+	return criterion
+}
+
+// polycysticOvaries checks the latest ultrasound. Follicle counts are only
+// reliable on transvaginal scans, so transabdominal scans use volume alone.
+func polycysticOvaries(ultrasound *entities.OvarianUltrasound) entities.RotterdamCriterion {
+	if ultrasound == nil {
+		return entities.RotterdamCriterion{Status: entities.CriterionUnknown}
+	}
+
+	criterion := entities.RotterdamCriterion{
+		Status: entities.CriterionNotMet,
+		Source: "ultrasound",
+		Details: []string{fmt.Sprintf("%s scan on %s",
+			ultrasound.Approach, ultrasound.ScanDate.Format("2006-01-02"))},
+	}
+
+	ovaries := []struct {
+		side      string
+		follicles *int
+		volume    *float64
+	}{
+		{"left", ultrasound.LeftFollicleCount, ultrasound.LeftVolumeMl},
+		{"right", ultrasound.RightFollicleCount, ultrasound.RightVolumeMl},
+	}
+	for _, ovary := range ovaries {
+		if ultrasound.Approach == "transvaginal" && ovary.follicles != nil && *ovary.follicles >= pcomFollicleCount {
+			criterion.Status = entities.CriterionMet
+			criterion.Details = append(criterion.Details, fmt.Sprintf("%d follicles in the %s ovary", *ovary.follicles, ovary.side))
+		}
+		if ovary.volume != nil && *ovary.volume >= pcomVolumeMl {
+			criterion.Status = entities.CriterionMet
+			criterion.Details = append(criterion.Details, fmt.Sprintf("%s ovarian volume %.1f mL", ovary.side, *ovary.volume))
+		}
+	}
+
+	return criterion
+}
+
+// rotterdamPhenotype returns the NIH phenotype (A-D) for a positive assessment
+func rotterdamPhenotype(criteria entities.RotterdamCriteria) string {
+	ovulatory := criteria.OvulatoryDysfunction.Status == entities.CriterionMet
+	androgen := criteria.Hyperandrogenism.Status == entities.CriterionMet
+	ovaries := criteria.PolycysticOvaries.Status == entities.CriterionMet
+
+	switch {
+	case ovulatory && androgen && ovaries:
+		return "A"
+	case ovulatory && androgen:
+		return "B"
+	case androgen && ovaries:
+		return "C"
+	case ovulatory && ovaries:
+		return "D"
+	}
+	return ""
+}
+
+// evaluateRotterdam fills in the result, phenotype and next steps
+func evaluateRotterdam(assessment *entities.PCOSAssessment) {
+	criteria := []entities.RotterdamCriterion{
+		assessment.Criteria.OvulatoryDysfunction,
+		assessment.Criteria.Hyperandrogenism,
+		assessment.Criteria.PolycysticOvaries,
+	}
+	met, open := 0, 0
+	for _, criterion := range criteria {
+		switch criterion.Status {
+		case entities.CriterionMet:
+			met++
+		case entities.CriterionPossible, entities.CriterionUnknown:
+			open++
+		}
+	}
+	assessment.CriteriaMet = met
+
+	var recommendations, tests []string
+	switch {
+	case met >= 2:
+		assessment.Result = "rotterdam criteria met"
+		assessment.Phenotype = rotterdamPhenotype(assessment.Criteria)
+		recommendations = append(recommendations,
+			"Your results meet the Rotterdam criteria for PCOS. Book a consultation with a gynaecologist to confirm the diagnosis.",
+			"Thyroid disease, high prolactin and non-classic congenital adrenal hyperplasia must be ruled out before a PCOS diagnosis is confirmed.",
+			"PCOS increases the risk of diabetes and high cholesterol, so metabolic screening is recommended.")
+		tests = append(tests, "TSH", "Prolactin", "17-OH progesterone", "Oral glucose tolerance", "Lipid profile")
+	case met+open >= 2:
+		assessment.Result = "more information needed"
+		recommendations = append(recommendations,
+			"Some findings are consistent with PCOS. The tests below will show whether the Rotterdam criteria are met.")
+	default:
+		assessment.Result = "rotterdam criteria not met"
+		recommendations = append(recommendations,
+			"Your results do not meet the Rotterdam criteria for PCOS. Keep tracking your cycles and see a doctor if symptoms change.")
+	}
+
+	if met < 2 {
+		switch assessment.Criteria.OvulatoryDysfunction.Status {
+		case entities.CriterionUnknown, entities.CriterionPossible:
+			recommendations = append(recommendations, "Log your periods for at least three cycles so cycle regularity can be assessed.")
+		}
+		switch assessment.Criteria.Hyperandrogenism.Status {
+		case entities.CriterionUnknown, entities.CriterionPossible:
+			tests = append(tests, "Total testosterone", "SHBG")
+		}
+		if assessment.Criteria.PolycysticOvaries.Status == entities.CriterionUnknown {
+			tests = append(tests, "Pelvic ultrasound")
+		}
+	}
+	if assessment.Criteria.Hyperandrogenism.Status == entities.CriterionPossible {
+		recommendations = append(recommendations, "Acne and hair thinning can be signs of raised androgens; a blood test will confirm.")
+	}
+
+	assessment.Recommendations = recommendations
+	assessment.RecommendedTests = tests
+	if assessment.RecommendedTests == nil {
+		assessment.RecommendedTests = []string{}
+	}
+}
+
+// suggestDiagnostics returns up to three packages covering the most
+// recommended tests, cheapest first among equals
+func suggestDiagnostics(diagnostics []*entities.Diagnostics, tests []string) []entities.DiagnosticSuggestion {
+	var suggestions []entities.DiagnosticSuggestion
+	for _, diagnostic := range diagnostics {
+		included := strings.ToLower(diagnostic.TestName + " " + strings.Join(diagnostic.Tests, " "))
+		var covers []string
+		for _, test := range tests {
+			for _, keyword := range pcosTestKeywords[test] {
+				if strings.Contains(included, keyword) {
+					covers = append(covers, test)
+					break
+				}
+			}
+		}
+		if len(covers) == 0 {
+			continue
+		}
+		suggestions = append(suggestions, entities.DiagnosticSuggestion{
+			DiagnosticID: diagnostic.ID.Hex(),
+			Name:         diagnostic.TestName,
+			Price:        diagnostic.TestPrice,
+			Covers:       covers,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if len(suggestions[i].Covers) != len(suggestions[j].Covers) {
+			return len(suggestions[i].Covers) > len(suggestions[j].Covers)
+		}
+		return suggestions[i].Price < suggestions[j].Price
+	})
+	if len(suggestions) > 3 {
+		suggestions = suggestions[:3]
+	}
+	return suggestions
+}
+
+func validateRotterdamInput(assessment *entities.PCOSAssessment) error {
+	if mfg := assessment.FerrimanGallwey; mfg != nil {
+		for _, area := range mfg.Areas() {
+			if area < 0 || area > mfgMaxAreaScore {
+				return fmt.Errorf("Ferriman-Gallwey area scores must be between 0 and %d", mfgMaxAreaScore)
+			}
+		}
+	}
+	if labs := assessment.Labs; labs != nil {
+		for _, value := range []*float64{labs.TotalTestosterone, labs.TestosteroneUpperLimit, labs.FreeAndrogenIndex} {
+			if value != nil && *value < 0 {
+				return errors.New("lab values cannot be negative")
+			}
+		}
+	}
+	return nil
+}
+
+func validateUltrasound(ultrasound *entities.OvarianUltrasound) error {
+	switch ultrasound.Approach {
+	case "transvaginal", "transabdominal":
+	default:
+		return fmt.Errorf("invalid ultrasound approach: %s", ultrasound.Approach)
+	}
+	if ultrasound.ScanDate.IsZero() {
+		return errors.New("scan date is required")
+	}
+	if ultrasound.ScanDate.After(time.Now()) {
+		return errors.New("scan date cannot be in the future")
+	}
+	if ultrasound.LeftFollicleCount == nil && ultrasound.RightFollicleCount == nil &&
+		ultrasound.LeftVolumeMl == nil && ultrasound.RightVolumeMl == nil {
+		return errors.New("at least one follicle count or ovarian volume is required")
+	}
+	for _, count := range []*int{ultrasound.LeftFollicleCount, ultrasound.RightFollicleCount} {
+		if count != nil && *count < 0 {
+			return errors.New("follicle count cannot be negative")
+		}
+	}
+	for _, volume := range []*float64{ultrasound.LeftVolumeMl, ultrasound.RightVolumeMl} {
+		if volume != nil && *volume < 0 {
+			return errors.New("ovarian volume cannot be negative")
+		}
+	}
+	return nil
+}
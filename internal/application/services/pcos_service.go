@@ -2,58 +2,169 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
 )
 
 type PCOSService struct {
-	pcosRepo repositories.PCOSRepository
+	pcosRepo             repositories.PCOSRepository
+	periodRepo           repositories.PeriodRepository
+	diagnosticRepo       repositories.DiagnosticRepository
+	questionnaireService *QuestionnaireService
 }
 
-func NewPCOSService(pcosRepo repositories.PCOSRepository) *PCOSService {
+func NewPCOSService(
+	pcosRepo repositories.PCOSRepository,
+	periodRepo repositories.PeriodRepository,
+	diagnosticRepo repositories.DiagnosticRepository,
+	questionnaireService *QuestionnaireService,
+) *PCOSService {
 	return &PCOSService{
-		pcosRepo: pcosRepo,
+		pcosRepo:             pcosRepo,
+		periodRepo:           periodRepo,
+		diagnosticRepo:       diagnosticRepo,
+		questionnaireService: questionnaireService,
 	}
 }
 
-func (s *PCOSService) GetQuestions() []entities.PCOSQuestion {
-	return []entities.PCOSQuestion{
-		{ID: "q1", Question: "Do you have irregular periods?", Type: "yes_no"},
-		{ID: "q2", Question: "Do you experience excessive hair growth?", Type: "yes_no"},
-		{ID: "q3", Question: "Do you have acne or oily skin?", Type: "yes_no"},
-		{ID: "q4", Question: "Have you experienced weight gain?", Type: "yes_no"},
-		{ID: "q5", Question: "Do you have difficulty losing weight?", Type: "yes_no"},
-		{ID: "q6", Question: "Do you experience hair thinning or hair loss?", Type: "yes_no"},
-		{ID: "q7", Question: "Do you have darkening of skin in body folds?", Type: "yes_no"},
-		{ID: "q8", Question: "Have you been diagnosed with insulin resistance?", Type: "yes_no"},
+// GetQuestions returns the symptom screening questions with their text in the given locale
+func (s *PCOSService) GetQuestions(ctx context.Context, locale string) ([]entities.PCOSQuestion, error) {
+	test, err := s.questionnaireService.GetDefinition(ctx, "pcos", 0)
+	if err != nil {
+		return nil, err
 	}
+	if test, err = s.questionnaireService.Localize(ctx, test, locale); err != nil {
+		return nil, err
+	}
+
+	items := questionItems(test)
+	questions := make([]entities.PCOSQuestion, 0, len(items))
+	for _, item := range items {
+		question := entities.PCOSQuestion{ID: item.Key, Question: item.QuestionText}
+		switch item.ResponseType {
+		case "binary":
+			question.Type = "yes_no"
+		case "slider":
+			question.Type = "scale"
+		default:
+			question.Type = "multiple_choice"
+			for _, option := range item.Options {
+				question.Options = append(question.Options, option.Label)
+			}
+		}
+		questions = append(questions, question)
+	}
+
+	return questions, nil
 }
 
+// SubmitAssessment evaluates the Rotterdam criteria: ovulatory dysfunction from
+// logged periods, hyperandrogenism from the mFG score, symptoms and labs, and
+// polycystic ovaries from the latest uploaded ultrasound. Two of the three
+// criteria must be met.
 func (s *PCOSService) SubmitAssessment(ctx context.Context, assessment *entities.PCOSAssessment) error {
-	// Calculate score based on responses
-	score := 0
-	for _, response := range assessment.Responses {
-		if answer, ok := response.(bool); ok && answer {
-			score++
-		} else if answer, ok := response.(string); ok && answer == "yes" {
-			score++
+	if err := validateRotterdamInput(assessment); err != nil {
+		return err
+	}
+	if len(assessment.Responses) > 0 {
+		if err := s.scoreSymptoms(ctx, assessment); err != nil {
+			return err
 		}
 	}
-	assessment.Score = score
 
-	// Determine result based on score
-	if score <= 2 {
-		assessment.Result = "low risk"
-	} else if score <= 5 {
-		assessment.Result = "moderate risk"
-	} else {
-		assessment.Result = "high risk"
+	userID := assessment.UserID.Hex()
+	cycles, err := s.periodRepo.FindByUserID(ctx, userID, 13) // About a year of cycles
+	if err != nil {
+		return err
+	}
+	ultrasound, err := s.pcosRepo.FindLatestUltrasound(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	assessment.Criteria = entities.RotterdamCriteria{
+		OvulatoryDysfunction: ovulatoryDysfunction(cycles, assessment.IrregularCycles, time.Now()),
+		Hyperandrogenism:     hyperandrogenism(assessment),
+		PolycysticOvaries:    polycysticOvaries(ultrasound),
+	}
+	evaluateRotterdam(assessment)
+
+	if len(assessment.RecommendedTests) > 0 {
+		diagnostics, err := s.diagnosticRepo.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+		assessment.RecommendedPackages = suggestDiagnostics(diagnostics, assessment.RecommendedTests)
+		if len(assessment.RecommendedPackages) > 0 {
+			assessment.Recommendations = append(assessment.Recommendations,
+				"Book one of the suggested diagnostics packages to complete the recommended tests.")
+		}
 	}
 
 	return s.pcosRepo.Create(ctx, assessment)
 }
 
+// scoreSymptoms scores the symptom screening responses, given in question
+// order, and uses the answers on periods, acne and hair thinning where the
+// assessment does not already say
+func (s *PCOSService) scoreSymptoms(ctx context.Context, assessment *entities.PCOSAssessment) error {
+	test, err := s.questionnaireService.GetDefinition(ctx, "pcos", assessment.QuestionnaireVersion)
+	if err != nil {
+		return err
+	}
+
+	items := questionItems(test)
+	if len(assessment.Responses) != len(items) {
+		return fmt.Errorf("expected %d responses, got %d", len(items), len(assessment.Responses))
+	}
+
+	result := &entities.TestResult{Answers: make(map[string]interface{}, len(items))}
+	for i, item := range items {
+		result.Answers[item.Key] = assessment.Responses[i]
+	}
+	if err := scoreTestResult(test, result); err != nil {
+		return err
+	}
+
+	assessment.Score = result.Score
+	assessment.QuestionnaireVersion = test.Version
+
+	answered := func(key string) *bool {
+		value, ok := result.Answers[key].(float64)
+		if !ok {
+			return nil
+		}
+		yes := value > 0
+		return &yes
+	}
+	if assessment.IrregularCycles == nil {
+		assessment.IrregularCycles = answered("q1")
+	}
+	if assessment.Acne == nil {
+		assessment.Acne = answered("q3")
+	}
+	if assessment.HairThinning == nil {
+		assessment.HairThinning = answered("q6")
+	}
+
+	return nil
+}
+
+func (s *PCOSService) AddUltrasound(ctx context.Context, ultrasound *entities.OvarianUltrasound) error {
+	if err := validateUltrasound(ultrasound); err != nil {
+		return err
+	}
+
+	return s.pcosRepo.CreateUltrasound(ctx, ultrasound)
+}
+
+func (s *PCOSService) GetUltrasounds(ctx context.Context, userID string) ([]*entities.OvarianUltrasound, error) {
+	return s.pcosRepo.FindUltrasoundsByUserID(ctx, userID, 10)
+}
+
 func (s *PCOSService) GetHistory(ctx context.Context, userID string) ([]*entities.PCOSAssessment, error) {
 	return s.pcosRepo.FindByUserID(ctx, userID, 10)
 }
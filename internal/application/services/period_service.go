@@ -2,18 +2,36 @@ package services
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
 )
 
+const (
+	defaultCycleLength = 28
+	lutealPhaseDays    = 14
+
+	// Withdrawal bleeding usually starts 2-3 days into the pill break
+	withdrawalBleedDelayDays = 2
+)
+
 type PeriodService struct {
-	periodRepo repositories.PeriodRepository
+	periodRepo     repositories.PeriodRepository
+	fertilityRepo  repositories.FertilityRepository
+	medicationRepo repositories.MedicationRepository
 }
 
-func NewPeriodService(periodRepo repositories.PeriodRepository) *PeriodService {
+func NewPeriodService(
+	periodRepo repositories.PeriodRepository,
+	fertilityRepo repositories.FertilityRepository,
+	medicationRepo repositories.MedicationRepository,
+) *PeriodService {
 	return &PeriodService{
-		periodRepo: periodRepo,
+		periodRepo:     periodRepo,
+		fertilityRepo:  fertilityRepo,
+		medicationRepo: medicationRepo,
 	}
 }
 
@@ -28,3 +46,106 @@ func (s *PeriodService) GetPeriodCycles(ctx context.Context, userID string) ([]*
 func (s *PeriodService) ResetPeriodTracker(ctx context.Context, userID string) error {
 	return s.periodRepo.DeleteByUserID(ctx, userID)
 }
+
+// GetPrediction predicts the next period and fertile window from the last 12 cycles,
+// using confirmed ovulation from basal body temperature logs when available
+func (s *PeriodService) GetPrediction(ctx context.Context, userID string) (*entities.PeriodPrediction, error) {
+	cycles, err := s.periodRepo.FindByUserID(ctx, userID, 12)
+	if err != nil {
+		return nil, err
+	}
+	if len(cycles) == 0 {
+		return nil, errors.New("no period logged yet")
+	}
+
+	lastStart := cycles[0].StartDate
+	cycleLength := averageCycleLength(cycles)
+
+	nextPeriod := lastStart.AddDate(0, 0, cycleLength)
+	ovulation := nextPeriod.AddDate(0, 0, -lutealPhaseDays)
+	prediction := &entities.PeriodPrediction{
+		LastPeriodStart:    lastStart,
+		AverageCycleLength: cycleLength,
+		NextPeriodStart:    nextPeriod,
+		Basis:              "calendar",
+		Notes:              []string{},
+	}
+	setOvulation(prediction, ovulation)
+
+	// Combined pills with a break week suppress ovulation; bleeding in the break is a
+	// withdrawal bleed rather than a period
+	regimens, err := s.medicationRepo.FindRegimensByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, regimen := range regimens {
+		if regimen.Category != "birth_control" || regimen.ScheduleType != "pill_pack" || regimen.BreakDays == 0 {
+			continue
+		}
+		status := pillPackStatus(regimen, time.Now())
+		if status == nil {
+			continue
+		}
+		prediction.PillPack = status
+		prediction.NextPeriodStart = status.NextBreakStart.AddDate(0, 0, withdrawalBleedDelayDays)
+		prediction.PredictedOvulationDate = nil
+		prediction.FertileWindowStart = nil
+		prediction.FertileWindowEnd = nil
+		prediction.Basis = "pill_pack"
+		if status.InBreak {
+			prediction.Notes = append(prediction.Notes, "You are in your pill break. Bleeding now is a withdrawal bleed and is expected.")
+		}
+		prediction.Notes = append(prediction.Notes, "Predictions follow your pill pack. Fertile window predictions do not apply while you take the pill as directed.")
+		return prediction, nil
+	}
+
+	status, err := currentCycleOvulation(ctx, s.fertilityRepo, userID, lastStart)
+	if err != nil {
+		return nil, err
+	}
+	if status.Confirmed {
+		confirmed := *status.OvulationDate
+		prediction.OvulationConfirmed = true
+		prediction.ConfirmedOvulationDate = &confirmed
+		prediction.NextPeriodStart = confirmed.AddDate(0, 0, lutealPhaseDays)
+		prediction.Basis = "temperature_shift"
+		setOvulation(prediction, confirmed)
+		prediction.Notes = append(prediction.Notes, status.Notes...)
+	}
+
+	return prediction, nil
+}
+
+func setOvulation(prediction *entities.PeriodPrediction, ovulation time.Time) {
+	windowStart := ovulation.AddDate(0, 0, -5)
+	windowEnd := ovulation.AddDate(0, 0, 1)
+	prediction.PredictedOvulationDate = &ovulation
+	prediction.FertileWindowStart = &windowStart
+	prediction.FertileWindowEnd = &windowEnd
+}
+
+// averageCycleLength averages the gaps between consecutive period starts (newest
+// first), falling back to recorded cycle lengths and then to 28 days
+func averageCycleLength(cycles []*entities.PeriodCycle) int {
+	total, count := 0, 0
+	for i := 0; i+1 < len(cycles); i++ {
+		days := int(cycles[i].StartDate.Sub(cycles[i+1].StartDate).Hours() / 24)
+		// Ignore gaps that are not plausible cycles, e.g. missed logs
+		if days >= 15 && days <= 60 {
+			total += days
+			count++
+		}
+	}
+	if count == 0 {
+		for _, cycle := range cycles {
+			if cycle.CycleLength > 0 {
+				total += cycle.CycleLength
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return defaultCycleLength
+	}
+	return (total + count/2) / count
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+)
+
+// Maternal warning signs that need urgent care after birth
+var urgentPostpartumSymptoms = map[string]string{
+	"fever":                "Fever of 38°C (100.4°F) or higher",
+	"chest pain":           "Chest pain",
+	"shortness of breath":  "Shortness of breath",
+	"severe headache":      "Severe headache",
+	"vision changes":       "Changes in vision",
+	"seizure":              "Seizure",
+	"calf pain":            "Swelling, redness or pain in a leg",
+	"incision discharge":   "Discharge or redness around an incision",
+	"foul smelling lochia": "Foul-smelling discharge",
+}
+
+type PostpartumService struct {
+	pregnancyRepo    repositories.PregnancyRepository
+	postpartumRepo   repositories.PostpartumRepository
+	mentalHealthRepo repositories.MentalHealthRepository
+}
+
+func NewPostpartumService(
+	pregnancyRepo repositories.PregnancyRepository,
+	postpartumRepo repositories.PostpartumRepository,
+	mentalHealthRepo repositories.MentalHealthRepository,
+) *PostpartumService {
+	return &PostpartumService{
+		pregnancyRepo:    pregnancyRepo,
+		postpartumRepo:   postpartumRepo,
+		mentalHealthRepo: mentalHealthRepo,
+	}
+}
+
+// StartPostpartum ends the pregnancy on the user's tracker and switches it to
+// postpartum mode, stopping any contraction or kick session still running
+func (s *PostpartumService) StartPostpartum(ctx context.Context, userID string, deliveryDate time.Time, deliveryType string) (*entities.PregnancyTracker, error) {
+	if deliveryDate.After(time.Now()) {
+		return nil, errors.New("delivery date cannot be in the future")
+	}
+	if deliveryType != "" && deliveryType != "vaginal" && deliveryType != "cesarean" {
+		return nil, fmt.Errorf("invalid delivery type: %s", deliveryType)
+	}
+
+	tracker, err := s.pregnancyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tracker == nil {
+		return nil, errors.New("pregnancy tracker not found")
+	}
+
+	tracker.Status = "postpartum"
+	tracker.DeliveryDate = &deliveryDate
+	tracker.DeliveryType = deliveryType
+
+	if err := s.pregnancyRepo.Update(ctx, tracker); err != nil {
+		return nil, err
+	}
+	if err := s.pregnancyRepo.CompleteActiveSessions(ctx, tracker.UserID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return tracker, nil
+}
+
+// AddEntry logs a recovery, feeding, bleeding or sleep entry
+func (s *PostpartumService) AddEntry(ctx context.Context, entry *entities.PostpartumEntry) error {
+	switch entry.Type {
+	case "recovery", "feeding", "bleeding", "sleep":
+	default:
+		return fmt.Errorf("invalid entry type: %s", entry.Type)
+	}
+	if entry.PainLevel != nil && (*entry.PainLevel < 0 || *entry.PainLevel > 10) {
+		return errors.New("pain level must be between 0 and 10")
+	}
+
+	tracker, err := s.findPostpartumTracker(ctx, entry.UserID.Hex())
+	if err != nil {
+		return err
+	}
+	entry.TrackerID = tracker.ID
+
+	return s.postpartumRepo.Create(ctx, entry)
+}
+
+func (s *PostpartumService) GetEntries(ctx context.Context, userID, entryType string) ([]*entities.PostpartumEntry, error) {
+	return s.postpartumRepo.FindByUserID(ctx, userID, entryType, 50) // Last 50 entries
+}
+
+// GetSummary returns feeding, sleep and bleeding statistics, the latest EPDS
+// screening and any warning signs logged in the last 7 days
+func (s *PostpartumService) GetSummary(ctx context.Context, userID string) (*entities.PostpartumSummary, error) {
+	tracker, err := s.findPostpartumTracker(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	daysPostpartum := int(now.Sub(*tracker.DeliveryDate).Hours() / 24)
+
+	entries, err := s.postpartumRepo.FindSince(ctx, userID, now.AddDate(0, 0, -7))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &entities.PostpartumSummary{
+		Tracker:        tracker,
+		DaysPostpartum: daysPostpartum,
+		Warnings:       []string{},
+	}
+
+	var sleepTotal float64
+	sleepDays := map[string]bool{}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		switch entry.Type {
+		case "feeding":
+			if now.Sub(entry.Date) <= 24*time.Hour {
+				summary.FeedsLast24Hours++
+			}
+		case "sleep":
+			sleepTotal += entry.SleepHours
+			sleepDays[entry.Date.Format("2006-01-02")] = true
+		case "bleeding":
+			// Entries are sorted newest first
+			if summary.LatestBleeding == nil {
+				summary.LatestBleeding = entry
+			}
+		case "recovery":
+			for _, symptom := range entry.Symptoms {
+				if warning, ok := urgentPostpartumSymptoms[symptom]; ok && !seen[symptom] {
+					seen[symptom] = true
+					summary.Warnings = append(summary.Warnings, warning+" is an urgent warning sign. Seek medical care now.")
+				}
+			}
+		}
+	}
+	if len(sleepDays) > 0 {
+		summary.AverageSleepHours = sleepTotal / float64(len(sleepDays))
+	}
+
+	if bleeding := summary.LatestBleeding; bleeding != nil {
+		if bleeding.LochiaFlow == "heavy" && bleeding.Clots {
+			summary.Warnings = append(summary.Warnings, "Heavy bleeding with clots can be a sign of postpartum haemorrhage. Seek medical care now.")
+		} else if bleeding.LochiaFlow == "heavy" && daysPostpartum > 14 {
+			summary.Warnings = append(summary.Warnings, "Heavy bleeding more than 2 weeks after birth should be checked by your doctor.")
+		}
+		if bleeding.LochiaColor == "red" && daysPostpartum > 14 {
+			summary.Warnings = append(summary.Warnings, "Bright red bleeding more than 2 weeks after birth should be checked by your doctor.")
+		}
+	}
+
+	results, err := s.mentalHealthRepo.FindResultsByUserID(ctx, userID, "epds")
+	if err != nil {
+		return nil, err
+	}
+	if len(results) > 0 {
+		summary.LatestEPDS = results[0]
+		if results[0].Critical {
+			summary.Warnings = append(summary.Warnings, results[0].CriticalNote)
+		}
+	} else if daysPostpartum >= 14 {
+		summary.Warnings = append(summary.Warnings, "Take the EPDS screening to check in on your emotional wellbeing.")
+	}
+
+	return summary, nil
+}
+
+func (s *PostpartumService) findPostpartumTracker(ctx context.Context, userID string) (*entities.PregnancyTracker, error) {
+	tracker, err := s.pregnancyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tracker == nil || tracker.Status != "postpartum" || tracker.DeliveryDate == nil {
+		return nil, errors.New("postpartum mode is not active, record the delivery first")
+	}
+	return tracker, nil
+}
@@ -2,12 +2,36 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/anshjamwal15/hsb_backend/internal/domain"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	SessionTypeContraction = "contraction"
+	SessionTypeKick        = "kick"
+
+	// 5-1-1 rule: contractions 5 minutes apart, lasting 1 minute, for 1 hour
+	contractionMaxInterval   = 5 * time.Minute
+	contractionMinDuration   = time.Minute
+	contractionPatternWindow = time.Hour
+	prolongedContraction     = 2 * time.Minute
+
+	// Count-the-kicks: 10 movements within 2 hours
+	kickGoal   = 10
+	kickWindow = 2 * time.Hour
+
+	// How far ahead of the server a device clock may run when it times an event
+	maxEventClockSkew = time.Minute
+)
+
+var ErrSessionChanged = errors.New("the session was changed on another device, reload it and try again")
+
 type PregnancyService struct {
 	pregnancyRepo repositories.PregnancyRepository
 }
@@ -37,11 +61,296 @@ func (s *PregnancyService) AddPregnancyEntry(ctx context.Context, tracker *entit
 		return err
 	}
 
+	// Delivery details are only changed through StartPostpartum
+	tracker.Status = "active"
+	tracker.DeliveryDate = nil
+	tracker.DeliveryType = ""
+
 	if existing != nil {
 		tracker.ID = existing.ID
 		tracker.CreatedAt = existing.CreatedAt
+		// A last period after the delivery date starts a new pregnancy
+		if existing.DeliveryDate != nil && !tracker.LastPeriodDate.After(*existing.DeliveryDate) {
+			tracker.Status = existing.Status
+			tracker.DeliveryDate = existing.DeliveryDate
+			tracker.DeliveryType = existing.DeliveryType
+		}
 		return s.pregnancyRepo.Update(ctx, tracker)
 	}
 
 	return s.pregnancyRepo.Create(ctx, tracker)
 }
+
+// StartSession starts a contraction or kick session on the user's pregnancy tracker.
+// If a session of the same type is already active it is returned instead, so every
+// device signed in to the account shares the same session.
+func (s *PregnancyService) StartSession(ctx context.Context, userID, sessionType string) (*entities.PregnancySession, error) {
+	if sessionType != SessionTypeContraction && sessionType != SessionTypeKick {
+		return nil, fmt.Errorf("invalid session type: %s", sessionType)
+	}
+
+	tracker, err := s.pregnancyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tracker == nil {
+		return nil, errors.New("pregnancy tracker not found, add pregnancy details first")
+	}
+	if tracker.Status == "postpartum" {
+		return nil, errors.New("pregnancy has ended, sessions are no longer available")
+	}
+
+	active, err := s.pregnancyRepo.FindActiveSession(ctx, userID, sessionType)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return active, nil
+	}
+
+	session := &entities.PregnancySession{
+		UserID:    tracker.UserID,
+		TrackerID: tracker.ID,
+		Type:      sessionType,
+		Status:    "active",
+		StartedAt: time.Now(),
+		Events:    []entities.SessionEvent{},
+	}
+
+	// Another device may have started one since the check above
+	if err := s.pregnancyRepo.CreateSession(ctx, session); err != nil {
+		if errors.Is(err, domain.ErrDuplicateKey) {
+			return s.pregnancyRepo.FindActiveSession(ctx, userID, sessionType)
+		}
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// RecordSessionEvent records a kick or a contraction on an active session.
+// For contractions, a request carrying only endedAt closes the contraction in progress.
+func (s *PregnancyService) RecordSessionEvent(ctx context.Context, userID, sessionID string, startedAt, endedAt *time.Time) (*entities.PregnancySessionSummary, error) {
+	session, err := s.findUserSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != "active" {
+		return nil, errors.New("session is not active")
+	}
+
+	now := time.Now()
+	if (startedAt != nil && startedAt.After(now.Add(maxEventClockSkew))) || (endedAt != nil && endedAt.After(now.Add(maxEventClockSkew))) {
+		return nil, errors.New("event times cannot be in the future")
+	}
+
+	var open *entities.SessionEvent
+	if n := len(session.Events); n > 0 && session.Type == SessionTypeContraction && session.Events[n-1].EndedAt == nil {
+		open = &session.Events[n-1]
+	}
+
+	var updated *entities.PregnancySession
+	switch {
+	case session.Type == SessionTypeContraction && startedAt == nil && endedAt != nil:
+		if open == nil {
+			return nil, errors.New("no contraction in progress")
+		}
+		if endedAt.Before(open.StartedAt) {
+			return nil, errors.New("contraction cannot end before it started")
+		}
+		updated, err = s.pregnancyRepo.EndContraction(ctx, session.ID, *endedAt)
+	default:
+		if open != nil {
+			return nil, errors.New("previous contraction is still in progress")
+		}
+		event := entities.SessionEvent{StartedAt: now}
+		if startedAt != nil {
+			event.StartedAt = *startedAt
+		}
+		if event.StartedAt.Before(session.StartedAt) {
+			return nil, errors.New("event cannot start before the session")
+		}
+		if session.Type == SessionTypeContraction && endedAt != nil {
+			if endedAt.Before(event.StartedAt) {
+				return nil, errors.New("contraction cannot end before it started")
+			}
+			event.EndedAt = endedAt
+		}
+		updated, err = s.pregnancyRepo.AddSessionEvent(ctx, session, event)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Stopped, or a contraction started or ended, since the session was read
+	if updated == nil {
+		return nil, ErrSessionChanged
+	}
+
+	return summarizeSession(updated, now), nil
+}
+
+// StopSession completes an active session
+func (s *PregnancyService) StopSession(ctx context.Context, userID, sessionID string) (*entities.PregnancySessionSummary, error) {
+	session, err := s.findUserSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.Status == "active" {
+		completed, err := s.pregnancyRepo.CompleteSession(ctx, session.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		// Another device stopped it first
+		if completed == nil {
+			if completed, err = s.findUserSession(ctx, userID, sessionID); err != nil {
+				return nil, err
+			}
+		}
+		session = completed
+	}
+
+	return summarizeSession(session, now), nil
+}
+
+// GetSession returns a session together with its analysis
+func (s *PregnancyService) GetSession(ctx context.Context, userID, sessionID string) (*entities.PregnancySessionSummary, error) {
+	session, err := s.findUserSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeSession(session, time.Now()), nil
+}
+
+// GetSessions returns the user's most recent sessions, optionally filtered by type
+func (s *PregnancyService) GetSessions(ctx context.Context, userID, sessionType string) ([]*entities.PregnancySessionSummary, error) {
+	sessions, err := s.pregnancyRepo.FindSessionsByUserID(ctx, userID, sessionType, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	summaries := make([]*entities.PregnancySessionSummary, 0, len(sessions))
+	for _, session := range sessions {
+		summaries = append(summaries, summarizeSession(session, now))
+	}
+
+	return summaries, nil
+}
+
+func (s *PregnancyService) findUserSession(ctx context.Context, userID, sessionID string) (*entities.PregnancySession, error) {
+	session, err := s.pregnancyRepo.FindSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.UserID.Hex() != userID {
+		return nil, errors.New("session not found")
+	}
+	return session, nil
+}
+
+func summarizeSession(session *entities.PregnancySession, now time.Time) *entities.PregnancySessionSummary {
+	summary := &entities.PregnancySessionSummary{Session: session}
+	switch session.Type {
+	case SessionTypeContraction:
+		summary.Contraction = analyzeContractions(session.Events)
+	case SessionTypeKick:
+		summary.Kick = analyzeKicks(session, now)
+	}
+	return summary
+}
+
+// analyzeContractions computes duration and frequency (start-to-start) statistics
+// and checks for the 5-1-1 pattern over the most recent contractions.
+func analyzeContractions(events []entities.SessionEvent) *entities.ContractionAnalysis {
+	analysis := &entities.ContractionAnalysis{
+		Count:    len(events),
+		Warnings: []string{},
+	}
+
+	var totalDuration, totalInterval time.Duration
+	var durations, intervals int
+	prolonged := false
+	for i, event := range events {
+		if event.EndedAt != nil {
+			duration := event.EndedAt.Sub(event.StartedAt)
+			totalDuration += duration
+			durations++
+			analysis.LastDurationSeconds = duration.Seconds()
+			if duration > prolongedContraction {
+				prolonged = true
+			}
+		}
+		if i > 0 {
+			interval := event.StartedAt.Sub(events[i-1].StartedAt)
+			totalInterval += interval
+			intervals++
+			analysis.LastIntervalMinutes = interval.Minutes()
+		}
+	}
+	if durations > 0 {
+		analysis.AverageDurationSeconds = (totalDuration / time.Duration(durations)).Seconds()
+	}
+	if intervals > 0 {
+		analysis.AverageIntervalMinutes = (totalInterval / time.Duration(intervals)).Minutes()
+	}
+
+	// Walk back from the latest completed contraction while the 5-1-1 conditions hold
+	last := len(events) - 1
+	if last >= 0 && events[last].EndedAt == nil {
+		last--
+	}
+	if last >= 0 && events[last].EndedAt != nil && events[last].EndedAt.Sub(events[last].StartedAt) >= contractionMinDuration {
+		first := last
+		for first > 0 {
+			prev := events[first-1]
+			if prev.EndedAt == nil ||
+				prev.EndedAt.Sub(prev.StartedAt) < contractionMinDuration ||
+				events[first].StartedAt.Sub(prev.StartedAt) > contractionMaxInterval {
+				break
+			}
+			first--
+		}
+		analysis.Pattern511 = events[last].EndedAt.Sub(events[first].StartedAt) >= contractionPatternWindow
+	}
+
+	if analysis.Pattern511 {
+		analysis.Warnings = append(analysis.Warnings, "Contractions have been 5 minutes apart or less, lasting 1 minute or more, for at least 1 hour. Contact your healthcare provider or go to the hospital.")
+	}
+	if prolonged {
+		analysis.Warnings = append(analysis.Warnings, "A contraction lasted longer than 2 minutes. Contact your healthcare provider.")
+	}
+
+	return analysis
+}
+
+// analyzeKicks computes the time taken to feel 10 movements
+func analyzeKicks(session *entities.PregnancySession, now time.Time) *entities.KickAnalysis {
+	end := now
+	if session.EndedAt != nil {
+		end = *session.EndedAt
+	}
+	elapsed := end.Sub(session.StartedAt)
+
+	analysis := &entities.KickAnalysis{
+		Count:          len(session.Events),
+		ElapsedMinutes: elapsed.Minutes(),
+		Warnings:       []string{},
+	}
+
+	if len(session.Events) >= kickGoal {
+		toGoal := session.Events[kickGoal-1].StartedAt.Sub(session.StartedAt)
+		minutes := toGoal.Minutes()
+		analysis.MinutesToTenKicks = &minutes
+		analysis.GoalReached = true
+		if toGoal > kickWindow {
+			analysis.Warnings = append(analysis.Warnings, "It took longer than 2 hours to feel 10 movements. Contact your healthcare provider.")
+		}
+	} else if elapsed >= kickWindow {
+		analysis.Warnings = append(analysis.Warnings, "Fewer than 10 movements were felt in 2 hours. Contact your healthcare provider.")
+	}
+
+	return analysis
+}
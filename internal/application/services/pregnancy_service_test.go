@@ -0,0 +1,233 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+var sessionStart = time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+
+// contractionEvent is a completed contraction starting offset after sessionStart
+func contractionEvent(offset, duration time.Duration) entities.SessionEvent {
+	start := sessionStart.Add(offset)
+	end := start.Add(duration)
+	return entities.SessionEvent{StartedAt: start, EndedAt: &end}
+}
+
+// contractionsEvery is n completed contractions, the first at sessionStart
+func contractionsEvery(n int, interval, duration time.Duration) []entities.SessionEvent {
+	events := make([]entities.SessionEvent, 0, n)
+	for i := 0; i < n; i++ {
+		events = append(events, contractionEvent(time.Duration(i)*interval, duration))
+	}
+	return events
+}
+
+// kickSession is a session with kicks a minute apart, the tenth moved to tenthAt
+func kickSession(kicks int, tenthAt time.Duration) *entities.PregnancySession {
+	session := &entities.PregnancySession{Type: SessionTypeKick, StartedAt: sessionStart}
+	for i := 1; i <= kicks; i++ {
+		offset := time.Duration(i) * time.Minute
+		if i == kickGoal {
+			offset = tenthAt
+		} else if i > kickGoal {
+			offset = tenthAt + time.Duration(i-kickGoal)*time.Minute
+		}
+		session.Events = append(session.Events, entities.SessionEvent{StartedAt: sessionStart.Add(offset)})
+	}
+	return session
+}
+
+func TestAnalyzeContractions(t *testing.T) {
+	tests := []struct {
+		name       string
+		events     []entities.SessionEvent
+		count      int
+		pattern511 bool
+		warnings   int
+	}{
+		{
+			name:  "no contractions",
+			count: 0,
+		},
+		{
+			// 5 minutes apart and 1 minute long, ending exactly an hour after the first began
+			name:       "exactly 5-1-1 for one hour",
+			events:     append(contractionsEvery(12, 5*time.Minute, time.Minute), contractionEvent(59*time.Minute, time.Minute)),
+			count:      13,
+			pattern511: true,
+			warnings:   1,
+		},
+		{
+			name:   "one second short of an hour",
+			events: append(contractionsEvery(12, 5*time.Minute, time.Minute), contractionEvent(59*time.Minute-time.Second, time.Minute)),
+			count:  13,
+		},
+		{
+			name:   "interval just over 5 minutes",
+			events: contractionsEvery(14, 5*time.Minute+time.Second, time.Minute),
+			count:  14,
+		},
+		{
+			name:   "duration just under 1 minute",
+			events: contractionsEvery(14, 5*time.Minute, time.Minute-time.Second),
+			count:  14,
+		},
+		{
+			name:   "latest contraction too short",
+			events: append(contractionsEvery(13, 5*time.Minute, time.Minute), contractionEvent(65*time.Minute, time.Minute-time.Second)),
+			count:  14,
+		},
+		{
+			// The contraction still in progress is skipped when looking for the pattern
+			name:       "open contraction after the pattern",
+			events:     append(contractionsEvery(13, 5*time.Minute, time.Minute), entities.SessionEvent{StartedAt: sessionStart.Add(65 * time.Minute)}),
+			count:      14,
+			pattern511: true,
+			warnings:   1,
+		},
+		{
+			name:   "contraction of exactly 2 minutes",
+			events: []entities.SessionEvent{contractionEvent(0, 2*time.Minute)},
+			count:  1,
+		},
+		{
+			name:     "contraction just over 2 minutes",
+			events:   []entities.SessionEvent{contractionEvent(0, 2*time.Minute+time.Second)},
+			count:    1,
+			warnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := analyzeContractions(tt.events)
+			if analysis.Count != tt.count {
+				t.Errorf("count = %d, want %d", analysis.Count, tt.count)
+			}
+			if analysis.Pattern511 != tt.pattern511 {
+				t.Errorf("pattern511 = %v, want %v", analysis.Pattern511, tt.pattern511)
+			}
+			if len(analysis.Warnings) != tt.warnings {
+				t.Errorf("warnings = %q, want %d", analysis.Warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestAnalyzeContractionsSkipsOpenDuration(t *testing.T) {
+	events := []entities.SessionEvent{
+		contractionEvent(0, time.Minute),
+		contractionEvent(5*time.Minute, 2*time.Minute),
+		{StartedAt: sessionStart.Add(9 * time.Minute)},
+	}
+
+	analysis := analyzeContractions(events)
+	if analysis.AverageDurationSeconds != 90 || analysis.LastDurationSeconds != 120 {
+		t.Errorf("durations = %v average, %v last, want 90 and 120", analysis.AverageDurationSeconds, analysis.LastDurationSeconds)
+	}
+	// Frequency is start-to-start, so the open contraction still counts
+	if analysis.AverageIntervalMinutes != 4.5 || analysis.LastIntervalMinutes != 4 {
+		t.Errorf("intervals = %v average, %v last, want 4.5 and 4", analysis.AverageIntervalMinutes, analysis.LastIntervalMinutes)
+	}
+}
+
+func TestAnalyzeKicks(t *testing.T) {
+	stoppedAt := sessionStart.Add(30 * time.Minute)
+	stopped := kickSession(9, 0)
+	stopped.EndedAt = &stoppedAt
+
+	tests := []struct {
+		name        string
+		session     *entities.PregnancySession
+		now         time.Time
+		count       int
+		elapsed     float64
+		toTenKicks  float64 // -1 when the goal was not reached
+		goalReached bool
+		warnings    int
+	}{
+		{
+			name:        "10th kick exactly at 2 hours",
+			session:     kickSession(10, 2*time.Hour),
+			now:         sessionStart.Add(3 * time.Hour),
+			count:       10,
+			elapsed:     180,
+			toTenKicks:  120,
+			goalReached: true,
+		},
+		{
+			name:        "10th kick just after 2 hours",
+			session:     kickSession(10, 2*time.Hour+time.Second),
+			now:         sessionStart.Add(3 * time.Hour),
+			count:       10,
+			elapsed:     180,
+			toTenKicks:  120 + 1.0/60,
+			goalReached: true,
+			warnings:    1,
+		},
+		{
+			name:        "kicks after the 10th",
+			session:     kickSession(12, 30*time.Minute),
+			now:         sessionStart.Add(time.Hour),
+			count:       12,
+			elapsed:     60,
+			toTenKicks:  30,
+			goalReached: true,
+		},
+		{
+			name:       "9 kicks just inside 2 hours",
+			session:    kickSession(9, 0),
+			now:        sessionStart.Add(2*time.Hour - time.Minute),
+			count:      9,
+			elapsed:    119,
+			toTenKicks: -1,
+		},
+		{
+			name:       "9 kicks at 2 hours",
+			session:    kickSession(9, 0),
+			now:        sessionStart.Add(2 * time.Hour),
+			count:      9,
+			elapsed:    120,
+			toTenKicks: -1,
+			warnings:   1,
+		},
+		{
+			name:       "stopped session is timed to its end",
+			session:    stopped,
+			now:        sessionStart.Add(5 * time.Hour),
+			count:      9,
+			elapsed:    30,
+			toTenKicks: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := analyzeKicks(tt.session, tt.now)
+			if analysis.Count != tt.count {
+				t.Errorf("count = %d, want %d", analysis.Count, tt.count)
+			}
+			if analysis.ElapsedMinutes != tt.elapsed {
+				t.Errorf("elapsed = %v, want %v", analysis.ElapsedMinutes, tt.elapsed)
+			}
+			if analysis.GoalReached != tt.goalReached {
+				t.Errorf("goalReached = %v, want %v", analysis.GoalReached, tt.goalReached)
+			}
+			switch {
+			case tt.toTenKicks < 0 && analysis.MinutesToTenKicks != nil:
+				t.Errorf("minutesToTenKicks = %v, want none", *analysis.MinutesToTenKicks)
+			case tt.toTenKicks >= 0 && analysis.MinutesToTenKicks == nil:
+				t.Errorf("minutesToTenKicks missing, want %v", tt.toTenKicks)
+			case tt.toTenKicks >= 0 && math.Abs(*analysis.MinutesToTenKicks-tt.toTenKicks) > 1e-9:
+				t.Errorf("minutesToTenKicks = %v, want %v", *analysis.MinutesToTenKicks, tt.toTenKicks)
+			}
+			if len(analysis.Warnings) != tt.warnings {
+				t.Errorf("warnings = %q, want %d", analysis.Warnings, tt.warnings)
+			}
+		})
+	}
+}
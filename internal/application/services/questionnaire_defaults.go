@@ -0,0 +1,333 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+// defaultQuestionnaires returns the built-in questionnaire definitions that are seeded
+// into the database. To change a questionnaire, edit it here and bump its Version:
+// the new version is stored alongside the old one so earlier results stay comparable.
+func defaultQuestionnaires() []entities.MentalHealthTest {
+	return []entities.MentalHealthTest{
+		phq9Test(),
+		gad7Test(),
+		epdsTest(),
+		mrsTest(),
+		fsfiTest(),
+		pcosTest(),
+	}
+}
+
+// frequencyOptions are the PHQ and GAD response options for the last two weeks
+func frequencyOptions() []entities.QuestionOption {
+	return []entities.QuestionOption{
+		{Label: "Not at all", Value: 0},
+		{Label: "Several days", Value: 1},
+		{Label: "More than half the days", Value: 2},
+		{Label: "Nearly every day", Value: 3},
+	}
+}
+
+// phq9Test returns the Patient Health Questionnaire. Item 9 asks about thoughts of
+// self-harm and is a critical item.
+func phq9Test() entities.MentalHealthTest {
+	items := []string{
+		"Little interest or pleasure in doing things",
+		"Feeling down, depressed, or hopeless",
+		"Trouble falling or staying asleep, or sleeping too much",
+		"Feeling tired or having little energy",
+		"Poor appetite or overeating",
+		"Feeling bad about yourself, or that you are a failure or have let yourself or your family down",
+		"Trouble concentrating on things, such as reading the newspaper or watching television",
+		"Moving or speaking so slowly that other people could have noticed, or the opposite: being so fidgety or restless that you have been moving around a lot more than usual",
+		"Thoughts that you would be better off dead, or of hurting yourself in some way",
+	}
+
+	return entities.MentalHealthTest{
+		TestName:    "phq9",
+		Name:        "phq9",
+		Version:     1,
+		Category:    "mental_health",
+		DisplayName: "PHQ-9 Depression Test",
+		Description: "Over the last 2 weeks, how often have you been bothered by any of the following problems?",
+		Questions:   frequencyQuestions(items),
+		Thresholds: []entities.TestThreshold{
+			{Min: 0, Max: 4, Severity: "minimal", AlertLevel: "none",
+				Recommendation: "Minimal depressive symptoms. No treatment is needed; repeat the screening if your mood changes."},
+			{Min: 5, Max: 9, Severity: "mild", AlertLevel: "low",
+				Recommendation: "Mild depressive symptoms. Keep an eye on your mood and repeat the screening in 2 to 4 weeks."},
+			{Min: 10, Max: 14, Severity: "moderate", AlertLevel: "moderate",
+				Recommendation: "Moderate depressive symptoms. Please talk to a doctor or counsellor about a treatment plan."},
+			{Min: 15, Max: 19, Severity: "moderately severe", AlertLevel: "high",
+				Recommendation: "Moderately severe depressive symptoms. Please book a consultation soon to discuss treatment."},
+			{Min: 20, Max: 27, Severity: "severe", AlertLevel: "high",
+				Recommendation: "Severe depressive symptoms. Please see a doctor or mental health professional as soon as possible."},
+		},
+		CriticalItems: []interface{}{"q9"},
+		CriticalNote:  "You told us you have had thoughts of being better off dead or of hurting yourself. Please talk to your doctor today, or call the Tele-MANAS helpline at 14416 (24x7, free).",
+	}
+}
+
+// gad7Test returns the Generalized Anxiety Disorder 7-item scale
+func gad7Test() entities.MentalHealthTest {
+	items := []string{
+		"Feeling nervous, anxious, or on edge",
+		"Not being able to stop or control worrying",
+		"Worrying too much about different things",
+		"Trouble relaxing",
+		"Being so restless that it is hard to sit still",
+		"Becoming easily annoyed or irritable",
+		"Feeling afraid, as if something awful might happen",
+	}
+
+	return entities.MentalHealthTest{
+		TestName:    "gad7",
+		Name:        "gad7",
+		Version:     1,
+		Category:    "mental_health",
+		DisplayName: "GAD-7 Anxiety Test",
+		Description: "Over the last 2 weeks, how often have you been bothered by the following problems?",
+		Questions:   frequencyQuestions(items),
+		Thresholds: []entities.TestThreshold{
+			{Min: 0, Max: 4, Severity: "minimal", AlertLevel: "none",
+				Recommendation: "Minimal anxiety. Repeat the screening if you start to feel anxious."},
+			{Min: 5, Max: 9, Severity: "mild", AlertLevel: "low",
+				Recommendation: "Mild anxiety. Relaxation techniques and regular exercise can help; repeat the screening in 2 to 4 weeks."},
+			{Min: 10, Max: 14, Severity: "moderate", AlertLevel: "moderate",
+				Recommendation: "Moderate anxiety. Please talk to a doctor or counsellor for further evaluation."},
+			{Min: 15, Max: 21, Severity: "severe", AlertLevel: "high",
+				Recommendation: "Severe anxiety. Please see a doctor or mental health professional as soon as possible."},
+		},
+	}
+}
+
+func frequencyQuestions(items []string) []entities.TestQuestion {
+	questions := make([]entities.TestQuestion, len(items))
+	for i, text := range items {
+		questions[i] = entities.TestQuestion{
+			Key:          fmt.Sprintf("q%d", i+1),
+			QuestionText: text,
+			ResponseType: "scale",
+			Options:      frequencyOptions(),
+		}
+	}
+	return questions
+}
+
+// epdsTest returns the Edinburgh Postnatal Depression Scale. Items 3 and 5-10 are
+// reverse scored, which is encoded in the option values. Item 10 asks about self-harm
+// and is a critical item: any answer above 0 needs follow-up regardless of the total.
+func epdsTest() entities.MentalHealthTest {
+	options := func(labels ...string) []entities.QuestionOption {
+		opts := make([]entities.QuestionOption, len(labels))
+		for i, label := range labels {
+			opts[i] = entities.QuestionOption{Label: label, Value: i}
+		}
+		return opts
+	}
+	reversed := func(labels ...string) []entities.QuestionOption {
+		opts := make([]entities.QuestionOption, len(labels))
+		for i, label := range labels {
+			opts[i] = entities.QuestionOption{Label: label, Value: len(labels) - 1 - i}
+		}
+		return opts
+	}
+
+	return entities.MentalHealthTest{
+		TestName:    "epds",
+		Name:        "epds",
+		Version:     1,
+		Category:    "mental_health",
+		DisplayName: "Edinburgh Postnatal Depression Scale (EPDS)",
+		Description: "Postnatal depression screening. Answer for how you have felt in the past 7 days.",
+		Questions: []entities.TestQuestion{
+			{Key: "q1", QuestionText: "I have been able to laugh and see the funny side of things", ResponseType: "mcq",
+				Options: options("As much as I always could", "Not quite so much now", "Definitely not so much now", "Not at all")},
+			{Key: "q2", QuestionText: "I have looked forward with enjoyment to things", ResponseType: "mcq",
+				Options: options("As much as I ever did", "Rather less than I used to", "Definitely less than I used to", "Hardly at all")},
+			{Key: "q3", QuestionText: "I have blamed myself unnecessarily when things went wrong", ResponseType: "mcq",
+				Options: reversed("Yes, most of the time", "Yes, some of the time", "Not very often", "No, never")},
+			{Key: "q4", QuestionText: "I have been anxious or worried for no good reason", ResponseType: "mcq",
+				Options: options("No, not at all", "Hardly ever", "Yes, sometimes", "Yes, very often")},
+			{Key: "q5", QuestionText: "I have felt scared or panicky for no very good reason", ResponseType: "mcq",
+				Options: reversed("Yes, quite a lot", "Yes, sometimes", "No, not much", "No, not at all")},
+			{Key: "q6", QuestionText: "Things have been getting on top of me", ResponseType: "mcq",
+				Options: reversed("Yes, most of the time I haven't been able to cope at all", "Yes, sometimes I haven't been coping as well as usual", "No, most of the time I have coped quite well", "No, I have been coping as well as ever")},
+			{Key: "q7", QuestionText: "I have been so unhappy that I have had difficulty sleeping", ResponseType: "mcq",
+				Options: reversed("Yes, most of the time", "Yes, sometimes", "Not very often", "No, not at all")},
+			{Key: "q8", QuestionText: "I have felt sad or miserable", ResponseType: "mcq",
+				Options: reversed("Yes, most of the time", "Yes, quite often", "Not very often", "No, not at all")},
+			{Key: "q9", QuestionText: "I have been so unhappy that I have been crying", ResponseType: "mcq",
+				Options: reversed("Yes, most of the time", "Yes, quite often", "Only occasionally", "No, never")},
+			{Key: "q10", QuestionText: "The thought of harming myself has occurred to me", ResponseType: "mcq",
+				Options: reversed("Yes, quite often", "Sometimes", "Hardly ever", "Never")},
+		},
+		Thresholds: []entities.TestThreshold{
+			{Min: 0, Max: 9, Severity: "minimal", AlertLevel: "none",
+				Recommendation: "Depression is unlikely. Repeat the screening if you start to feel low."},
+			{Min: 10, Max: 12, Severity: "possible depression", AlertLevel: "moderate",
+				Recommendation: "Possible depression. Repeat the screening in 2 to 4 weeks and talk to your doctor or midwife."},
+			{Min: 13, Max: 30, Severity: "probable depression", AlertLevel: "high",
+				Recommendation: "Probable depression. Please book a consultation with your doctor for a full assessment."},
+		},
+		CriticalItems: []interface{}{"q10"},
+		CriticalNote:  "You told us that the thought of harming yourself has occurred to you. Please talk to your doctor today, or call the Tele-MANAS helpline at 14416 (24x7, free).",
+	}
+}
+
+// mrsTest returns the Menopause Rating Scale. Each of the 11 complaints is rated from
+// none (0) to very severe (4); the total and the somatic, psychological and urogenital
+// sub-scores are reported.
+func mrsTest() entities.MentalHealthTest {
+	severity := []entities.QuestionOption{
+		{Label: "None", Value: 0},
+		{Label: "Mild", Value: 1},
+		{Label: "Moderate", Value: 2},
+		{Label: "Severe", Value: 3},
+		{Label: "Very severe", Value: 4},
+	}
+
+	return entities.MentalHealthTest{
+		TestName:    "mrs",
+		Name:        "mrs",
+		Version:     1,
+		Category:    "menopause",
+		DisplayName: "Menopause Rating Scale (MRS)",
+		Description: "Rate how much each complaint is bothering you at the moment.",
+		Questions: []entities.TestQuestion{
+			{Key: "q1", QuestionText: "Hot flushes, sweating (episodes of sweating)", ResponseType: "mcq", Options: severity},
+			{Key: "q2", QuestionText: "Heart discomfort (unusual awareness of heart beat, heart skipping, heart racing, tightness)", ResponseType: "mcq", Options: severity},
+			{Key: "q3", QuestionText: "Sleep problems (difficulty in falling asleep, difficulty in sleeping through, waking up early)", ResponseType: "mcq", Options: severity},
+			{Key: "q4", QuestionText: "Depressive mood (feeling down, sad, on the verge of tears, lack of drive, mood swings)", ResponseType: "mcq", Options: severity},
+			{Key: "q5", QuestionText: "Irritability (feeling nervous, inner tension, feeling aggressive)", ResponseType: "mcq", Options: severity},
+			{Key: "q6", QuestionText: "Anxiety (inner restlessness, feeling panicky)", ResponseType: "mcq", Options: severity},
+			{Key: "q7", QuestionText: "Physical and mental exhaustion (general decrease in performance, impaired memory, decrease in concentration, forgetfulness)", ResponseType: "mcq", Options: severity},
+			{Key: "q8", QuestionText: "Sexual problems (change in sexual desire, in sexual activity and satisfaction)", ResponseType: "mcq", Options: severity},
+			{Key: "q9", QuestionText: "Bladder problems (difficulty in urinating, increased need to urinate, bladder incontinence)", ResponseType: "mcq", Options: severity},
+			{Key: "q10", QuestionText: "Dryness of vagina (sensation of dryness or burning in the vagina, difficulty with sexual intercourse)", ResponseType: "mcq", Options: severity},
+			{Key: "q11", QuestionText: "Joint and muscular discomfort (pain in the joints, rheumatoid complaints)", ResponseType: "mcq", Options: severity},
+		},
+		Subscales: []entities.TestSubscale{
+			{Name: "somatic", Keys: []string{"q1", "q2", "q3", "q11"}},
+			{Name: "psychological", Keys: []string{"q4", "q5", "q6", "q7"}},
+			{Name: "urogenital", Keys: []string{"q8", "q9", "q10"}},
+		},
+		Thresholds: []entities.TestThreshold{
+			{Min: 0, Max: 4, Severity: "none or little", AlertLevel: "none",
+				Recommendation: "Your menopausal symptoms are mild. Keep tracking them so changes are easy to spot."},
+			{Min: 5, Max: 8, Severity: "mild", AlertLevel: "low",
+				Recommendation: "Mild symptoms. Regular exercise, sleep routines and avoiding triggers such as caffeine and spicy food can help."},
+			{Min: 9, Max: 16, Severity: "moderate", AlertLevel: "moderate",
+				Recommendation: "Moderate symptoms. Consider discussing treatment options with a gynaecologist."},
+			{Min: 17, Max: 44, Severity: "severe", AlertLevel: "high",
+				Recommendation: "Severe symptoms are affecting your quality of life. Please book a consultation with a gynaecologist to discuss treatment, including hormone therapy."},
+		},
+	}
+}
+
+// fsfiTest returns the 19-item Female Sexual Function Index (Rosen et al., 2000).
+// Each domain is a subscale whose sum is multiplied by its factor, so every domain
+// ranges up to 6 and the full scale ranges from 2 to 36. Option values follow the
+// published scoring, including the 0 "no sexual activity" responses.
+func fsfiTest() entities.MentalHealthTest {
+	options := func(labels ...string) []entities.QuestionOption {
+		opts := make([]entities.QuestionOption, len(labels))
+		for i, label := range labels {
+			opts[i] = entities.QuestionOption{Label: label, Value: len(labels) - i}
+		}
+		return opts
+	}
+	withNone := func(none string, opts []entities.QuestionOption) []entities.QuestionOption {
+		return append(opts, entities.QuestionOption{Label: none, Value: 0})
+	}
+
+	frequency := options("Almost always or always", "Most times (more than half the time)", "Sometimes (about half the time)", "A few times (less than half the time)", "Almost never or never")
+	level := options("Very high", "High", "Moderate", "Low", "Very low or none at all")
+	confidence := options("Very high confidence", "High confidence", "Moderate confidence", "Low confidence", "Very low or no confidence")
+	difficulty := options("Not difficult", "Slightly difficult", "Difficult", "Very difficult", "Extremely difficult or impossible")
+	satisfaction := options("Very satisfied", "Moderately satisfied", "About equally satisfied and dissatisfied", "Moderately dissatisfied", "Very dissatisfied")
+	painFrequency := options("Almost never or never", "A few times (less than half the time)", "Sometimes (about half the time)", "Most times (more than half the time)", "Almost always or always")
+	painLevel := options("Very low or none at all", "Low", "Moderate", "High", "Very high")
+
+	const noActivity = "No sexual activity"
+	const noIntercourse = "Did not attempt intercourse"
+
+	question := func(key, text string, opts []entities.QuestionOption) entities.TestQuestion {
+		return entities.TestQuestion{Key: key, QuestionText: text, ResponseType: "mcq", Options: opts}
+	}
+
+	return entities.MentalHealthTest{
+		TestName:    "fsfi",
+		Name:        "fsfi",
+		Version:     2,
+		Category:    "sexual_health",
+		DisplayName: "Female Sexual Function Index (FSFI)",
+		Description: "These questions ask about your sexual feelings and responses during the past 4 weeks.",
+		Questions: []entities.TestQuestion{
+			question("q1", "How often did you feel sexual desire or interest?", frequency),
+			question("q2", "How would you rate your level (degree) of sexual desire or interest?", level),
+			question("q3", "How often did you feel sexually aroused (\"turned on\") during sexual activity or intercourse?", withNone(noActivity, frequency)),
+			question("q4", "How would you rate your level of sexual arousal during sexual activity or intercourse?", withNone(noActivity, level)),
+			question("q5", "How confident were you about becoming sexually aroused during sexual activity or intercourse?", withNone(noActivity, confidence)),
+			question("q6", "How often have you been satisfied with your arousal (excitement) during sexual activity or intercourse?", withNone(noActivity, frequency)),
+			question("q7", "How often did you become lubricated (\"wet\") during sexual activity or intercourse?", withNone(noActivity, frequency)),
+			question("q8", "How difficult was it to become lubricated (\"wet\") during sexual activity or intercourse?", withNone(noActivity, difficulty)),
+			question("q9", "How often did you maintain your lubrication (\"wetness\") until completion of sexual activity or intercourse?", withNone(noActivity, frequency)),
+			question("q10", "How difficult was it to maintain your lubrication (\"wetness\") until completion of sexual activity or intercourse?", withNone(noActivity, difficulty)),
+			question("q11", "When you had sexual stimulation or intercourse, how often did you reach orgasm (climax)?", withNone(noActivity, frequency)),
+			question("q12", "When you had sexual stimulation or intercourse, how difficult was it for you to reach orgasm (climax)?", withNone(noActivity, difficulty)),
+			question("q13", "How satisfied were you with your ability to reach orgasm (climax) during sexual activity or intercourse?", withNone(noActivity, satisfaction)),
+			question("q14", "How satisfied have you been with the amount of emotional closeness during sexual activity between you and your partner?", withNone(noActivity, satisfaction)),
+			question("q15", "How satisfied have you been with your sexual relationship with your partner?", satisfaction),
+			question("q16", "How satisfied have you been with your overall sexual life?", satisfaction),
+			question("q17", "How often did you experience discomfort or pain during vaginal penetration?", withNone(noIntercourse, painFrequency)),
+			question("q18", "How often did you experience discomfort or pain following vaginal penetration?", withNone(noIntercourse, painFrequency)),
+			question("q19", "How would you rate your level (degree) of discomfort or pain during or following vaginal penetration?", withNone(noIntercourse, painLevel)),
+		},
+		Subscales: []entities.TestSubscale{
+			{Name: "desire", Keys: []string{"q1", "q2"}, Factor: 0.6},
+			{Name: "arousal", Keys: []string{"q3", "q4", "q5", "q6"}, Factor: 0.3},
+			{Name: "lubrication", Keys: []string{"q7", "q8", "q9", "q10"}, Factor: 0.3},
+			{Name: "orgasm", Keys: []string{"q11", "q12", "q13"}, Factor: 0.4},
+			{Name: "satisfaction", Keys: []string{"q14", "q15", "q16"}, Factor: 0.4},
+			{Name: "pain", Keys: []string{"q17", "q18", "q19"}, Factor: 0.4},
+		},
+	}
+}
+
+// pcosTest returns the PCOS symptom screening questionnaire
+func pcosTest() entities.MentalHealthTest {
+	yesNo := []entities.QuestionOption{
+		{Label: "No", Value: 0},
+		{Label: "Yes", Value: 1},
+	}
+
+	return entities.MentalHealthTest{
+		TestName:    "pcos",
+		Name:        "pcos",
+		Version:     1,
+		Category:    "pcos",
+		DisplayName: "PCOS Symptom Screening",
+		Description: "Screening for symptoms associated with polycystic ovary syndrome",
+		Questions: []entities.TestQuestion{
+			{Key: "q1", QuestionText: "Do you have irregular periods?", ResponseType: "binary", Options: yesNo},
+			{Key: "q2", QuestionText: "Do you experience excessive hair growth?", ResponseType: "binary", Options: yesNo},
+			{Key: "q3", QuestionText: "Do you have acne or oily skin?", ResponseType: "binary", Options: yesNo},
+			{Key: "q4", QuestionText: "Have you experienced weight gain?", ResponseType: "binary", Options: yesNo},
+			{Key: "q5", QuestionText: "Do you have difficulty losing weight?", ResponseType: "binary", Options: yesNo},
+			{Key: "q6", QuestionText: "Do you experience hair thinning or hair loss?", ResponseType: "binary", Options: yesNo},
+			{Key: "q7", QuestionText: "Do you have darkening of skin in body folds?", ResponseType: "binary", Options: yesNo},
+			{Key: "q8", QuestionText: "Have you been diagnosed with insulin resistance?", ResponseType: "binary", Options: yesNo},
+		},
+		Thresholds: []entities.TestThreshold{
+			{Min: 0, Max: 2, Severity: "low risk", AlertLevel: "none",
+				Recommendation: "Few PCOS symptoms. Keep tracking your cycles."},
+			{Min: 3, Max: 5, Severity: "moderate risk", AlertLevel: "moderate",
+				Recommendation: "Some symptoms associated with PCOS. Consider discussing them with a gynaecologist."},
+			{Min: 6, Max: 8, Severity: "high risk", AlertLevel: "high",
+				Recommendation: "Many symptoms associated with PCOS. Please book a consultation with a gynaecologist for an assessment."},
+		},
+	}
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+)
+
+// Localize returns a copy of a questionnaire definition with its text in the
+// given locale. Text without a translation stays in English and is listed in
+// the copy's MissingTranslations.
+func (s *QuestionnaireService) Localize(ctx context.Context, test *entities.MentalHealthTest, locale string) (*entities.MentalHealthTest, error) {
+	translation, err := s.translation(ctx, test.Name, test.Version, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	return localizeTest(test, translation, locale), nil
+}
+
+func (s *QuestionnaireService) LocalizeAll(ctx context.Context, tests []*entities.MentalHealthTest, locale string) ([]*entities.MentalHealthTest, error) {
+	localized := make([]*entities.MentalHealthTest, len(tests))
+	for i, test := range tests {
+		var err error
+		if localized[i], err = s.Localize(ctx, test, locale); err != nil {
+			return nil, err
+		}
+	}
+	return localized, nil
+}
+
+// LocalizeResults returns copies of results with the recommendation and critical
+// note in the given locale. The stored results keep their English text.
+func (s *QuestionnaireService) LocalizeResults(ctx context.Context, results []*entities.TestResult, locale string) ([]*entities.TestResult, error) {
+	translations := map[string]*entities.QuestionnaireTranslation{}
+
+	localized := make([]*entities.TestResult, len(results))
+	for i, result := range results {
+		key := fmt.Sprintf("%s@%d", result.TestName, result.TestVersion)
+		translation, ok := translations[key]
+		if !ok {
+			var err error
+			if translation, err = s.translation(ctx, result.TestName, result.TestVersion, locale); err != nil {
+				return nil, err
+			}
+			translations[key] = translation
+		}
+		localized[i] = localizeResult(result, translation)
+	}
+	return localized, nil
+}
+
+// MissingTranslations reports, for the latest version of every questionnaire
+// and for the API messages, what has no translation in locale
+func (s *QuestionnaireService) MissingTranslations(ctx context.Context, locale string) (*entities.TranslationReport, error) {
+	tests, err := s.questionnaireRepo.FindAllLatest(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.TranslationReport{
+		Locale:         locale,
+		Questionnaires: []entities.TranslationGap{},
+		Messages:       i18n.MissingMessages(locale),
+	}
+	for _, test := range tests {
+		localized, err := s.Localize(ctx, test, locale)
+		if err != nil {
+			return nil, err
+		}
+		if len(localized.MissingTranslations) == 0 {
+			continue
+		}
+		report.Questionnaires = append(report.Questionnaires, entities.TranslationGap{
+			Name:    test.Name,
+			Version: test.Version,
+			Missing: localized.MissingTranslations,
+		})
+	}
+	report.Complete = len(report.Questionnaires) == 0 && len(report.Messages) == 0
+
+	return report, nil
+}
+
+// seedTranslations stores the built-in translations, checking that each refers
+// to text that exists in its questionnaire version
+func (s *QuestionnaireService) seedTranslations(ctx context.Context) error {
+	for _, translation := range defaultTranslations() {
+		translation := translation
+		test, err := s.questionnaireRepo.FindByVersion(ctx, translation.Name, translation.Version)
+		if err != nil {
+			return err
+		}
+		if test == nil {
+			log.Printf("Skipping %s translation of %s v%d: questionnaire version not stored", translation.Locale, translation.Name, translation.Version)
+			continue
+		}
+		if err := validateTranslation(test, &translation); err != nil {
+			return fmt.Errorf("%s translation of %s: %w", translation.Locale, translation.Name, err)
+		}
+
+		if err := s.questionnaireRepo.UpsertTranslation(ctx, &translation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// translation loads the translation of a questionnaire version, or nil for the
+// default locale, whose text is the definition itself
+func (s *QuestionnaireService) translation(ctx context.Context, name string, version int, locale string) (*entities.QuestionnaireTranslation, error) {
+	if locale == "" || locale == i18n.DefaultLocale {
+		return nil, nil
+	}
+	return s.questionnaireRepo.FindTranslation(ctx, name, version, locale)
+}
+
+// validateTranslation rejects a translation that is for an unsupported locale or
+// translates questions, options, sections or severities the definition does not have
+func validateTranslation(test *entities.MentalHealthTest, translation *entities.QuestionnaireTranslation) error {
+	if locale, ok := i18n.Normalize(translation.Locale); !ok || locale != translation.Locale || locale == i18n.DefaultLocale {
+		return fmt.Errorf("unsupported locale %q", translation.Locale)
+	}
+
+	questions := map[string]bool{}
+	options := map[string]bool{}
+	for _, item := range questionItems(test) {
+		questions[item.Key] = true
+		for _, option := range item.Options {
+			options[option.Label] = true
+		}
+	}
+	sections := map[string]bool{}
+	for _, section := range test.Sections {
+		sections[section.SectionTitle] = true
+	}
+	severities := map[string]bool{}
+	for _, threshold := range test.Thresholds {
+		severities[threshold.Severity] = true
+	}
+
+	for key := range translation.Questions {
+		if !questions[key] {
+			return fmt.Errorf("unknown question %s", key)
+		}
+	}
+	for label := range translation.Options {
+		if !options[label] {
+			return fmt.Errorf("unknown option %q", label)
+		}
+	}
+	for title := range translation.Sections {
+		if !sections[title] {
+			return fmt.Errorf("unknown section %q", title)
+		}
+	}
+	for severity := range translation.Recommendations {
+		if !severities[severity] {
+			return fmt.Errorf("unknown severity %q", severity)
+		}
+	}
+	return nil
+}
+
+// localizeTest copies test with the translated text, keeping English where a
+// translation is missing. Keys are listed once, in the order they appear.
+func localizeTest(test *entities.MentalHealthTest, translation *entities.QuestionnaireTranslation, locale string) *entities.MentalHealthTest {
+	localized := *test
+	localized.Locale = locale
+	localized.MissingTranslations = nil
+	if locale == "" || locale == i18n.DefaultLocale {
+		localized.Locale = i18n.DefaultLocale
+		return &localized
+	}
+	if translation == nil {
+		translation = &entities.QuestionnaireTranslation{}
+	}
+
+	l := &localizer{translation: translation, seen: map[string]bool{}}
+	localized.DisplayName = l.text("displayName", test.DisplayName, translation.DisplayName)
+	localized.Description = l.text("description", test.Description, translation.Description)
+	localized.Questions = l.questions(test.Questions)
+
+	if test.Sections != nil {
+		localized.Sections = make([]entities.TestSection, len(test.Sections))
+		for i, section := range test.Sections {
+			sectionTranslation := translation.Sections[section.SectionTitle]
+			section.Description = l.text("section:"+section.SectionTitle+":description", section.Description, sectionTranslation.Description)
+			section.SectionTitle = l.text("section:"+section.SectionTitle, section.SectionTitle, sectionTranslation.Title)
+			section.Options = l.options(section.Options)
+			section.Questions = l.questions(section.Questions)
+			localized.Sections[i] = section
+		}
+	}
+
+	if test.Thresholds != nil {
+		localized.Thresholds = make([]entities.TestThreshold, len(test.Thresholds))
+		for i, threshold := range test.Thresholds {
+			threshold.Recommendation = l.text("recommendation:"+threshold.Severity, threshold.Recommendation, translation.Recommendations[threshold.Severity])
+			localized.Thresholds[i] = threshold
+		}
+	}
+	localized.CriticalNote = l.text("criticalNote", test.CriticalNote, translation.CriticalNote)
+
+	localized.MissingTranslations = l.missing
+	return &localized
+}
+
+// localizeResult copies a result with its recommendation and critical note translated
+func localizeResult(result *entities.TestResult, translation *entities.QuestionnaireTranslation) *entities.TestResult {
+	localized := *result
+	if translation == nil {
+		return &localized
+	}
+	if text := translation.Recommendations[result.Level]; text != "" && result.Recommendation != "" {
+		localized.Recommendation = text
+	}
+	if result.CriticalNote != "" && translation.CriticalNote != "" {
+		localized.CriticalNote = translation.CriticalNote
+	}
+	return &localized
+}
+
+type localizer struct {
+	translation *entities.QuestionnaireTranslation
+	seen        map[string]bool
+	missing     []string
+}
+
+// text returns the translated text, or the English text when there is no
+// translation, noting the key as missing. Empty English text needs none.
+func (l *localizer) text(key, english, translated string) string {
+	if english == "" {
+		return ""
+	}
+	if translated != "" {
+		return translated
+	}
+	if !l.seen[key] {
+		l.seen[key] = true
+		l.missing = append(l.missing, key)
+	}
+	return english
+}
+
+func (l *localizer) questions(questions []entities.TestQuestion) []entities.TestQuestion {
+	if questions == nil {
+		return nil
+	}
+	localized := make([]entities.TestQuestion, len(questions))
+	for i, question := range questions {
+		question.QuestionText = l.text("question:"+question.Key, question.QuestionText, l.translation.Questions[question.Key])
+		question.Options = l.options(question.Options)
+		localized[i] = question
+	}
+	return localized
+}
+
+func (l *localizer) options(options []entities.QuestionOption) []entities.QuestionOption {
+	if options == nil {
+		return nil
+	}
+	localized := make([]entities.QuestionOption, len(options))
+	for i, option := range options {
+		option.Label = l.text("option:"+option.Label, option.Label, l.translation.Options[option.Label])
+		localized[i] = option
+	}
+	return localized
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+)
+
+type QuestionnaireService struct {
+	questionnaireRepo repositories.QuestionnaireRepository
+}
+
+func NewQuestionnaireService(questionnaireRepo repositories.QuestionnaireRepository) *QuestionnaireService {
+	return &QuestionnaireService{
+		questionnaireRepo: questionnaireRepo,
+	}
+}
+
+// SeedDefinitions stores every built-in questionnaire whose version is newer than
+// the latest version in the database, then stores the built-in translations
+func (s *QuestionnaireService) SeedDefinitions(ctx context.Context) error {
+	for _, def := range defaultQuestionnaires() {
+		def := def
+		if err := validateQuestionnaire(&def); err != nil {
+			return fmt.Errorf("questionnaire %s: %w", def.Name, err)
+		}
+		if spec, ok := instrumentSpecs[def.Name]; ok {
+			if err := spec.check(&def); err != nil {
+				return fmt.Errorf("questionnaire %s: %w", def.Name, err)
+			}
+		}
+
+		latest, err := s.questionnaireRepo.FindLatest(ctx, def.Name)
+		if err != nil {
+			return err
+		}
+		if latest != nil && latest.Version >= def.Version {
+			continue
+		}
+
+		if err := s.questionnaireRepo.Create(ctx, &def); err != nil {
+			return err
+		}
+	}
+	return s.seedTranslations(ctx)
+}
+
+// GetDefinitions returns the latest version of every questionnaire in a category,
+// or of all questionnaires when category is empty
+func (s *QuestionnaireService) GetDefinitions(ctx context.Context, category string) ([]*entities.MentalHealthTest, error) {
+	return s.questionnaireRepo.FindAllLatest(ctx, category)
+}
+
+// GetDefinition returns a questionnaire at the given version, or the latest version when version is 0
+func (s *QuestionnaireService) GetDefinition(ctx context.Context, name string, version int) (*entities.MentalHealthTest, error) {
+	var (
+		test *entities.MentalHealthTest
+		err  error
+	)
+	if version > 0 {
+		test, err = s.questionnaireRepo.FindByVersion(ctx, name, version)
+	} else {
+		test, err = s.questionnaireRepo.FindLatest(ctx, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if test == nil {
+		return nil, fmt.Errorf("questionnaire not found: %s", name)
+	}
+
+	return test, nil
+}
+
+func (s *QuestionnaireService) GetVersions(ctx context.Context, name string) ([]*entities.MentalHealthTest, error) {
+	return s.questionnaireRepo.FindVersions(ctx, name)
+}
+
+// Score loads the definition the result was answered against (the latest one unless
+// TestVersion is set) and scores the result with it, using the instrument's own
+// rules for validated instruments such as PHQ-9 and GAD-7
+func (s *QuestionnaireService) Score(ctx context.Context, result *entities.TestResult) (*entities.MentalHealthTest, error) {
+	test, err := s.GetDefinition(ctx, result.TestName, result.TestVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := scoreInstrument(test, result); err != nil {
+		return nil, err
+	}
+
+	return test, nil
+}
+
+// questionItems flattens top-level and section questions. Section questions without
+// their own response type or options inherit the section's.
+func questionItems(test *entities.MentalHealthTest) []entities.TestQuestion {
+	items := append([]entities.TestQuestion{}, test.Questions...)
+	for _, section := range test.Sections {
+		for _, question := range section.Questions {
+			if question.ResponseType == "" {
+				question.ResponseType = section.ResponseType
+			}
+			if len(question.Options) == 0 {
+				question.Options = section.Options
+			}
+			items = append(items, question)
+		}
+	}
+	return items
+}
+
+// validateQuestionnaire checks that a definition can be scored: every question has a
+// unique key and a response type with options or a range
+func validateQuestionnaire(test *entities.MentalHealthTest) error {
+	if test.Name == "" {
+		return errors.New("name is required")
+	}
+	if test.Version < 1 {
+		return errors.New("version must be at least 1")
+	}
+
+	items := questionItems(test)
+	if len(items) == 0 {
+		return errors.New("at least one question is required")
+	}
+
+	keys := map[string]bool{}
+	for _, item := range items {
+		if item.Key == "" {
+			return fmt.Errorf("question %q has no key", item.QuestionText)
+		}
+		if keys[item.Key] {
+			return fmt.Errorf("duplicate question key %s", item.Key)
+		}
+		keys[item.Key] = true
+
+		switch item.ResponseType {
+		case "mcq", "scale", "binary":
+			if len(item.Options) == 0 {
+				return fmt.Errorf("question %s has no options", item.Key)
+			}
+		case "slider":
+			if item.ResponseRange == nil || item.ResponseRange.Min > item.ResponseRange.Max {
+				return fmt.Errorf("question %s has no valid response range", item.Key)
+			}
+		default:
+			return fmt.Errorf("question %s has unsupported response type %q", item.Key, item.ResponseType)
+		}
+	}
+
+	for _, subscale := range test.Subscales {
+		for _, key := range subscale.Keys {
+			if !keys[key] {
+				return fmt.Errorf("subscale %s references unknown question %s", subscale.Name, key)
+			}
+		}
+	}
+	for _, item := range test.CriticalItems {
+		if key, ok := item.(string); ok && !keys[key] {
+			return fmt.Errorf("critical item references unknown question %s", key)
+		}
+	}
+
+	return nil
+}
+
+// scoreTestResult validates every answer against its question's response type and
+// options, sums the score, and applies the definition's thresholds, subscales and
+// critical items. Answers are normalised to numbers in place.
+func scoreTestResult(test *entities.MentalHealthTest, result *entities.TestResult) error {
+	items := questionItems(test)
+
+	known := make(map[string]bool, len(items))
+	for _, item := range items {
+		known[item.Key] = true
+	}
+	for key := range result.Answers {
+		if !known[key] {
+			return fmt.Errorf("unknown question %s", key)
+		}
+	}
+
+	score, maxScore := 0, 0
+	for _, item := range items {
+		answer, ok := result.Answers[item.Key]
+		if !ok {
+			return fmt.Errorf("missing answer for %s", item.Key)
+		}
+
+		value, itemMax, err := answerValue(item, answer)
+		if err != nil {
+			return err
+		}
+
+		result.Answers[item.Key] = float64(value)
+		score += value
+		maxScore += itemMax
+	}
+
+	result.TestName = test.Name
+	result.TestVersion = test.Version
+	result.Score = score
+	result.ObtainedScore = score
+	result.TotalScore = maxScore
+
+	for _, threshold := range test.Thresholds {
+		if score >= threshold.Min && score <= threshold.Max {
+			result.Level = threshold.Severity
+			result.Recommendation = threshold.Recommendation
+			result.AlertLevel = threshold.AlertLevel
+			break
+		}
+	}
+
+	if len(test.Subscales) > 0 {
+		result.SubScores = make(map[string]int, len(test.Subscales))
+		for _, subscale := range test.Subscales {
+			for _, key := range subscale.Keys {
+				result.SubScores[subscale.Name] += int(result.Answers[key].(float64))
+			}
+		}
+	}
+
+	for _, item := range test.CriticalItems {
+		key, ok := item.(string)
+		if !ok {
+			continue
+		}
+		if answer, ok := result.Answers[key].(float64); ok && answer > 0 {
+			result.Critical = true
+			result.CriticalNote = test.CriticalNote
+		}
+	}
+
+	return nil
+}
+
+// answerValue converts an answer to its numeric value and returns it with the
+// highest value the question allows
+func answerValue(item entities.TestQuestion, answer interface{}) (int, int, error) {
+	var value float64
+	switch v := answer.(type) {
+	case float64:
+		value = v
+	case int:
+		value = float64(v)
+	case bool:
+		if item.ResponseType != "binary" {
+			return 0, 0, fmt.Errorf("invalid answer for %s", item.Key)
+		}
+		if v {
+			value = 1
+		}
+	case string:
+		if item.ResponseType != "binary" || (v != "yes" && v != "no") {
+			return 0, 0, fmt.Errorf("invalid answer for %s", item.Key)
+		}
+		if v == "yes" {
+			value = 1
+		}
+	default:
+		return 0, 0, fmt.Errorf("invalid answer for %s", item.Key)
+	}
+
+	if value != math.Trunc(value) {
+		return 0, 0, fmt.Errorf("answer for %s must be a whole number", item.Key)
+	}
+
+	if item.ResponseType == "slider" {
+		if value < float64(item.ResponseRange.Min) || value > float64(item.ResponseRange.Max) {
+			return 0, 0, fmt.Errorf("answer for %s must be between %d and %d", item.Key, item.ResponseRange.Min, item.ResponseRange.Max)
+		}
+		return int(value), item.ResponseRange.Max, nil
+	}
+
+	valid, itemMax := false, 0
+	for i, option := range item.Options {
+		if float64(option.Value) == value {
+			valid = true
+		}
+		if i == 0 || option.Value > itemMax {
+			itemMax = option.Value
+		}
+	}
+	if !valid {
+		return 0, 0, fmt.Errorf("invalid answer for %s", item.Key)
+	}
+
+	return int(value), itemMax, nil
+}
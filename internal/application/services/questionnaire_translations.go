@@ -0,0 +1,164 @@
+package services
+
+import (
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+// defaultTranslations returns the built-in questionnaire translations that are
+// stored on startup, replacing earlier text for the same version and locale. A
+// new questionnaire version needs its own translations. Translations of
+// validated instruments must be reviewed against a validated translation before
+// they are added; until then the missing-translations report lists them.
+func defaultTranslations() []entities.QuestionnaireTranslation {
+	return []entities.QuestionnaireTranslation{
+		phq9Hindi(),
+		gad7Hindi(),
+		pcosHindi(),
+		pcosTamil(),
+		pcosBengali(),
+	}
+}
+
+// frequencyOptionsHindi translates the PHQ and GAD response options
+func frequencyOptionsHindi() map[string]string {
+	return map[string]string{
+		"Not at all":              "बिल्कुल नहीं",
+		"Several days":            "कई दिन",
+		"More than half the days": "आधे से अधिक दिन",
+		"Nearly every day":        "लगभग हर दिन",
+	}
+}
+
+func phq9Hindi() entities.QuestionnaireTranslation {
+	return entities.QuestionnaireTranslation{
+		Name:        "phq9",
+		Version:     1,
+		Locale:      "hi",
+		DisplayName: "PHQ-9 अवसाद परीक्षण",
+		Description: "पिछले 2 हफ़्तों में, आप निम्नलिखित में से किसी भी समस्या से कितनी बार परेशान हुए हैं?",
+		Questions: map[string]string{
+			"q1": "काम करने में कम रुचि या आनंद",
+			"q2": "उदास, निराश या हताश महसूस करना",
+			"q3": "नींद आने या सोते रहने में परेशानी, या बहुत अधिक सोना",
+			"q4": "थकान महसूस करना या ऊर्जा की कमी",
+			"q5": "भूख कम लगना या ज़्यादा खाना",
+			"q6": "अपने बारे में बुरा महसूस करना, या यह कि आप असफल हैं या आपने ख़ुद को या अपने परिवार को निराश किया है",
+			"q7": "चीज़ों पर ध्यान लगाने में परेशानी, जैसे अख़बार पढ़ना या टीवी देखना",
+			"q8": "इतना धीरे चलना या बोलना कि दूसरों ने भी ग़ौर किया हो, या इसके उलट, इतना बेचैन रहना कि आप सामान्य से बहुत अधिक इधर-उधर घूमते रहे हों",
+			"q9": "यह विचार कि आपके लिए मर जाना बेहतर होगा, या किसी तरह ख़ुद को चोट पहुँचाने के विचार",
+		},
+		Options: frequencyOptionsHindi(),
+		Recommendations: map[string]string{
+			"minimal":           "अवसाद के न्यूनतम लक्षण। किसी उपचार की ज़रूरत नहीं है; अगर आपका मूड बदले तो जाँच दोबारा करें।",
+			"mild":              "अवसाद के हल्के लक्षण। अपने मूड पर नज़र रखें और 2 से 4 हफ़्तों में जाँच दोबारा करें।",
+			"moderate":          "अवसाद के मध्यम लक्षण। उपचार की योजना के लिए कृपया किसी डॉक्टर या काउंसलर से बात करें।",
+			"moderately severe": "अवसाद के मध्यम रूप से गंभीर लक्षण। उपचार पर चर्चा के लिए कृपया जल्द ही परामर्श बुक करें।",
+			"severe":            "अवसाद के गंभीर लक्षण। कृपया जितनी जल्दी हो सके किसी डॉक्टर या मानसिक स्वास्थ्य विशेषज्ञ से मिलें।",
+		},
+		CriticalNote: "आपने हमें बताया है कि आपके मन में मर जाना बेहतर होने या ख़ुद को चोट पहुँचाने के विचार आए हैं। कृपया आज ही अपने डॉक्टर से बात करें, या Tele-MANAS हेल्पलाइन 14416 पर कॉल करें (24x7, निःशुल्क)।",
+	}
+}
+
+func gad7Hindi() entities.QuestionnaireTranslation {
+	return entities.QuestionnaireTranslation{
+		Name:        "gad7",
+		Version:     1,
+		Locale:      "hi",
+		DisplayName: "GAD-7 चिंता परीक्षण",
+		Description: "पिछले 2 हफ़्तों में, आप निम्नलिखित समस्याओं से कितनी बार परेशान हुए हैं?",
+		Questions: map[string]string{
+			"q1": "घबराहट, चिंता या बेचैनी महसूस करना",
+			"q2": "चिंता करना बंद न कर पाना या उस पर क़ाबू न रख पाना",
+			"q3": "अलग-अलग बातों को लेकर बहुत अधिक चिंता करना",
+			"q4": "आराम करने में परेशानी",
+			"q5": "इतना बेचैन होना कि स्थिर बैठना मुश्किल हो",
+			"q6": "आसानी से नाराज़ या चिड़चिड़ा हो जाना",
+			"q7": "डर लगना, जैसे कि कुछ बहुत बुरा होने वाला हो",
+		},
+		Options: frequencyOptionsHindi(),
+		Recommendations: map[string]string{
+			"minimal":  "न्यूनतम चिंता। अगर आपको चिंता महसूस होने लगे तो जाँच दोबारा करें।",
+			"mild":     "हल्की चिंता। विश्राम की तकनीकें और नियमित व्यायाम मदद कर सकते हैं; 2 से 4 हफ़्तों में जाँच दोबारा करें।",
+			"moderate": "मध्यम चिंता। आगे के मूल्यांकन के लिए कृपया किसी डॉक्टर या काउंसलर से बात करें।",
+			"severe":   "गंभीर चिंता। कृपया जितनी जल्दी हो सके किसी डॉक्टर या मानसिक स्वास्थ्य विशेषज्ञ से मिलें।",
+		},
+	}
+}
+
+func pcosHindi() entities.QuestionnaireTranslation {
+	return entities.QuestionnaireTranslation{
+		Name:        "pcos",
+		Version:     1,
+		Locale:      "hi",
+		DisplayName: "PCOS लक्षण जाँच",
+		Description: "पॉलीसिस्टिक ओवरी सिंड्रोम से जुड़े लक्षणों की जाँच",
+		Questions: map[string]string{
+			"q1": "क्या आपके पीरियड्स अनियमित हैं?",
+			"q2": "क्या आपके शरीर पर अत्यधिक बाल उगते हैं?",
+			"q3": "क्या आपको मुँहासे हैं या आपकी त्वचा तैलीय है?",
+			"q4": "क्या आपका वज़न बढ़ा है?",
+			"q5": "क्या आपको वज़न कम करने में कठिनाई होती है?",
+			"q6": "क्या आपके बाल पतले हो रहे हैं या झड़ रहे हैं?",
+			"q7": "क्या शरीर की सिलवटों में आपकी त्वचा काली पड़ गई है?",
+			"q8": "क्या आपको इंसुलिन रेज़िस्टेंस का निदान हुआ है?",
+		},
+		Options: map[string]string{"No": "नहीं", "Yes": "हाँ"},
+		Recommendations: map[string]string{
+			"low risk":      "PCOS के कुछ ही लक्षण हैं। अपने मासिक चक्र को ट्रैक करती रहें।",
+			"moderate risk": "PCOS से जुड़े कुछ लक्षण हैं। इनके बारे में किसी स्त्री रोग विशेषज्ञ से बात करने पर विचार करें।",
+			"high risk":     "PCOS से जुड़े कई लक्षण हैं। कृपया जाँच के लिए किसी स्त्री रोग विशेषज्ञ से परामर्श बुक करें।",
+		},
+	}
+}
+
+func pcosTamil() entities.QuestionnaireTranslation {
+	return entities.QuestionnaireTranslation{
+		Name:        "pcos",
+		Version:     1,
+		Locale:      "ta",
+		DisplayName: "PCOS அறிகுறி பரிசோதனை",
+		Description: "பாலிசிஸ்டிக் ஓவரி சிண்ட்ரோம் தொடர்பான அறிகுறிகளுக்கான பரிசோதனை",
+		Questions: map[string]string{
+			"q1": "உங்களுக்கு மாதவிடாய் ஒழுங்கற்றதாக உள்ளதா?",
+			"q2": "உங்களுக்கு அதிகப்படியான முடி வளர்ச்சி உள்ளதா?",
+			"q3": "உங்களுக்கு முகப்பரு அல்லது எண்ணெய்ப் பசை சருமம் உள்ளதா?",
+			"q4": "உங்கள் உடல் எடை அதிகரித்துள்ளதா?",
+			"q5": "உடல் எடையைக் குறைப்பதில் உங்களுக்குச் சிரமம் உள்ளதா?",
+			"q6": "உங்களுக்கு முடி மெலிதல் அல்லது முடி உதிர்தல் உள்ளதா?",
+			"q7": "உடல் மடிப்புகளில் உங்கள் சருமம் கருமையாகியுள்ளதா?",
+			"q8": "உங்களுக்கு இன்சுலின் எதிர்ப்பு இருப்பதாகக் கண்டறியப்பட்டுள்ளதா?",
+		},
+		Options: map[string]string{"No": "இல்லை", "Yes": "ஆம்"},
+		Recommendations: map[string]string{
+			"low risk":      "PCOS அறிகுறிகள் குறைவாக உள்ளன. உங்கள் மாதவிடாய் சுழற்சியைத் தொடர்ந்து பதிவு செய்யுங்கள்.",
+			"moderate risk": "PCOS தொடர்பான சில அறிகுறிகள் உள்ளன. அவற்றைப் பற்றி ஒரு மகப்பேறு மருத்துவரிடம் பேசுவதைக் கருத்தில் கொள்ளுங்கள்.",
+			"high risk":     "PCOS தொடர்பான பல அறிகுறிகள் உள்ளன. பரிசோதனைக்காக ஒரு மகப்பேறு மருத்துவரிடம் ஆலோசனைக்கு முன்பதிவு செய்யுங்கள்.",
+		},
+	}
+}
+
+func pcosBengali() entities.QuestionnaireTranslation {
+	return entities.QuestionnaireTranslation{
+		Name:        "pcos",
+		Version:     1,
+		Locale:      "bn",
+		DisplayName: "PCOS উপসর্গ স্ক্রিনিং",
+		Description: "পলিসিস্টিক ওভারি সিনড্রোমের সঙ্গে যুক্ত উপসর্গের স্ক্রিনিং",
+		Questions: map[string]string{
+			"q1": "আপনার কি অনিয়মিত পিরিয়ড হয়?",
+			"q2": "আপনার কি শরীরে অতিরিক্ত লোম গজায়?",
+			"q3": "আপনার কি ব্রণ বা তৈলাক্ত ত্বক আছে?",
+			"q4": "আপনার কি ওজন বেড়েছে?",
+			"q5": "আপনার কি ওজন কমাতে অসুবিধা হয়?",
+			"q6": "আপনার কি চুল পাতলা হয়ে যাচ্ছে বা চুল পড়ছে?",
+			"q7": "আপনার শরীরের ভাঁজে কি ত্বক কালো হয়ে গেছে?",
+			"q8": "আপনার কি ইনসুলিন রেজিস্ট্যান্স ধরা পড়েছে?",
+		},
+		Options: map[string]string{"No": "না", "Yes": "হ্যাঁ"},
+		Recommendations: map[string]string{
+			"low risk":      "PCOS-এর উপসর্গ কম। আপনার মাসিক চক্র ট্র্যাক করা চালিয়ে যান।",
+			"moderate risk": "PCOS-এর সঙ্গে যুক্ত কিছু উপসর্গ আছে। এগুলো নিয়ে একজন স্ত্রীরোগ বিশেষজ্ঞের সঙ্গে কথা বলার কথা ভাবুন।",
+			"high risk":     "PCOS-এর সঙ্গে যুক্ত অনেক উপসর্গ আছে। মূল্যায়নের জন্য অনুগ্রহ করে একজন স্ত্রীরোগ বিশেষজ্ঞের সঙ্গে পরামর্শ বুক করুন।",
+		},
+	}
+}
@@ -0,0 +1,403 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrNotDoctor          = errors.New("only doctors can view shared records")
+	ErrShareNotAccessible = errors.New("shared records are not available")
+)
+
+const (
+	// Without an explicit expiry, access lasts until three days after the consultation
+	defaultShareHoursAfterBooking = 72
+	minShareHours                 = 1
+	maxShareHours                 = 30 * 24
+
+	sharedCycleLimit   = 6
+	sharedSymptomsDays = 90
+)
+
+type RecordShareService struct {
+	shareRepo        repositories.RecordShareRepository
+	bookingRepo      repositories.BookingRepository
+	doctorRepo       repositories.DoctorRepository
+	userRepo         repositories.UserRepository
+	mentalHealthRepo repositories.MentalHealthRepository
+	pcosRepo         repositories.PCOSRepository
+	periodRepo       repositories.PeriodRepository
+	symptomsRepo     repositories.SymptomsRepository
+}
+
+func NewRecordShareService(
+	shareRepo repositories.RecordShareRepository,
+	bookingRepo repositories.BookingRepository,
+	doctorRepo repositories.DoctorRepository,
+	userRepo repositories.UserRepository,
+	mentalHealthRepo repositories.MentalHealthRepository,
+	pcosRepo repositories.PCOSRepository,
+	periodRepo repositories.PeriodRepository,
+	symptomsRepo repositories.SymptomsRepository,
+) *RecordShareService {
+	return &RecordShareService{
+		shareRepo:        shareRepo,
+		bookingRepo:      bookingRepo,
+		doctorRepo:       doctorRepo,
+		userRepo:         userRepo,
+		mentalHealthRepo: mentalHealthRepo,
+		pcosRepo:         pcosRepo,
+		periodRepo:       periodRepo,
+		symptomsRepo:     symptomsRepo,
+	}
+}
+
+// Grant gives the doctor of one of the user's bookings read access to the chosen
+// records. Chosen results and assessments must belong to the user.
+func (s *RecordShareService) Grant(ctx context.Context, userID string, req *entities.RecordShareRequest, ipAddress string) (*entities.RecordShare, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	booking, err := s.bookingRepo.FindByID(ctx, req.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil || booking.UserID != userOID {
+		return nil, errors.New("booking not found")
+	}
+	if booking.Status == "cancelled" {
+		return nil, errors.New("cannot share records for a cancelled booking")
+	}
+
+	if len(req.TestResultIDs) == 0 && len(req.PCOSAssessmentIDs) == 0 && !req.IncludePeriods && !req.IncludeSymptoms {
+		return nil, errors.New("choose at least one record to share")
+	}
+
+	resultIDs, err := parseObjectIDs(req.TestResultIDs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test result ID: %w", err)
+	}
+	if len(resultIDs) > 0 {
+		results, err := s.mentalHealthRepo.FindResultsByIDs(ctx, userID, resultIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) != len(resultIDs) {
+			return nil, errors.New("test result not found")
+		}
+	}
+
+	assessmentIDs, err := parseObjectIDs(req.PCOSAssessmentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PCOS assessment ID: %w", err)
+	}
+	if len(assessmentIDs) > 0 {
+		assessments, err := s.pcosRepo.FindByIDs(ctx, userID, assessmentIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(assessments) != len(assessmentIDs) {
+			return nil, errors.New("PCOS assessment not found")
+		}
+	}
+
+	now := time.Now()
+	var expiresAt time.Time
+	switch {
+	case req.ExpiresInHours == 0:
+		expiresAt = booking.Date.Add(defaultShareHoursAfterBooking * time.Hour)
+		if limit := now.Add(maxShareHours * time.Hour); expiresAt.After(limit) {
+			expiresAt = limit
+		}
+		// A share for a long-past booking would otherwise expire before it is created
+		if floor := now.Add(minShareHours * time.Hour); expiresAt.Before(floor) {
+			expiresAt = floor
+		}
+	case req.ExpiresInHours < minShareHours || req.ExpiresInHours > maxShareHours:
+		return nil, fmt.Errorf("expiresInHours must be between %d and %d", minShareHours, maxShareHours)
+	default:
+		expiresAt = now.Add(time.Duration(req.ExpiresInHours) * time.Hour)
+	}
+
+	share := &entities.RecordShare{
+		UserID:            userOID,
+		DoctorID:          booking.DoctorID,
+		BookingID:         booking.ID,
+		TestResultIDs:     resultIDs,
+		PCOSAssessmentIDs: assessmentIDs,
+		IncludePeriods:    req.IncludePeriods,
+		IncludeSymptoms:   req.IncludeSymptoms,
+		ExpiresAt:         expiresAt,
+	}
+	if err := s.shareRepo.Create(ctx, share); err != nil {
+		return nil, err
+	}
+	s.logAccess(ctx, share, "granted", ipAddress)
+
+	return share, nil
+}
+
+func (s *RecordShareService) GetShares(ctx context.Context, userID string) ([]*entities.RecordShare, error) {
+	return s.shareRepo.FindByUserID(ctx, userID)
+}
+
+// Revoke ends the doctor's access immediately
+func (s *RecordShareService) Revoke(ctx context.Context, userID, shareID, ipAddress string) (*entities.RecordShare, error) {
+	share, err := s.findOwnShare(ctx, userID, shareID)
+	if err != nil {
+		return nil, err
+	}
+	if share.RevokedAt != nil {
+		return share, nil
+	}
+
+	now := time.Now()
+	share.RevokedAt = &now
+	if err := s.shareRepo.Update(ctx, share); err != nil {
+		return nil, err
+	}
+	s.logAccess(ctx, share, "revoked", ipAddress)
+
+	return share, nil
+}
+
+// GetAccessLog returns who accessed a share and when, newest first
+func (s *RecordShareService) GetAccessLog(ctx context.Context, userID, shareID string) ([]*entities.RecordAccessLog, error) {
+	if _, err := s.findOwnShare(ctx, userID, shareID); err != nil {
+		return nil, err
+	}
+
+	return s.shareRepo.FindAccessLogs(ctx, shareID)
+}
+
+// GetSharedWithDoctor returns the active shares for the doctor signed in as userID
+func (s *RecordShareService) GetSharedWithDoctor(ctx context.Context, userID string) ([]*entities.RecordShare, error) {
+	doctor, err := s.doctorForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.shareRepo.FindActiveByDoctorID(ctx, doctor.ID, time.Now())
+}
+
+// GetSummary builds the pre-consultation summary of a share for the doctor signed
+// in as userID. Every attempt on a share addressed to the doctor is logged.
+func (s *RecordShareService) GetSummary(ctx context.Context, userID, shareID, ipAddress string) (*entities.PreConsultationSummary, error) {
+	doctor, err := s.doctorForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := s.shareRepo.FindByID(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+	if share == nil || share.DoctorID.Hex() != doctor.ID {
+		return nil, ErrShareNotAccessible
+	}
+	if !share.Active(time.Now()) {
+		s.logAccess(ctx, share, "denied", ipAddress)
+		return nil, ErrShareNotAccessible
+	}
+
+	summary, err := s.buildSummary(ctx, share)
+	if err != nil {
+		return nil, err
+	}
+	// Records are only released once the view has been logged
+	if err := s.shareRepo.CreateAccessLog(ctx, accessLogEntry(share, "viewed_summary", ipAddress)); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (s *RecordShareService) buildSummary(ctx context.Context, share *entities.RecordShare) (*entities.PreConsultationSummary, error) {
+	userID := share.UserID.Hex()
+	summary := &entities.PreConsultationSummary{
+		ShareID:     share.ID,
+		ExpiresAt:   share.ExpiresAt,
+		Assessments: []*entities.TestResult{},
+		PCOS:        []*entities.PCOSAssessment{},
+		Highlights:  []string{},
+	}
+
+	user, err := s.userRepo.FindByID(ctx, share.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		summary.Patient = entities.SummaryPatient{Name: user.Name, TrackingMode: user.TrackingMode}
+	}
+
+	booking, err := s.bookingRepo.FindByID(ctx, share.BookingID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	summary.Booking = booking
+
+	if len(share.TestResultIDs) > 0 {
+		results, err := s.mentalHealthRepo.FindResultsByIDs(ctx, userID, share.TestResultIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			// The doctor sees scores and bands, not the individual answers
+			result.Answers = nil
+			summary.Assessments = append(summary.Assessments, result)
+
+			highlight := fmt.Sprintf("%s on %s: %s (score %d)",
+				result.TestName, result.TestDate.Format("2006-01-02"), result.Level, result.ObtainedScore)
+			if result.Critical {
+				highlight += ", critical item answered"
+			}
+			summary.Highlights = append(summary.Highlights, highlight)
+		}
+	}
+
+	if len(share.PCOSAssessmentIDs) > 0 {
+		assessments, err := s.pcosRepo.FindByIDs(ctx, userID, share.PCOSAssessmentIDs)
+		if err != nil {
+			return nil, err
+		}
+		summary.PCOS = assessments
+		for _, assessment := range assessments {
+			highlight := fmt.Sprintf("PCOS assessment on %s: %s, %d of 3 Rotterdam criteria met",
+				assessment.CreatedAt.Format("2006-01-02"), assessment.Result, assessment.CriteriaMet)
+			if assessment.Phenotype != "" {
+				highlight += fmt.Sprintf(" (phenotype %s)", assessment.Phenotype)
+			}
+			summary.Highlights = append(summary.Highlights, highlight)
+		}
+	}
+
+	if share.IncludePeriods {
+		cycles, err := s.periodRepo.FindByUserID(ctx, userID, sharedCycleLimit)
+		if err != nil {
+			return nil, err
+		}
+		if len(cycles) > 0 {
+			summary.Cycles = summarizeCycles(cycles)
+			if summary.Cycles.AverageCycleLength > 0 {
+				summary.Highlights = append(summary.Highlights, fmt.Sprintf("Average cycle %d days (range %d-%d) over the last %d periods",
+					summary.Cycles.AverageCycleLength, summary.Cycles.ShortestCycle, summary.Cycles.LongestCycle, len(cycles)))
+			}
+		}
+	}
+
+	if share.IncludeSymptoms {
+		logs, err := s.symptomsRepo.FindByUserID(ctx, userID, 100)
+		if err != nil {
+			return nil, err
+		}
+		since := time.Now().AddDate(0, 0, -sharedSymptomsDays)
+		summary.Symptoms = []*entities.SymptomsTracking{}
+		for _, entry := range logs {
+			if entry.Date.After(since) {
+				summary.Symptoms = append(summary.Symptoms, entry)
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// summarizeCycles computes cycle lengths from consecutive period starts;
+// cycles are given newest first
+func summarizeCycles(cycles []*entities.PeriodCycle) *entities.CycleSummary {
+	summary := &entities.CycleSummary{
+		LastPeriodStart: cycles[0].StartDate,
+		Cycles:          cycles,
+	}
+
+	total := 0
+	for i := 1; i < len(cycles); i++ {
+		length := int(cycles[i-1].StartDate.Sub(cycles[i].StartDate).Hours() / 24)
+		if summary.ShortestCycle == 0 || length < summary.ShortestCycle {
+			summary.ShortestCycle = length
+		}
+		if length > summary.LongestCycle {
+			summary.LongestCycle = length
+		}
+		total += length
+	}
+	if len(cycles) > 1 {
+		summary.AverageCycleLength = total / (len(cycles) - 1)
+	}
+
+	return summary
+}
+
+func (s *RecordShareService) findOwnShare(ctx context.Context, userID, shareID string) (*entities.RecordShare, error) {
+	share, err := s.shareRepo.FindByID(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+	if share == nil || share.UserID.Hex() != userID {
+		return nil, errors.New("share not found")
+	}
+	return share, nil
+}
+
+// doctorForUser resolves the doctor profile linked to the user's account
+func (s *RecordShareService) doctorForUser(ctx context.Context, userID string) (*entities.Doctor, error) {
+	if !primitive.IsValidObjectID(userID) {
+		return nil, ErrNotDoctor
+	}
+
+	doctor, err := s.doctorRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if doctor == nil {
+		return nil, ErrNotDoctor
+	}
+	return doctor, nil
+}
+
+// logAccess records an access log entry. Failures are logged rather than returned
+// so they never block a patient from granting or revoking access.
+func (s *RecordShareService) logAccess(ctx context.Context, share *entities.RecordShare, action, ipAddress string) {
+	if err := s.shareRepo.CreateAccessLog(ctx, accessLogEntry(share, action, ipAddress)); err != nil {
+		log.Printf("Failed to log %s for record share %s: %v", action, share.ID.Hex(), err)
+	}
+}
+
+func accessLogEntry(share *entities.RecordShare, action, ipAddress string) *entities.RecordAccessLog {
+	return &entities.RecordAccessLog{
+		ShareID:   share.ID,
+		UserID:    share.UserID,
+		DoctorID:  share.DoctorID,
+		Action:    action,
+		IPAddress: ipAddress,
+	}
+}
+
+// parseObjectIDs parses hex IDs, dropping repeats so they can be counted against
+// the records found
+func parseObjectIDs(ids []string) ([]primitive.ObjectID, error) {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	for _, id := range ids {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		if seen[oid] {
+			continue
+		}
+		seen[oid] = true
+		oids = append(oids, oid)
+	}
+	return oids, nil
+}
@@ -0,0 +1,428 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+	"github.com/anshjamwal15/hsb_backend/pkg/pdf"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrReportNotFound = errors.New("result not found")
+
+const (
+	reportCycleLimit   = 12
+	reportWeightLimit  = 30
+	reportSymptomsDays = 90
+
+	reportDisclaimer = "This report is generated from information entered in the app. " +
+		"It is a screening summary to support a conversation with your doctor and is not a diagnosis."
+)
+
+type ReportService struct {
+	userRepo             repositories.UserRepository
+	mentalHealthRepo     repositories.MentalHealthRepository
+	fsfiRepo             repositories.FSFIRepository
+	pcosRepo             repositories.PCOSRepository
+	periodRepo           repositories.PeriodRepository
+	weightRepo           repositories.WeightRepository
+	symptomsRepo         repositories.SymptomsRepository
+	questionnaireService *QuestionnaireService
+}
+
+func NewReportService(
+	userRepo repositories.UserRepository,
+	mentalHealthRepo repositories.MentalHealthRepository,
+	fsfiRepo repositories.FSFIRepository,
+	pcosRepo repositories.PCOSRepository,
+	periodRepo repositories.PeriodRepository,
+	weightRepo repositories.WeightRepository,
+	symptomsRepo repositories.SymptomsRepository,
+	questionnaireService *QuestionnaireService,
+) *ReportService {
+	return &ReportService{
+		userRepo:             userRepo,
+		mentalHealthRepo:     mentalHealthRepo,
+		fsfiRepo:             fsfiRepo,
+		pcosRepo:             pcosRepo,
+		periodRepo:           periodRepo,
+		weightRepo:           weightRepo,
+		symptomsRepo:         symptomsRepo,
+		questionnaireService: questionnaireService,
+	}
+}
+
+// AssessmentReport renders a questionnaire result such as PHQ-9 or GAD-7 with
+// each question and the answer given
+func (s *ReportService) AssessmentReport(ctx context.Context, userID, resultID string) ([]byte, error) {
+	oid, err := primitive.ObjectIDFromHex(resultID)
+	if err != nil {
+		return nil, ErrReportNotFound
+	}
+	results, err := s.mentalHealthRepo.FindResultsByIDs(ctx, userID, []primitive.ObjectID{oid})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrReportNotFound
+	}
+	result := results[0]
+
+	test, err := s.questionnaireService.GetDefinition(ctx, result.TestName, result.TestVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.newReport(ctx, userID, test.DisplayName+" Report")
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Heading("Result")
+	doc.Field("Date taken", result.TestDate.Format("2 January 2006"))
+	doc.Field("Score", fmt.Sprintf("%d of %d", result.ObtainedScore, result.TotalScore))
+	doc.Field("Severity", result.Level)
+	if result.Recommendation != "" {
+		doc.Field("Recommendation", result.Recommendation)
+	}
+	if result.Critical {
+		doc.Field("Important", result.CriticalNote)
+	}
+	if len(result.SubScores) > 0 {
+		names := make([]string, 0, len(result.SubScores))
+		for name := range result.SubScores {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			doc.Field(name, fmt.Sprintf("%d", result.SubScores[name]))
+		}
+	}
+
+	doc.Heading("Answers")
+	for i, item := range questionItems(test) {
+		answer, ok := result.Answers[item.Key]
+		if !ok {
+			continue
+		}
+		doc.Item(fmt.Sprintf("%d. %s", i+1, item.QuestionText), answerLabel(item, answer))
+	}
+
+	reportSeverityBands(doc, test)
+	doc.Space(10)
+	doc.Note(reportDisclaimer)
+
+	return doc.Bytes()
+}
+
+// FSFIReport renders an FSFI result with its domain scores and answers
+func (s *ReportService) FSFIReport(ctx context.Context, userID, resultID string) ([]byte, error) {
+	if _, err := primitive.ObjectIDFromHex(resultID); err != nil {
+		return nil, ErrReportNotFound
+	}
+	result, err := s.fsfiRepo.FindByID(ctx, userID, resultID)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ErrReportNotFound
+	}
+
+	test, err := s.questionnaireService.GetDefinition(ctx, "fsfi", result.TestVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.newReport(ctx, userID, "Female Sexual Function Index Report")
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Heading("Result")
+	doc.Field("Date taken", result.SubmittedAt.Format("2 January 2006"))
+	doc.Field("Total score", fmt.Sprintf("%.1f of 36.0 (cutoff %.2f)", result.TotalScore, fsfiCutoff))
+	doc.Field("Interpretation", result.Diagnosis)
+	if result.Recommendation != "" {
+		doc.Field("Recommendation", result.Recommendation)
+	}
+
+	doc.Heading("Domain scores")
+	domains := result.DomainScores
+	doc.Table([]string{"Domain", "Score", "Range"}, []float64{3, 1, 1}, [][]string{
+		{"Desire", fmt.Sprintf("%.1f", domains.Desire), "1.2 - 6.0"},
+		{"Arousal", fmt.Sprintf("%.1f", domains.Arousal), "0 - 6.0"},
+		{"Lubrication", fmt.Sprintf("%.1f", domains.Lubrication), "0 - 6.0"},
+		{"Orgasm", fmt.Sprintf("%.1f", domains.Orgasm), "0 - 6.0"},
+		{"Satisfaction", fmt.Sprintf("%.1f", domains.Satisfaction), "0.8 - 6.0"},
+		{"Pain", fmt.Sprintf("%.1f", domains.Pain), "0 - 6.0"},
+	})
+
+	doc.Heading("Answers")
+	for i, item := range questionItems(test) {
+		if i >= len(result.Responses) {
+			break
+		}
+		doc.Item(fmt.Sprintf("%d. %s", i+1, item.QuestionText), answerLabel(item, result.Responses[i]))
+	}
+
+	doc.Space(10)
+	doc.Note(reportDisclaimer)
+
+	return doc.Bytes()
+}
+
+// PCOSReport renders a Rotterdam-criteria assessment
+func (s *ReportService) PCOSReport(ctx context.Context, userID, assessmentID string) ([]byte, error) {
+	oid, err := primitive.ObjectIDFromHex(assessmentID)
+	if err != nil {
+		return nil, ErrReportNotFound
+	}
+	assessments, err := s.pcosRepo.FindByIDs(ctx, userID, []primitive.ObjectID{oid})
+	if err != nil {
+		return nil, err
+	}
+	if len(assessments) == 0 {
+		return nil, ErrReportNotFound
+	}
+	assessment := assessments[0]
+
+	doc, err := s.newReport(ctx, userID, "PCOS Assessment Report")
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Heading("Result")
+	doc.Field("Date", assessment.CreatedAt.Format("2 January 2006"))
+	doc.Field("Result", assessment.Result)
+	doc.Field("Criteria met", fmt.Sprintf("%d of 3 (Rotterdam criteria require 2)", assessment.CriteriaMet))
+	if assessment.Phenotype != "" {
+		doc.Field("Phenotype", assessment.Phenotype)
+	}
+
+	doc.Heading("Rotterdam criteria")
+	criteria := []struct {
+		name      string
+		criterion entities.RotterdamCriterion
+	}{
+		{"Oligo/anovulation", assessment.Criteria.OvulatoryDysfunction},
+		{"Hyperandrogenism", assessment.Criteria.Hyperandrogenism},
+		{"Polycystic ovaries", assessment.Criteria.PolycysticOvaries},
+	}
+	for _, c := range criteria {
+		status := strings.ReplaceAll(c.criterion.Status, "_", " ")
+		if c.criterion.Source != "" {
+			status += " (" + strings.ReplaceAll(c.criterion.Source, "_", " ") + ")"
+		}
+		doc.Field(c.name, status)
+		for _, detail := range c.criterion.Details {
+			doc.Bullet(detail)
+		}
+	}
+	if mfg := assessment.FerrimanGallwey; mfg != nil {
+		doc.Field("mFG score", fmt.Sprintf("%d", mfg.Total()))
+	}
+	if assessment.QuestionnaireVersion > 0 {
+		doc.Field("Symptom screen", fmt.Sprintf("%d of 8 symptoms", assessment.Score))
+	}
+
+	if len(assessment.Recommendations) > 0 {
+		doc.Heading("Next steps")
+		for _, recommendation := range assessment.Recommendations {
+			doc.Bullet(recommendation)
+		}
+	}
+	if len(assessment.RecommendedTests) > 0 {
+		doc.Field("Recommended tests", strings.Join(assessment.RecommendedTests, ", "))
+	}
+
+	doc.Space(10)
+	doc.Note(reportDisclaimer)
+
+	return doc.Bytes()
+}
+
+// HealthSummaryReport renders cycle statistics, the weight trend and the most
+// frequent symptoms
+func (s *ReportService) HealthSummaryReport(ctx context.Context, userID string) ([]byte, error) {
+	cycles, err := s.periodRepo.FindByUserID(ctx, userID, reportCycleLimit)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := s.weightRepo.FindByUserID(ctx, userID, reportWeightLimit)
+	if err != nil {
+		return nil, err
+	}
+	symptomLogs, err := s.symptomsRepo.FindByUserID(ctx, userID, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.newReport(ctx, userID, "Health Summary")
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Heading("Menstrual cycles")
+	if len(cycles) == 0 {
+		doc.Paragraph("No periods logged.")
+	} else {
+		summary := summarizeCycles(cycles)
+		doc.Field("Last period started", summary.LastPeriodStart.Format("2 January 2006"))
+		if summary.AverageCycleLength > 0 {
+			doc.Field("Average cycle length", fmt.Sprintf("%d days", summary.AverageCycleLength))
+			doc.Field("Shortest / longest", fmt.Sprintf("%d / %d days", summary.ShortestCycle, summary.LongestCycle))
+		}
+		doc.Space(6)
+
+		rows := make([][]string, 0, len(cycles))
+		for i, cycle := range cycles {
+			length, days := "", ""
+			if i+1 < len(cycles) {
+				length = fmt.Sprintf("%d", int(cycle.StartDate.Sub(cycles[i+1].StartDate).Hours()/24))
+			}
+			if !cycle.EndDate.IsZero() && cycle.EndDate.After(cycle.StartDate) {
+				days = fmt.Sprintf("%d", int(cycle.EndDate.Sub(cycle.StartDate).Hours()/24)+1)
+			}
+			rows = append(rows, []string{cycle.StartDate.Format("2 Jan 2006"), days, length, cycle.Flow})
+		}
+		doc.Table([]string{"Period start", "Period days", "Cycle length", "Flow"}, []float64{2, 1, 1, 1}, rows)
+	}
+
+	doc.Heading("Weight")
+	if len(weights) == 0 {
+		doc.Paragraph("No weight entries logged.")
+	} else {
+		// Entries are newest first; chart oldest to newest
+		values := make([]float64, len(weights))
+		for i, entry := range weights {
+			values[len(weights)-1-i] = entry.Weight
+		}
+		first, latest := weights[len(weights)-1], weights[0]
+		doc.Field("Latest", fmt.Sprintf("%.1f kg on %s", latest.Weight, weightDate(latest).Format("2 January 2006")))
+		if latest.BMI > 0 {
+			doc.Field("BMI", fmt.Sprintf("%.1f", latest.BMI))
+		}
+		if len(weights) > 1 {
+			doc.Field("Change", fmt.Sprintf("%+.1f kg since %s", latest.Weight-first.Weight, weightDate(first).Format("2 January 2006")))
+		}
+		doc.LineChart(values, weightDate(first).Format("2 Jan 2006"), weightDate(latest).Format("2 Jan 2006"))
+	}
+
+	doc.Heading(fmt.Sprintf("Symptoms in the last %d days", reportSymptomsDays))
+	counts := symptomCounts(symptomLogs, time.Now().AddDate(0, 0, -reportSymptomsDays))
+	if len(counts) == 0 {
+		doc.Paragraph("No symptoms logged.")
+	} else {
+		rows := make([][]string, 0, len(counts))
+		for _, count := range counts {
+			rows = append(rows, []string{count.name, fmt.Sprintf("%d", count.days)})
+		}
+		doc.Table([]string{"Symptom or mood", "Days logged"}, []float64{3, 1}, rows)
+	}
+
+	doc.Space(10)
+	doc.Note(reportDisclaimer)
+
+	return doc.Bytes()
+}
+
+// newReport starts a document with the title and the patient's name
+func (s *ReportService) newReport(ctx context.Context, userID, title string) (*pdf.Document, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	user, err := s.userRepo.FindByID(ctx, userOID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := pdf.New(title)
+	doc.Title(title)
+	doc.Field("Name", user.Name)
+	doc.Field("Generated", time.Now().Format("2 January 2006"))
+	return doc, nil
+}
+
+func reportSeverityBands(doc *pdf.Document, test *entities.MentalHealthTest) {
+	if len(test.Thresholds) == 0 {
+		return
+	}
+	doc.Heading("Severity bands")
+	rows := make([][]string, 0, len(test.Thresholds))
+	for _, threshold := range test.Thresholds {
+		rows = append(rows, []string{fmt.Sprintf("%d - %d", threshold.Min, threshold.Max), threshold.Severity})
+	}
+	doc.Table([]string{"Score", "Severity"}, []float64{1, 3}, rows)
+}
+
+// answerLabel describes an answer by its option label and value
+func answerLabel(item entities.TestQuestion, answer interface{}) string {
+	value, itemMax, err := answerValue(item, answer)
+	if err != nil {
+		return fmt.Sprint(answer)
+	}
+	if item.ResponseType == "slider" {
+		return fmt.Sprintf("%d of %d", value, itemMax)
+	}
+	for _, option := range item.Options {
+		if option.Value == value {
+			return fmt.Sprintf("%s (%d)", option.Label, value)
+		}
+	}
+	return fmt.Sprintf("%d", value)
+}
+
+type symptomCount struct {
+	name string
+	days int
+}
+
+// symptomCounts counts the days each symptom or mood was logged since the given
+// time, most frequent first
+func symptomCounts(logs []*entities.SymptomsTracking, since time.Time) []symptomCount {
+	days := map[string]map[string]bool{}
+	for _, entry := range logs {
+		if entry.Date.Before(since) {
+			continue
+		}
+		day := entry.Date.Format("2006-01-02")
+		for _, names := range [][]string{entry.Symptoms, entry.Mood, entry.WhatAreYouFeelingToday} {
+			for _, name := range names {
+				if days[name] == nil {
+					days[name] = map[string]bool{}
+				}
+				days[name][day] = true
+			}
+		}
+	}
+
+	counts := make([]symptomCount, 0, len(days))
+	for name, logged := range days {
+		counts = append(counts, symptomCount{name: name, days: len(logged)})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].days != counts[j].days {
+			return counts[i].days > counts[j].days
+		}
+		return counts[i].name < counts[j].name
+	})
+	if len(counts) > 15 {
+		counts = counts[:15]
+	}
+	return counts
+}
+
+// weightDate reads an entry date stored as Unix seconds or milliseconds
+func weightDate(entry *entities.WeightMetabolic) time.Time {
+	if entry.Date > 1e12 {
+		return time.UnixMilli(entry.Date)
+	}
+	return time.Unix(entry.Date, 0)
+}
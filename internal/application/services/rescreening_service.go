@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+)
+
+// Instruments that are repeated on a schedule
+var rescreenedTests = []string{"phq9", "gad7", "epds"}
+
+// Days until the next screening by the alert level of the last result; higher
+// severity is rescreened sooner
+var rescreenIntervalDays = map[string]int{
+	"none":     28,
+	"low":      28,
+	"moderate": 14,
+	"high":     7,
+}
+
+const (
+	defaultRescreenIntervalDays  = 28
+	criticalRescreenIntervalDays = 7
+)
+
+type RescreeningService struct {
+	mentalHealthRepo     repositories.MentalHealthRepository
+	questionnaireService *QuestionnaireService
+	notifier             Notifier
+	reminderChannel      string
+}
+
+func NewRescreeningService(
+	mentalHealthRepo repositories.MentalHealthRepository,
+	questionnaireService *QuestionnaireService,
+	notifier Notifier,
+	reminderChannel string,
+) *RescreeningService {
+	return &RescreeningService{
+		mentalHealthRepo:     mentalHealthRepo,
+		questionnaireService: questionnaireService,
+		notifier:             notifier,
+		reminderChannel:      reminderChannel,
+	}
+}
+
+// GetDueAssessments returns the user's rescreening schedule, soonest first. Only
+// instruments the user has taken before are scheduled. Unless includeUpcoming is
+// set, only assessments that are due now are returned.
+func (s *RescreeningService) GetDueAssessments(ctx context.Context, userID string, includeUpcoming bool) ([]entities.DueAssessment, error) {
+	results, err := s.mentalHealthRepo.FindLatestResults(ctx, userID, rescreenedTests)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	assessments := make([]entities.DueAssessment, 0, len(results))
+	for _, result := range results {
+		due := dueAssessment(result, now)
+		if !due.Due && !includeUpcoming {
+			continue
+		}
+		if test, err := s.questionnaireService.GetDefinition(ctx, result.TestName, 0); err == nil {
+			due.DisplayName = test.DisplayName
+		}
+		assessments = append(assessments, due)
+	}
+
+	sort.Slice(assessments, func(i, j int) bool { return assessments[i].DueAt.Before(assessments[j].DueAt) })
+
+	return assessments, nil
+}
+
+// NotifyDue sends one reminder for each latest result whose rescreening has come
+// due and returns the number sent. Results that fail to notify are retried on the
+// next run.
+func (s *RescreeningService) NotifyDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	// No interval is shorter than the critical one, so newer results cannot be due
+	results, err := s.mentalHealthRepo.FindLatestResultsPendingReminder(ctx, rescreenedTests,
+		now.AddDate(0, 0, -criticalRescreenIntervalDays))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, result := range results {
+		due := dueAssessment(result, now)
+		if !due.Due {
+			continue
+		}
+
+		notification := entities.Notification{
+			Channel: s.reminderChannel,
+			Subject: fmt.Sprintf("Time to retake %s", result.TestName),
+			Body:    fmt.Sprintf("It has been %d days since your last %s. Retaking it helps track how you are doing.", due.IntervalDays+due.DaysOverdue, result.TestName),
+			Data: map[string]string{
+				"userId":       result.UserID.Hex(),
+				"test":         result.TestName,
+				"lastResultId": result.ID.Hex(),
+				"dueAt":        due.DueAt.Format(time.RFC3339),
+			},
+		}
+		if err := s.notifier.Notify(ctx, notification); err != nil {
+			log.Printf("Failed to send rescreening reminder for result %s: %v", result.ID.Hex(), err)
+			continue
+		}
+		if err := s.mentalHealthRepo.MarkRescreenNotified(ctx, result.ID, now); err != nil {
+			log.Printf("Failed to mark rescreening reminder for result %s: %v", result.ID.Hex(), err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// Run checks for due rescreenings at the given interval until the context is done
+func (s *RescreeningService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if sent, err := s.NotifyDue(ctx); err != nil {
+			log.Printf("Failed to check due rescreenings: %v", err)
+		} else if sent > 0 {
+			log.Printf("Sent %d rescreening reminders", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func dueAssessment(result *entities.TestResult, now time.Time) entities.DueAssessment {
+	interval, ok := rescreenIntervalDays[result.AlertLevel]
+	if !ok {
+		interval = defaultRescreenIntervalDays
+	}
+	if result.Critical {
+		interval = criticalRescreenIntervalDays
+	}
+
+	dueAt := result.TestDate.AddDate(0, 0, interval)
+	due := entities.DueAssessment{
+		TestName:     result.TestName,
+		LastResultID: result.ID,
+		LastTakenAt:  result.TestDate,
+		LastLevel:    result.Level,
+		IntervalDays: interval,
+		DueAt:        dueAt,
+		Due:          !now.Before(dueAt),
+	}
+	if due.Due {
+		due.DaysOverdue = int(now.Sub(dueAt).Hours() / 24)
+	}
+	return due
+}
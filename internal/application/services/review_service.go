@@ -0,0 +1,440 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrReviewNotFound        = errors.New("review not found")
+	ErrReviewBookingNotFound = errors.New("booking not found")
+	ErrBookingNotReviewable  = errors.New("reviews can only be left for completed bookings")
+	ErrAlreadyReviewed       = errors.New("this booking has already been reviewed")
+	ErrNotModerator          = errors.New("only moderators can moderate reviews")
+	ErrReviewStatusChanged   = errors.New("review status changed, reload and try again")
+	ErrReviewNotPublished    = errors.New("you can respond once the review is published")
+	ErrRatingNotUpdated      = errors.New("the rating could not be updated")
+)
+
+const (
+	maxReviewText   = 2000
+	maxResponseText = 1000
+	maxReviewTags   = 5
+)
+
+// Tags a review can carry, by target type. Fixed lists keep tags comparable
+// across reviews.
+var reviewTags = map[string][]string{
+	"doctor": {"good_listener", "explains_clearly", "thorough", "empathetic", "on_time", "long_wait", "felt_rushed"},
+	"clinic": {"clean", "friendly_staff", "on_time", "easy_to_find", "good_value", "long_wait"},
+	"lab":    {"painless_collection", "on_time", "quick_report", "clean", "friendly_staff", "long_wait", "delayed_report"},
+}
+
+type ReviewService struct {
+	reviewRepo     repositories.ReviewRepository
+	bookingRepo    repositories.BookingRepository
+	clinicRepo     repositories.ClinicRepository
+	diagnosticRepo repositories.DiagnosticRepository
+	doctorRepo     repositories.DoctorRepository
+	labRepo        repositories.LabRepository
+	userRepo       repositories.UserRepository
+	doctorService  *DoctorService
+}
+
+func NewReviewService(
+	reviewRepo repositories.ReviewRepository,
+	bookingRepo repositories.BookingRepository,
+	clinicRepo repositories.ClinicRepository,
+	diagnosticRepo repositories.DiagnosticRepository,
+	doctorRepo repositories.DoctorRepository,
+	labRepo repositories.LabRepository,
+	userRepo repositories.UserRepository,
+	doctorService *DoctorService,
+) *ReviewService {
+	return &ReviewService{
+		reviewRepo:     reviewRepo,
+		bookingRepo:    bookingRepo,
+		clinicRepo:     clinicRepo,
+		diagnosticRepo: diagnosticRepo,
+		doctorRepo:     doctorRepo,
+		labRepo:        labRepo,
+		userRepo:       userRepo,
+		doctorService:  doctorService,
+	}
+}
+
+// ReviewTags returns the tags reviews can carry, by target type
+func (s *ReviewService) ReviewTags() map[string][]string {
+	return reviewTags
+}
+
+// CreateReview leaves a review for one of the user's completed bookings. The
+// review's TargetType names the kind of booking; the target is the booking's
+// doctor, clinic or lab. Reviews wait for a moderator before they are shown.
+func (s *ReviewService) CreateReview(ctx context.Context, userID, bookingID string, review *entities.Review) (*entities.Review, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	if _, ok := reviewTags[review.TargetType]; !ok {
+		return nil, errors.New("targetType must be doctor, clinic or lab")
+	}
+	if review.Rating < 1 || review.Rating > 5 {
+		return nil, errors.New("rating must be between 1 and 5")
+	}
+	review.Text = strings.TrimSpace(review.Text)
+	if utf8.RuneCountInString(review.Text) > maxReviewText {
+		return nil, fmt.Errorf("the review must be at most %d characters", maxReviewText)
+	}
+	if review.Tags, err = normalizeReviewTags(review.TargetType, review.Tags); err != nil {
+		return nil, err
+	}
+
+	if !primitive.IsValidObjectID(bookingID) {
+		return nil, ErrReviewBookingNotFound
+	}
+	targetID, err := s.reviewedTarget(ctx, review.TargetType, userID, bookingID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userOID)
+	if err != nil {
+		return nil, err
+	}
+
+	review.ID = primitive.NilObjectID
+	review.TargetID = targetID
+	review.BookingID, _ = primitive.ObjectIDFromHex(bookingID)
+	review.UserID = userOID
+	review.ReviewerName = firstName(user.Name)
+	review.Status = "pending"
+	review.ModerationNote = ""
+	review.ModeratedBy = ""
+	review.ModeratedAt = nil
+	review.Response = nil
+
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		if errors.Is(err, domain.ErrDuplicateKey) {
+			return nil, ErrAlreadyReviewed
+		}
+		return nil, err
+	}
+	return review, nil
+}
+
+// GetMyReviews lists the user's reviews in every status, newest first
+func (s *ReviewService) GetMyReviews(ctx context.Context, userID string) ([]*entities.Review, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	reviews, err := s.reviewRepo.FindByUserID(ctx, userOID)
+	if err != nil {
+		return nil, err
+	}
+	if reviews == nil {
+		reviews = []*entities.Review{}
+	}
+	return reviews, nil
+}
+
+// GetReviews returns a page of a doctor's, clinic's or lab's published
+// reviews, newest first, and their total
+func (s *ReviewService) GetReviews(ctx context.Context, targetType, targetID string, page, limit int) ([]*entities.Review, int64, error) {
+	if _, ok := reviewTags[targetType]; !ok {
+		return nil, 0, errors.New("targetType must be doctor, clinic or lab")
+	}
+	targetOID, err := primitive.ObjectIDFromHex(targetID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid %s ID", targetType)
+	}
+	return s.reviewRepo.FindByTarget(ctx, targetType, targetOID, "approved", page, limit)
+}
+
+// DeleteReview removes one of the user's reviews, taking it out of the rating
+// if it was published
+func (s *ReviewService) DeleteReview(ctx context.Context, userID, reviewID string) error {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+	if !primitive.IsValidObjectID(reviewID) {
+		return ErrReviewNotFound
+	}
+	review, err := s.reviewRepo.Delete(ctx, reviewID, userOID)
+	if err != nil {
+		return err
+	}
+	if review == nil {
+		return ErrReviewNotFound
+	}
+	if review.Status == "approved" {
+		if err := s.applyRating(ctx, review, -1); err != nil {
+			return fmt.Errorf("%w: %v", ErrRatingNotUpdated, err)
+		}
+	}
+	return nil
+}
+
+// GetModerationQueue returns a page of reviews with the status, pending by
+// default, oldest first
+func (s *ReviewService) GetModerationQueue(ctx context.Context, userID, status string, page, limit int) ([]*entities.Review, int64, error) {
+	if _, err := s.moderator(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+	if status == "" {
+		status = "pending"
+	}
+	if status != "pending" && status != "approved" && status != "rejected" {
+		return nil, 0, errors.New("status must be pending, approved or rejected")
+	}
+	return s.reviewRepo.FindByStatus(ctx, status, page, limit)
+}
+
+// ModerateReview approves or rejects a review. Approving adds its stars to
+// the target's rating; rejecting a published review takes them out again.
+func (s *ReviewService) ModerateReview(ctx context.Context, userID, reviewID, status, note string) (*entities.Review, error) {
+	moderator, err := s.moderator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if status != "approved" && status != "rejected" {
+		return nil, errors.New("status must be approved or rejected")
+	}
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxResponseText {
+		return nil, fmt.Errorf("the note must be at most %d characters", maxResponseText)
+	}
+
+	review, err := s.findReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status == status {
+		return review, nil
+	}
+
+	updated, err := s.reviewRepo.UpdateStatus(ctx, reviewID, review.Status, status, note, moderator)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrReviewStatusChanged
+	}
+
+	delta := 1
+	if review.Status == "approved" {
+		delta = -1
+	} else if status != "approved" {
+		return updated, nil
+	}
+	if err := s.applyRating(ctx, updated, delta); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRatingNotUpdated, err)
+	}
+	return updated, nil
+}
+
+// GetDoctorReviews returns a page of the published reviews of the doctor
+// profile linked to the user's account
+func (s *ReviewService) GetDoctorReviews(ctx context.Context, userID string, page, limit int) ([]*entities.Review, int64, error) {
+	doctor, err := s.doctorService.DoctorForUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.GetReviews(ctx, "doctor", doctor.ID, page, limit)
+}
+
+// RespondToReview sets the doctor's public reply to a published review of
+// their profile. Empty text removes the reply.
+func (s *ReviewService) RespondToReview(ctx context.Context, userID, reviewID, text string) (*entities.Review, error) {
+	doctor, err := s.doctorService.DoctorForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	review, err := s.findReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.TargetType != "doctor" || review.TargetID.Hex() != doctor.ID {
+		return nil, ErrReviewNotFound
+	}
+	if review.Status != "approved" {
+		return nil, ErrReviewNotPublished
+	}
+
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) > maxResponseText {
+		return nil, fmt.Errorf("the response must be at most %d characters", maxResponseText)
+	}
+	review.Response = nil
+	if text != "" {
+		review.Response = &entities.ReviewResponse{Text: text, RespondedAt: time.Now()}
+	}
+	if err := s.reviewRepo.SetResponse(ctx, reviewID, review.Response); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// reviewedTarget checks that the booking is the user's and completed, and
+// returns the doctor, clinic or lab it was with
+func (s *ReviewService) reviewedTarget(ctx context.Context, targetType, userID, bookingID string, now time.Time) (primitive.ObjectID, error) {
+	switch targetType {
+	case "doctor":
+		booking, err := s.bookingRepo.FindByID(ctx, bookingID)
+		if err != nil {
+			return primitive.NilObjectID, err
+		}
+		if booking == nil || booking.UserID.Hex() != userID {
+			return primitive.NilObjectID, ErrReviewBookingNotFound
+		}
+		if !doctorBookingCompleted(booking, now) {
+			return primitive.NilObjectID, ErrBookingNotReviewable
+		}
+		return booking.DoctorID, nil
+
+	case "clinic":
+		booking, err := s.clinicRepo.FindBookingByID(ctx, bookingID)
+		if err != nil {
+			return primitive.NilObjectID, err
+		}
+		if booking == nil || booking.UserID != userID {
+			return primitive.NilObjectID, ErrReviewBookingNotFound
+		}
+		if !clinicBookingCompleted(booking, now) {
+			return primitive.NilObjectID, ErrBookingNotReviewable
+		}
+		return primitive.ObjectIDFromHex(booking.ClinicID)
+
+	default:
+		booking, err := s.diagnosticRepo.FindBookingByID(ctx, bookingID)
+		if err != nil {
+			return primitive.NilObjectID, err
+		}
+		if booking == nil || booking.UserID.Hex() != userID {
+			return primitive.NilObjectID, ErrReviewBookingNotFound
+		}
+		// Bookings made before labs had their own prices have no lab to review
+		if booking.Status != "completed" || booking.LabID.IsZero() {
+			return primitive.NilObjectID, ErrBookingNotReviewable
+		}
+		return booking.LabID, nil
+	}
+}
+
+// applyRating adds a review's stars to its target's rating or removes them
+func (s *ReviewService) applyRating(ctx context.Context, review *entities.Review, delta int) error {
+	targetID := review.TargetID.Hex()
+	switch review.TargetType {
+	case "doctor":
+		return s.doctorRepo.ApplyReviewRating(ctx, targetID, review.Rating, delta)
+	case "clinic":
+		return s.clinicRepo.ApplyReviewRating(ctx, targetID, review.Rating, delta)
+	case "lab":
+		return s.labRepo.ApplyReviewRating(ctx, targetID, review.Rating, delta)
+	}
+	return fmt.Errorf("unknown review target %q", review.TargetType)
+}
+
+func (s *ReviewService) findReview(ctx context.Context, reviewID string) (*entities.Review, error) {
+	if !primitive.IsValidObjectID(reviewID) {
+		return nil, ErrReviewNotFound
+	}
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+	return review, nil
+}
+
+// moderator returns the email of an account an admin made a moderator, or
+// ErrNotModerator
+func (s *ReviewService) moderator(ctx context.Context, userID string) (string, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return "", fmt.Errorf("invalid user ID format: %w", err)
+	}
+	user, err := s.userRepo.FindByID(ctx, userOID)
+	if err != nil {
+		return "", err
+	}
+	if !user.HasRole(entities.RoleModerator) {
+		return "", ErrNotModerator
+	}
+	return user.Email, nil
+}
+
+// doctorBookingCompleted reports whether a consultation has taken place:
+// marked completed, or paid and its day over. Calls are settled as completed
+// or no_show from attendance, and a no-show is never reviewable.
+func doctorBookingCompleted(booking *entities.Booking, now time.Time) bool {
+	if booking.Status == "completed" {
+		return true
+	}
+	if booking.PaymentStatus != "paid" || (booking.Status != "pending" && booking.Status != "confirmed") {
+		return false
+	}
+	return !now.Before(booking.Date.AddDate(0, 0, 1))
+}
+
+// clinicBookingCompleted reports whether a clinic visit has taken place:
+// marked completed, or confirmed and its day over
+func clinicBookingCompleted(booking *entities.ClinicBooking, now time.Time) bool {
+	if booking.Status == "completed" {
+		return true
+	}
+	if booking.PaymentStatus != "completed" || booking.Status != "confirmed" {
+		return false
+	}
+	return !now.Before(time.Unix(booking.Date, 0).AddDate(0, 0, 1))
+}
+
+// normalizeReviewTags lowercases and de-duplicates tags, keeping the ones
+// listed for the target type
+func normalizeReviewTags(targetType string, tags []string) ([]string, error) {
+	allowed := make(map[string]bool, len(reviewTags[targetType]))
+	for _, tag := range reviewTags[targetType] {
+		allowed[tag] = true
+	}
+
+	seen := make(map[string]bool, len(tags))
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if !allowed[tag] {
+			return nil, fmt.Errorf("unknown tag %q for a %s review; use one of %s", tag, targetType, strings.Join(reviewTags[targetType], ", "))
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxReviewTags {
+		return nil, fmt.Errorf("a review can have at most %d tags", maxReviewTags)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// firstName is how reviewers are shown
+func firstName(name string) string {
+	if fields := strings.Fields(name); len(fields) > 0 {
+		return fields[0]
+	}
+	return "Patient"
+}
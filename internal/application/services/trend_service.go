@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
+)
+
+// trendSpec describes how to read changes in an instrument's score
+type trendSpec struct {
+	mcid          float64 // 0 when no MCID is established
+	lowerIsBetter bool
+}
+
+var trendSpecs = map[string]trendSpec{
+	"phq9": {mcid: 5, lowerIsBetter: true}, // Löwe et al. (2004)
+	"gad7": {mcid: 4, lowerIsBetter: true}, // Toussaint et al. (2020)
+	"epds": {mcid: 4, lowerIsBetter: true}, // Matthey (2004), reliable change
+	"mrs":  {lowerIsBetter: true},
+	"fsfi": {lowerIsBetter: false},
+}
+
+// FSFI diagnoses in order of increasing score
+var fsfiBands = []string{"sexual dysfunction", "normal"}
+
+type TrendService struct {
+	mentalHealthRepo     repositories.MentalHealthRepository
+	fsfiRepo             repositories.FSFIRepository
+	questionnaireService *QuestionnaireService
+}
+
+func NewTrendService(
+	mentalHealthRepo repositories.MentalHealthRepository,
+	fsfiRepo repositories.FSFIRepository,
+	questionnaireService *QuestionnaireService,
+) *TrendService {
+	return &TrendService{
+		mentalHealthRepo:     mentalHealthRepo,
+		fsfiRepo:             fsfiRepo,
+		questionnaireService: questionnaireService,
+	}
+}
+
+// GetTrend returns one page of results for an instrument, oldest first, with the change
+// from the previous result, whether it is clinically significant and whether the user
+// moved severity bands. The total number of results in the date range is also returned.
+func (s *TrendService) GetTrend(ctx context.Context, userID, testName string, from, to *time.Time, page, limit int) (*entities.AssessmentTrend, int64, error) {
+	spec, ok := trendSpecs[testName]
+	if !ok {
+		spec = trendSpec{lowerIsBetter: true}
+	}
+
+	var (
+		points      []entities.TrendPoint
+		hasPrevious bool
+		bands       []string
+		total       int64
+		err         error
+	)
+	if testName == "fsfi" {
+		points, hasPrevious, total, err = s.fsfiPoints(ctx, userID, from, to, page, limit)
+		bands = fsfiBands
+	} else {
+		points, hasPrevious, total, err = s.resultPoints(ctx, userID, testName, from, to, page, limit)
+		if err == nil {
+			bands, err = s.severityBands(ctx, testName)
+		}
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	compareTrendPoints(points, spec, bands)
+	if hasPrevious {
+		// The result before the page is only needed for comparison
+		points = points[1:]
+	}
+	if points == nil {
+		points = []entities.TrendPoint{}
+	}
+
+	trend := &entities.AssessmentTrend{
+		TestName:      testName,
+		LowerIsBetter: spec.lowerIsBetter,
+		Points:        points,
+	}
+	if spec.mcid > 0 {
+		mcid := spec.mcid
+		trend.MCID = &mcid
+	}
+
+	return trend, total, nil
+}
+
+// resultPoints loads a page of results plus the result before the oldest one on the
+// page, and returns them oldest first
+func (s *TrendService) resultPoints(ctx context.Context, userID, testName string, from, to *time.Time, page, limit int) ([]entities.TrendPoint, bool, int64, error) {
+	results, total, err := s.mentalHealthRepo.FindResultsByDateRange(ctx, userID, testName, from, to, page, limit)
+	if err != nil || len(results) == 0 {
+		return nil, false, total, err
+	}
+
+	previous, err := s.mentalHealthRepo.FindPreviousResult(ctx, userID, testName, results[len(results)-1].TestDate)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	if previous != nil {
+		results = append(results, previous)
+	}
+
+	points := make([]entities.TrendPoint, 0, len(results))
+	for i := len(results) - 1; i >= 0; i-- {
+		result := results[i]
+		points = append(points, entities.TrendPoint{
+			ResultID:    result.ID,
+			Date:        result.TestDate,
+			TestVersion: result.TestVersion,
+			Score:       float64(result.ObtainedScore),
+			Level:       result.Level,
+		})
+	}
+	return points, previous != nil, total, nil
+}
+
+func (s *TrendService) fsfiPoints(ctx context.Context, userID string, from, to *time.Time, page, limit int) ([]entities.TrendPoint, bool, int64, error) {
+	results, total, err := s.fsfiRepo.FindByDateRange(ctx, userID, from, to, page, limit)
+	if err != nil || len(results) == 0 {
+		return nil, false, total, err
+	}
+
+	previous, err := s.fsfiRepo.FindPrevious(ctx, userID, results[len(results)-1].SubmittedAt)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	if previous != nil {
+		results = append(results, previous)
+	}
+
+	points := make([]entities.TrendPoint, 0, len(results))
+	for i := len(results) - 1; i >= 0; i-- {
+		result := results[i]
+		points = append(points, entities.TrendPoint{
+			ResultID:    result.ID,
+			Date:        result.SubmittedAt,
+			TestVersion: result.TestVersion,
+			Score:       result.TotalScore,
+			Level:       result.Diagnosis,
+		})
+	}
+	return points, previous != nil, total, nil
+}
+
+// severityBands returns the instrument's severity levels in order of increasing score
+func (s *TrendService) severityBands(ctx context.Context, testName string) ([]string, error) {
+	test, err := s.questionnaireService.GetDefinition(ctx, testName, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	bands := make([]string, len(test.Thresholds))
+	for i, threshold := range test.Thresholds {
+		bands[i] = threshold.Severity
+	}
+	return bands, nil
+}
+
+// compareTrendPoints compares every point with the point before it
+func compareTrendPoints(points []entities.TrendPoint, spec trendSpec, bands []string) {
+	for i := 1; i < len(points); i++ {
+		previous, point := points[i-1], &points[i]
+
+		change := roundTo(point.Score-previous.Score, 2)
+		point.Change = &change
+		point.Direction = changeDirection(change, spec.lowerIsBetter)
+		point.ClinicallySignificant = spec.mcid > 0 && math.Abs(change) >= spec.mcid
+
+		point.PreviousLevel = previous.Level
+		previousRank, rank := bandRank(bands, previous.Level), bandRank(bands, point.Level)
+		if previousRank >= 0 && rank >= 0 && previousRank != rank {
+			point.BandChanged = true
+			point.BandDirection = changeDirection(float64(rank-previousRank), spec.lowerIsBetter)
+		}
+	}
+}
+
+func changeDirection(change float64, lowerIsBetter bool) string {
+	switch {
+	case change == 0:
+		return "unchanged"
+	case (change < 0) == lowerIsBetter:
+		return "improved"
+	default:
+		return "worsened"
+	}
+}
+
+func bandRank(bands []string, level string) int {
+	for i, band := range bands {
+		if band == level {
+			return i
+		}
+	}
+	return -1
+}
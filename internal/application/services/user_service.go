@@ -2,19 +2,28 @@ package services
 
 import (
 	"context"
+	"errors"
+	"io"
+	"strings"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/repositories"
 	"github.com/anshjamwal15/hsb_backend/pkg/auth"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 )
 
+// ErrUnsupportedLanguage is returned when a profile language is not one the API supports
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
 type UserService struct {
 	userRepo repositories.UserRepository
+	media    *MediaService
 }
 
-func NewUserService(userRepo repositories.UserRepository) *UserService {
+func NewUserService(userRepo repositories.UserRepository, media *MediaService) *UserService {
 	return &UserService{
 		userRepo: userRepo,
+		media:    media,
 	}
 }
 
@@ -24,10 +33,18 @@ func (s *UserService) GetProfile(ctx context.Context, userID string) (*entities.
 		return nil, err
 	}
 
-	return s.userRepo.FindByID(ctx, objID)
+	user, err := s.userRepo.FindByID(ctx, objID)
+	if err != nil {
+		return nil, err
+	}
+	s.signProfileImage(ctx, user)
+	return user, nil
 }
 
-func (s *UserService) UpdateProfile(ctx context.Context, userID, name, phoneNumber, profileImage string) (*entities.User, error) {
+// UpdateProfile changes the fields that are set. The preferred language may be
+// a tag such as "hi-IN"; it is stored as the supported locale it reduces to.
+// A profile image must be a key from one of the user's own uploads.
+func (s *UserService) UpdateProfile(ctx context.Context, userID, name, phoneNumber, profileImage, preferredLanguage string) (*entities.User, error) {
 	objID, err := auth.ParseObjectID(userID)
 	if err != nil {
 		return nil, err
@@ -44,13 +61,82 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID, name, phoneNumb
 	if phoneNumber != "" {
 		user.PhoneNumber = phoneNumber
 	}
+	previousImage := user.ProfileImage
 	if profileImage != "" {
+		if !strings.HasPrefix(profileImage, "uploads/"+userID+"/") && !strings.HasPrefix(profileImage, "profiles/"+userID+"/") {
+			return nil, ErrInvalidImageKey
+		}
 		user.ProfileImage = profileImage
 	}
+	if preferredLanguage != "" {
+		locale, ok := i18n.Normalize(preferredLanguage)
+		if !ok {
+			return nil, ErrUnsupportedLanguage
+		}
+		user.PreferredLanguage = locale
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	if user.ProfileImage != previousImage {
+		s.deleteProfileImage(ctx, userID, previousImage)
+	}
+
+	s.signProfileImage(ctx, user)
+	return user, nil
+}
+
+// SetProfileImage uploads a new profile image and removes the one it replaces
+func (s *UserService) SetProfileImage(ctx context.Context, userID string, file io.Reader) (*entities.User, error) {
+	objID, err := auth.ParseObjectID(userID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.FindByID(ctx, objID)
+	if err != nil {
+		return nil, err
+	}
 
+	image, err := s.media.StoreImage(ctx, "profiles/"+userID, file)
+	if err != nil {
+		return nil, err
+	}
+	previousImage := user.ProfileImage
+	user.ProfileImage = image.Key
 	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.media.Delete(ctx, image.Key)
 		return nil, err
 	}
+	s.deleteProfileImage(ctx, userID, previousImage)
 
+	s.signProfileImage(ctx, user)
 	return user, nil
 }
+
+func (s *UserService) signProfileImage(ctx context.Context, user *entities.User) {
+	user.ProfileImageURL, user.ThumbnailURL = s.media.ImageURLs(ctx, user.ProfileImage)
+}
+
+// deleteProfileImage removes a replaced profile image. Images from the
+// general uploads may also be used in posts, so only profile uploads go.
+func (s *UserService) deleteProfileImage(ctx context.Context, userID, key string) {
+	if strings.HasPrefix(key, "profiles/"+userID+"/") {
+		s.media.Delete(ctx, key)
+	}
+}
+
+// PreferredLanguage returns the language chosen in the user's profile, or "" when none is set
+func (s *UserService) PreferredLanguage(ctx context.Context, userID string) (string, error) {
+	objID, err := auth.ParseObjectID(userID)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, objID)
+	if err != nil {
+		return "", err
+	}
+
+	return user.PreferredLanguage, nil
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"log"
 	"os"
 	"strconv"
@@ -20,14 +23,33 @@ type Config struct {
 	RazorpaySecret string
 
 	// Agora
-	AgoraAppID   string
-	AgoraAppCert string
+	AgoraAppID               string
+	AgoraAppCert             string
+	ConsultationCheckMinutes int // how often closed calls are settled
 
 	// Email
 	SMTPHost     string
 	SMTPPort     string
 	SMTPUser     string
 	SMTPPassword string
+
+	// Notifications
+	CareTeamChannel      string
+	ReminderChannel      string
+	RescreenCheckMinutes int
+
+	// Object storage for uploaded images and documents
+	StorageBackend    string // local, s3
+	StorageLocalDir   string
+	StoragePublicURL  string // base of signed URLs served by the local backend
+	StorageSigningKey string
+	StorageURLMinutes int
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PathStyle       bool
 }
 
 func LoadConfig() *Config {
@@ -49,12 +71,53 @@ func LoadConfig() *Config {
 		SMTPPort:       getEnv("SMTP_PORT", "587"),
 		SMTPUser:       getEnv("SMTP_USER", ""),
 		SMTPPassword:   getEnv("SMTP_PASSWORD", ""),
+
+		ConsultationCheckMinutes: getEnvAsInt("CONSULTATION_CHECK_INTERVAL_MINUTES", 5),
+
+		CareTeamChannel:      getEnv("CARE_TEAM_CHANNEL", "care-team"),
+		ReminderChannel:      getEnv("REMINDER_CHANNEL", "assessment-reminders"),
+		RescreenCheckMinutes: getEnvAsInt("RESCREEN_CHECK_INTERVAL_MINUTES", 60),
+
+		StorageBackend:    getEnv("STORAGE_BACKEND", "local"),
+		StorageLocalDir:   getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+		StoragePublicURL:  getEnv("STORAGE_PUBLIC_URL", ""),
+		StorageSigningKey: getEnv("STORAGE_SIGNING_KEY", ""),
+		StorageURLMinutes: getEnvAsInt("STORAGE_URL_EXPIRY_MINUTES", 60),
+		S3Endpoint:        getEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+		S3Region:          getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:          getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:     getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey: getEnv("S3_SECRET_ACCESS_KEY", ""),
+		S3PathStyle:       getEnvAsBool("S3_PATH_STYLE", false),
+	}
+
+	if config.RescreenCheckMinutes <= 0 {
+		config.RescreenCheckMinutes = 60
+	}
+	if config.ConsultationCheckMinutes <= 0 {
+		config.ConsultationCheckMinutes = 5
+	}
+	if config.StorageURLMinutes <= 0 {
+		config.StorageURLMinutes = 60
+	}
+	if config.StoragePublicURL == "" {
+		config.StoragePublicURL = "http://localhost:" + config.Port
 	}
 
 	// Validate required configurations
 	if config.JWTSecret == "default-secret-key" && config.Environment == "production" {
 		log.Fatal("JWT_SECRET must be set in production environment")
 	}
+	if config.StorageSigningKey == "" && config.StorageBackend == "local" && config.Environment == "production" {
+		log.Fatal("STORAGE_SIGNING_KEY must be set in production environment")
+	}
+	// Elsewhere local signed URLs use a key derived from the JWT secret, so
+	// development needs no extra setup and never signs URLs with the secret itself
+	if config.StorageSigningKey == "" {
+		mac := hmac.New(sha256.New, []byte(config.JWTSecret))
+		mac.Write([]byte("storage-url-signing"))
+		config.StorageSigningKey = hex.EncodeToString(mac.Sum(nil))
+	}
 
 	return config
 }
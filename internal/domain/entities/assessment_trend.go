@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AssessmentTrend is a page of a user's results for one instrument, oldest first,
+// each compared with the result taken before it
+type AssessmentTrend struct {
+	TestName      string       `json:"testName"`
+	MCID          *float64     `json:"mcid,omitempty"` // minimal clinically important difference
+	LowerIsBetter bool         `json:"lowerIsBetter"`
+	Points        []TrendPoint `json:"points"`
+}
+
+type TrendPoint struct {
+	ResultID              primitive.ObjectID `json:"resultId"`
+	Date                  time.Time          `json:"date"`
+	TestVersion           int                `json:"testVersion,omitempty"`
+	Score                 float64            `json:"score"`
+	Level                 string             `json:"level,omitempty"`
+	Change                *float64           `json:"change,omitempty"`    // score minus the previous score
+	Direction             string             `json:"direction,omitempty"` // improved, worsened, unchanged
+	ClinicallySignificant bool               `json:"clinicallySignificant"`
+	PreviousLevel         string             `json:"previousLevel,omitempty"`
+	BandChanged           bool               `json:"bandChanged"`
+	BandDirection         string             `json:"bandDirection,omitempty"` // improved, worsened
+}
@@ -11,7 +11,7 @@ type Blog struct {
 	Title       string             `bson:"title" json:"title"`
 	Content     string             `bson:"content" json:"content"`
 	Author      string             `bson:"author" json:"author"`
-	Image       string             `bson:"image,omitempty" json:"image,omitempty"`
+	Image       string             `bson:"image,omitempty" json:"image,omitempty"` // object key
 	Category    string             `bson:"category" json:"category"`
 	Tags        []string           `bson:"tags,omitempty" json:"tags,omitempty"`
 	PublishedAt time.Time          `bson:"publishedAt" json:"publishedAt"`
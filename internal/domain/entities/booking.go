@@ -13,7 +13,8 @@ type Booking struct {
 	SessionType       string             `bson:"sessionType" json:"sessionType"` // Video Call, Audio Call, In-Clinic, Chat
 	Date              time.Time          `bson:"date" json:"date"`
 	TimeSlot          string             `bson:"timeSlot" json:"timeSlot"`
-	Status            string             `bson:"status" json:"status"` // pending, confirmed, completed, cancelled
+	Status            string             `bson:"status" json:"status"`                         // pending, confirmed, completed, cancelled, no_show
+	NoShowBy          string             `bson:"noShowBy,omitempty" json:"noShowBy,omitempty"` // patient, doctor or both, for a no_show call
 	Amount            int                `bson:"amount" json:"amount"`
 	RazorpayOrderID   string             `bson:"razorpayOrderId,omitempty" json:"razorpayOrderId,omitempty"`
 	RazorpayPaymentID string             `bson:"razorpayPaymentId,omitempty" json:"razorpayPaymentId,omitempty"`
@@ -1,28 +1,76 @@
 package entities
 
 type Clinic struct {
-	ID        string   `bson:"_id,omitempty" json:"id,omitempty"`
-	Name      string   `bson:"name" json:"name"`
-	Address   string   `bson:"address" json:"address"`
-	City      string   `bson:"city" json:"city"`
-	Phone     string   `bson:"phone" json:"phone"`
-	Services  []string `bson:"services" json:"services"`
-	Rating    float64  `bson:"rating" json:"rating"`
-	Image     string   `bson:"image,omitempty" json:"image,omitempty"`
-	CreatedAt int64    `bson:"createdAt" json:"createdAt"`
+	ID          string           `bson:"_id,omitempty" json:"id,omitempty"`
+	Name        string           `bson:"name" json:"name"`
+	Address     string           `bson:"address" json:"address"`
+	City        string           `bson:"city" json:"city"`
+	Pincode     string           `bson:"pincode,omitempty" json:"pincode,omitempty"`
+	GeoLocation *GeoPoint        `bson:"geoLocation,omitempty" json:"geoLocation,omitempty"`
+	Phone       string           `bson:"phone" json:"phone"`
+	Services    []string         `bson:"services" json:"services"` // display names; bookings use Catalog
+	Catalog     []ClinicOffering `bson:"catalog,omitempty" json:"catalog,omitempty"`
+	// Opening hours in Indian Standard Time; a weekday may have several ranges
+	OpeningHours []OpeningHours `bson:"openingHours,omitempty" json:"openingHours,omitempty"`
+	Holidays     []string       `bson:"holidays,omitempty" json:"holidays,omitempty"` // YYYY-MM-DD, closed all day
+	Rating       float64        `bson:"rating" json:"rating"`
+	TotalReviews int            `bson:"totalReviews,omitempty" json:"totalReviews"`
+	Image        string         `bson:"image,omitempty" json:"image,omitempty"` // object key, or a URL saved before uploads were managed
+	ImageURL     string         `bson:"-" json:"imageUrl,omitempty"`            // signed URLs, set in responses
+	ThumbnailURL string         `bson:"-" json:"thumbnailUrl,omitempty"`
+	CreatedAt    int64          `bson:"createdAt" json:"createdAt"`
+	DistanceKm   *float64       `bson:"distanceKm,omitempty" json:"distanceKm,omitempty"` // set only in search results near a location
+}
+
+// ClinicOffering is a service a clinic offers, with its price in rupees. Each
+// booking takes one slot of DurationMinutes; DailyCapacity caps the bookings
+// per day, and 0 leaves only the slots to limit them.
+type ClinicOffering struct {
+	Name            string `bson:"name" json:"name"`
+	Price           int    `bson:"price" json:"price"`
+	DurationMinutes int    `bson:"durationMinutes,omitempty" json:"durationMinutes,omitempty"`
+	DailyCapacity   int    `bson:"dailyCapacity,omitempty" json:"dailyCapacity,omitempty"`
+}
+
+// OpeningHours is one opening range on a weekday, with times as "HH:MM"
+type OpeningHours struct {
+	Weekday int    `bson:"weekday" json:"weekday"` // 0 = Sunday
+	Open    string `bson:"open" json:"open"`
+	Close   string `bson:"close" json:"close"`
+}
+
+// ClinicAvailability is the slots of one clinic service on one day
+type ClinicAvailability struct {
+	ClinicID  string       `json:"clinicId"`
+	Service   string       `json:"service"`
+	Date      string       `json:"date"`
+	Open      bool         `json:"open"`
+	Reason    string       `json:"reason,omitempty"` // holiday, closed, fully_booked
+	Capacity  int          `json:"capacity"`
+	Booked    int          `json:"booked"`
+	Remaining int          `json:"remaining"`
+	Slots     []ClinicSlot `json:"slots"`
+}
+
+// ClinicSlot is a start time for a clinic service
+type ClinicSlot struct {
+	Time      string `json:"time"`
+	Available bool   `json:"available"`
 }
 
 type ClinicBooking struct {
-	ID            string `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID        string `bson:"userId" json:"userId"`
-	ClinicID      string `bson:"clinicId" json:"clinicId"`
-	Service       string `bson:"service" json:"service"`
-	Date          int64  `bson:"date" json:"date"`
-	TimeSlot      string `bson:"timeSlot" json:"timeSlot"`
-	Amount        int    `bson:"amount" json:"amount"`
-	PaymentID     string `bson:"paymentId,omitempty" json:"paymentId,omitempty"`
-	PaymentStatus string `bson:"paymentStatus" json:"paymentStatus"` // pending, completed, failed
-	Status        string `bson:"status" json:"status"`               // pending, confirmed, completed, cancelled
-	CreatedAt     int64  `bson:"createdAt" json:"createdAt"`
-	UpdatedAt     int64  `bson:"updatedAt" json:"updatedAt"`
+	ID              string `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID          string `bson:"userId" json:"userId"`
+	ClinicID        string `bson:"clinicId" json:"clinicId"`
+	Service         string `bson:"service" json:"service"`
+	Date            int64  `bson:"date" json:"date"`
+	TimeSlot        string `bson:"timeSlot" json:"timeSlot"`
+	Amount          int    `bson:"amount" json:"amount"`
+	RazorpayOrderID string `bson:"razorpayOrderId,omitempty" json:"razorpayOrderId,omitempty"`
+	PaymentID       string `bson:"paymentId,omitempty" json:"paymentId,omitempty"`
+	PaymentStatus   string `bson:"paymentStatus" json:"paymentStatus"` // pending, completed, failed
+	Status          string `bson:"status" json:"status"`               // pending, confirmed, completed, cancelled, expired (unpaid in time)
+	SlotHold        string `bson:"slotHold,omitempty" json:"-"`        // clinic, service, date and slot, set while the booking holds the slot
+	CreatedAt       int64  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt       int64  `bson:"updatedAt" json:"updatedAt"`
 }
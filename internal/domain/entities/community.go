@@ -23,7 +23,7 @@ type GroupPost struct {
 	GroupID   primitive.ObjectID `bson:"groupId" json:"groupId"`
 	UserID    primitive.ObjectID `bson:"userId" json:"userId"`
 	Content   string             `bson:"content" json:"content"`
-	Images    []string           `bson:"images,omitempty" json:"images,omitempty"`
+	Images    []string           `bson:"images,omitempty" json:"images,omitempty"` // object keys from POST /api/uploads/images
 	Likes     int                `bson:"likes" json:"likes"`
 	Comments  int                `bson:"comments" json:"comments"`
 	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
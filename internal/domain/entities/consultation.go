@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ConsultationEvent records the patient or doctor of a booking joining or
+// leaving its call. A leave carries the time since the matching join.
+type ConsultationEvent struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	BookingID       primitive.ObjectID `bson:"bookingId" json:"bookingId"`
+	UserID          primitive.ObjectID `bson:"userId" json:"userId"`
+	Role            string             `bson:"role" json:"role"` // patient, doctor
+	Type            string             `bson:"type" json:"type"` // joined, left
+	At              time.Time          `bson:"at" json:"at"`
+	DurationSeconds int                `bson:"durationSeconds,omitempty" json:"durationSeconds,omitempty"`
+}
+
+// ConsultationToken lets one participant join the Agora channel of a booking's
+// call until the window closes
+type ConsultationToken struct {
+	AppID     string    `json:"appId"`
+	Channel   string    `json:"channel"`
+	UID       uint32    `json:"uid"`
+	Token     string    `json:"token"`
+	Role      string    `json:"role"`
+	OpensAt   time.Time `json:"opensAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ConsultationAttendance sums up who has been in a booking's call and for how
+// long
+type ConsultationAttendance struct {
+	BookingID      primitive.ObjectID   `json:"bookingId"`
+	Status         string               `json:"status"`
+	NoShowBy       string               `json:"noShowBy,omitempty"`
+	OpensAt        time.Time            `json:"opensAt"`
+	ClosesAt       time.Time            `json:"closesAt"`
+	PatientSeconds int                  `json:"patientSeconds"`
+	DoctorSeconds  int                  `json:"doctorSeconds"`
+	Events         []*ConsultationEvent `json:"events"`
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CrisisEscalation records a critical or high-alert assessment result and the
+// care-team notification sent for it
+type CrisisEscalation struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID        primitive.ObjectID `bson:"userId" json:"userId"`
+	ResultID      primitive.ObjectID `bson:"resultId" json:"resultId"`
+	TestName      string             `bson:"testName" json:"testName"`
+	TestVersion   int                `bson:"testVersion,omitempty" json:"testVersion,omitempty"`
+	Severity      string             `bson:"severity" json:"severity"` // critical, high
+	Reason        string             `bson:"reason" json:"reason"`
+	CriticalItems []string           `bson:"criticalItems,omitempty" json:"criticalItems,omitempty"`
+	Score         int                `bson:"score" json:"score"`
+	Level         string             `bson:"level,omitempty" json:"level,omitempty"`
+	Status        string             `bson:"status" json:"status"` // open, notified, notify_failed
+	Channel       string             `bson:"channel" json:"channel"`
+	NotifiedAt    *time.Time         `bson:"notifiedAt,omitempty" json:"notifiedAt,omitempty"`
+	NotifyError   string             `bson:"notifyError,omitempty" json:"notifyError,omitempty"`
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt     time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+type CrisisHelpline struct {
+	Name         string `json:"name"`
+	Phone        string `json:"phone"`
+	Availability string `json:"availability"`
+	Description  string `json:"description,omitempty"`
+}
+
+// CrisisResponse is returned with a submitted result that needs immediate attention
+type CrisisResponse struct {
+	Severity     string           `json:"severity"`
+	Message      string           `json:"message"`
+	Helplines    []CrisisHelpline `json:"helplines"`
+	EscalationID string           `json:"escalationId,omitempty"`
+}
+
+// Notification is a message sent to a notification channel such as the care team
+type Notification struct {
+	Channel string            `json:"channel"`
+	Subject string            `json:"subject"`
+	Body    string            `json:"body"`
+	Data    map[string]string `json:"data,omitempty"`
+}
@@ -6,9 +6,14 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Diagnostics is a test or package in the shared catalog. TestPrice is the
+// reference price; each lab sets its own price in its offerings.
 type Diagnostics struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
 	TestName     string             `bson:"testName" json:"testName"`
+	Kind         string             `bson:"kind" json:"kind"` // test, package
+	Category     string             `bson:"category,omitempty" json:"category,omitempty"`
+	Tests        []string           `bson:"tests,omitempty" json:"tests,omitempty"` // tests a package includes
 	TestPrice    float64            `bson:"testPrice" json:"testPrice"`
 	TestImage    string             `bson:"testImage,omitempty" json:"testImage,omitempty"`
 	HealthDataID primitive.ObjectID `bson:"healthDataId,omitempty" json:"healthDataId,omitempty"`
@@ -18,35 +23,127 @@ type Diagnostics struct {
 	UpdatedAt    time.Time          `bson:"updatedAt" json:"updatedAt"`
 }
 
+// DiagnosticsUser is a diagnostic lab
 type DiagnosticsUser struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
-	Name        string             `bson:"name" json:"name"`
-	Email       string             `bson:"email" json:"email"`
-	Phone       string             `bson:"phone" json:"phone"`
-	Address     string             `bson:"address" json:"address"`
-	City        string             `bson:"city" json:"city"`
-	Pincode     string             `bson:"pincode" json:"pincode"`
-	State       string             `bson:"state" json:"state"`
-	Description string             `bson:"description,omitempty" json:"description,omitempty"`
-	Timing      Timing             `bson:"timing" json:"timing"`
-	IsApproved  bool               `bson:"isApproved" json:"isApproved"`
-	IsDeleted   bool               `bson:"isDeleted" json:"isDeleted"`
-	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
-	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
+	ID             primitive.ObjectID      `bson:"_id,omitempty" json:"_id"`
+	Name           string                  `bson:"name" json:"name"`
+	Email          string                  `bson:"email" json:"email"`
+	UserID         primitive.ObjectID      `bson:"userId,omitempty" json:"-"` // account that manages the lab, linked when the lab is onboarded
+	Phone          string                  `bson:"phone" json:"phone"`
+	Address        string                  `bson:"address" json:"address"`
+	City           string                  `bson:"city" json:"city"`
+	Pincode        string                  `bson:"pincode" json:"pincode"`
+	State          string                  `bson:"state" json:"state"`
+	GeoLocation    *GeoPoint               `bson:"geoLocation,omitempty" json:"geoLocation,omitempty"`
+	Description    string                  `bson:"description,omitempty" json:"description,omitempty"`
+	Offerings      []LabOffering           `bson:"offerings,omitempty" json:"offerings,omitempty"`
+	HomeCollection *HomeCollectionSettings `bson:"homeCollection,omitempty" json:"homeCollection,omitempty"` // nil when the lab does not collect at home
+	Rating         float64                 `bson:"rating,omitempty" json:"rating,omitempty"`
+	TotalReviews   int                     `bson:"totalReviews,omitempty" json:"totalReviews,omitempty"`
+	Timing         Timing                  `bson:"timing" json:"timing"`
+	IsApproved     bool                    `bson:"isApproved" json:"isApproved"`
+	IsDeleted      bool                    `bson:"isDeleted" json:"isDeleted"`
+	CreatedAt      time.Time               `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time               `bson:"updatedAt" json:"updatedAt"`
+	DistanceKm     *float64                `bson:"distanceKm,omitempty" json:"distanceKm,omitempty"` // set only in search results near a location
 }
 
+// LabOffering is a catalog test or package a lab runs, at the lab's price in
+// rupees. The name is copied from the catalog so labs can be searched by it.
+type LabOffering struct {
+	DiagnosticsID  primitive.ObjectID `bson:"diagnosticsId" json:"diagnosticsId"`
+	TestName       string             `bson:"testName" json:"testName"`
+	Price          float64            `bson:"price" json:"price"`
+	IsAvailable    bool               `bson:"isAvailable" json:"isAvailable"`
+	HomeCollection bool               `bson:"homeCollection" json:"homeCollection"` // sample can be collected at home
+}
+
+// HomeCollectionSettings is where and when a lab collects samples at home. The
+// fee is added to the price of each home collection booking.
+type HomeCollectionSettings struct {
+	Pincodes []string           `bson:"pincodes" json:"pincodes"`
+	Fee      float64            `bson:"fee" json:"fee"`
+	Windows  []CollectionWindow `bson:"windows" json:"windows"`
+}
+
+// CollectionWindow is a daily home collection window in IST, with times as "HH:MM"
+type CollectionWindow struct {
+	Start string `bson:"start" json:"start"`
+	End   string `bson:"end" json:"end"`
+}
+
+// HomeCollectionAvailability says whether a lab collects at a pincode, and
+// which of its windows can still be booked on a day
+type HomeCollectionAvailability struct {
+	LabID       string                 `json:"labId"`
+	Pincode     string                 `json:"pincode"`
+	Date        string                 `json:"date"`
+	Serviceable bool                   `json:"serviceable"`
+	Fee         float64                `json:"fee"`
+	Windows     []CollectionWindowSlot `json:"windows"`
+}
+
+type CollectionWindowSlot struct {
+	Window    string `json:"window"` // "07:00-09:00", passed as the booking's timeSlot
+	Available bool   `json:"available"`
+}
+
+// Phlebotomist is a lab's sample collector
+type Phlebotomist struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	LabID     primitive.ObjectID `bson:"labId" json:"labId"`
+	Name      string             `bson:"name" json:"name"`
+	Phone     string             `bson:"phone" json:"phone"`
+	IsActive  bool               `bson:"isActive" json:"isActive"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// DiagnosticsBooking is a booking of a test or package at a lab. TestName and
+// Amount are copied when booking so later catalog changes do not alter it.
+// Bookings migrated from before labs existed have no LabID.
 type DiagnosticsBooking struct {
 	ID                primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
 	UserID            primitive.ObjectID `bson:"userId" json:"userId"`
+	LabID             primitive.ObjectID `bson:"labId,omitempty" json:"labId,omitempty"`
 	DiagnosticsID     primitive.ObjectID `bson:"diagnosticsId" json:"diagnosticsId"`
+	TestName          string             `bson:"testName,omitempty" json:"testName,omitempty"`
 	Date              time.Time          `bson:"date" json:"date"`
 	Time              string             `bson:"time" json:"time"`
 	Notes             string             `bson:"notes,omitempty" json:"notes,omitempty"`
-	Status            string             `bson:"status" json:"status"`
+	CollectionType    string             `bson:"collectionType,omitempty" json:"collectionType,omitempty"` // lab (default), home
+	HomeCollection    *HomeCollection    `bson:"homeCollection,omitempty" json:"homeCollection,omitempty"`
+	CollectionFee     float64            `bson:"collectionFee,omitempty" json:"collectionFee,omitempty"` // included in Amount
+	Status            string             `bson:"status" json:"status"`                                   // pending, confirmed, completed, cancelled
 	Amount            float64            `bson:"amount" json:"amount"`
 	RazorpayOrderID   string             `bson:"razorpayOrderId,omitempty" json:"razorpayOrderId,omitempty"`
 	RazorpayPaymentID string             `bson:"razorpayPaymentId,omitempty" json:"razorpayPaymentId,omitempty"`
-	PaymentStatus     string             `bson:"paymentStatus" json:"paymentStatus"`
+	PaymentStatus     string             `bson:"paymentStatus" json:"paymentStatus"` // pending, completed, failed
 	CreatedAt         time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt         time.Time          `bson:"updatedAt" json:"updatedAt"`
 }
+
+// HomeCollection is the visit to collect a booking's sample at home
+type HomeCollection struct {
+	Address        ServiceAddress           `bson:"address" json:"address"`
+	WindowStart    time.Time                `bson:"windowStart" json:"windowStart"`
+	WindowEnd      time.Time                `bson:"windowEnd" json:"windowEnd"`
+	PhlebotomistID primitive.ObjectID       `bson:"phlebotomistId,omitempty" json:"phlebotomistId,omitempty"`
+	Status         string                   `bson:"status" json:"status"` // scheduled, en_route, collected, in_lab, report_ready
+	History        []CollectionStatusChange `bson:"history" json:"history"`
+}
+
+type ServiceAddress struct {
+	Line1    string `bson:"line1" json:"line1"`
+	Line2    string `bson:"line2,omitempty" json:"line2,omitempty"`
+	Landmark string `bson:"landmark,omitempty" json:"landmark,omitempty"`
+	City     string `bson:"city" json:"city"`
+	Pincode  string `bson:"pincode" json:"pincode"`
+	Phone    string `bson:"phone" json:"phone"`
+}
+
+type CollectionStatusChange struct {
+	Status string    `bson:"status" json:"status"`
+	Note   string    `bson:"note,omitempty" json:"note,omitempty"`
+	At     time.Time `bson:"at" json:"at"`
+}
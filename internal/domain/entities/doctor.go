@@ -2,20 +2,28 @@ package entities
 
 import (
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Doctor struct {
 	ID               string             `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID           primitive.ObjectID `bson:"userId,omitempty" json:"-"` // account of the doctor, linked when the doctor is onboarded
 	Name             string             `bson:"name" json:"name"`
 	Experience       string             `bson:"experience,omitempty" json:"experience,omitempty"`
 	Qualifications   string             `bson:"qualifications,omitempty" json:"qualifications,omitempty"`
-	Image            string             `bson:"image,omitempty" json:"image,omitempty"`
+	Image            string             `bson:"image,omitempty" json:"image,omitempty"` // object key, or a URL saved before uploads were managed
+	ImageURL         string             `bson:"-" json:"imageUrl,omitempty"`            // signed URLs, set in responses
+	ThumbnailURL     string             `bson:"-" json:"thumbnailUrl,omitempty"`
 	Specialization   string             `bson:"specialization" json:"specialization"`
 	Bio              string             `bson:"bio,omitempty" json:"bio,omitempty"`
 	About            string             `bson:"about,omitempty" json:"about,omitempty"`
 	Rating           *float64           `bson:"rating,omitempty" json:"rating,omitempty"`
 	TotalReviews     *int               `bson:"totalReviews,omitempty" json:"totalReviews,omitempty"`
 	Location         string             `bson:"location,omitempty" json:"location,omitempty"`
+	City             string             `bson:"city,omitempty" json:"city,omitempty"` // where in-clinic consultations take place
+	Pincode          string             `bson:"pincode,omitempty" json:"pincode,omitempty"`
+	GeoLocation      *GeoPoint          `bson:"geoLocation,omitempty" json:"geoLocation,omitempty"`
 	Phone            string             `bson:"phone,omitempty" json:"phone,omitempty"`
 	Email            string             `bson:"email" json:"email"`
 	Languages        []string           `bson:"languages,omitempty" json:"languages,omitempty"`
@@ -27,6 +35,7 @@ type Doctor struct {
 	IsDeleted        *bool              `bson:"isDeleted,omitempty" json:"isDeleted,omitempty"`
 	CreatedAt        time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt        time.Time          `bson:"updatedAt" json:"updatedAt"`
+	DistanceKm       *float64           `bson:"distanceKm,omitempty" json:"distanceKm,omitempty"` // set only in search results near a location
 }
 
 type ConsultationFees struct {
@@ -0,0 +1,48 @@
+package entities
+
+import "time"
+
+// Session types a doctor offers when the matching consultation fee is set
+const (
+	SessionVideoCall = "Video Call"
+	SessionAudioCall = "Audio Call"
+	SessionInClinic  = "In-Clinic"
+)
+
+// DoctorQuery filters and orders a doctor search. Empty fields filter nothing.
+// The fee range applies to SessionType's fee when it is set, otherwise to any
+// session type the doctor offers.
+type DoctorQuery struct {
+	Search         string     // part of the name or specialization, ignoring case
+	Specialization string     // exact, ignoring case
+	Language       string     // one of the doctor's languages, ignoring case
+	SessionType    string     // Video Call, Audio Call, In-Clinic
+	MinFee         int        // rupees
+	MaxFee         int        // rupees
+	AvailableOn    *time.Time // a day, at midnight UTC, with a slot not yet booked
+	MinRating      float64
+	SortBy         string // rating (the default), fee, experience
+	Page           int
+	Limit          int
+}
+
+// DoctorSearchResult is one page of a doctor search, the total number of
+// matches and facet counts over all of them
+type DoctorSearchResult struct {
+	Doctors []*Doctor
+	Total   int64
+	Facets  DoctorFacets
+}
+
+// DoctorFacets counts the matching doctors by each filterable value, most
+// common first
+type DoctorFacets struct {
+	Specializations []FacetCount `bson:"specializations" json:"specializations"`
+	Languages       []FacetCount `bson:"languages" json:"languages"`
+	SessionTypes    []FacetCount `bson:"sessionTypes" json:"sessionTypes"`
+}
+
+type FacetCount struct {
+	Value string `bson:"_id" json:"value"`
+	Count int64  `bson:"count" json:"count"`
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FertilityLog is a structured fertility observation: basal body temperature,
+// LH (ovulation) test, cervical mucus or intercourse
+type FertilityLog struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID primitive.ObjectID `bson:"userId" json:"userId"`
+	Type   string             `bson:"type" json:"type"` // bbt, lh_test, cervical_mucus, intercourse
+	Date   time.Time          `bson:"date" json:"date"`
+
+	// Basal body temperature, stored in Celsius
+	Temperature     float64 `bson:"temperature,omitempty" json:"temperature,omitempty"`
+	TemperatureUnit string  `bson:"temperatureUnit,omitempty" json:"temperatureUnit,omitempty"` // C, F
+
+	// LH test
+	LHResult  string   `bson:"lhResult,omitempty" json:"lhResult,omitempty"`   // negative, positive, peak
+	LHReading *float64 `bson:"lhReading,omitempty" json:"lhReading,omitempty"` // strip reading (test/control line ratio or mIU/mL)
+
+	// Cervical mucus
+	CervicalMucus string `bson:"cervicalMucus,omitempty" json:"cervicalMucus,omitempty"` // dry, sticky, creamy, watery, egg_white
+
+	// Intercourse
+	Protected *bool `bson:"protected,omitempty" json:"protected,omitempty"`
+
+	Notes     string    `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+type TemperaturePoint struct {
+	Date           time.Time `json:"date"`
+	Temperature    float64   `json:"temperature"`
+	AboveCoverline bool      `json:"aboveCoverline"`
+}
+
+// OvulationStatus is the fertility analysis of the current cycle
+type OvulationStatus struct {
+	CycleStart          time.Time          `json:"cycleStart"`
+	Confirmed           bool               `json:"confirmed"`
+	OvulationDate       *time.Time         `json:"ovulationDate,omitempty"`
+	ConfirmedOn         *time.Time         `json:"confirmedOn,omitempty"`
+	Coverline           *float64           `json:"coverline,omitempty"`
+	LHSurgeDate         *time.Time         `json:"lhSurgeDate,omitempty"`
+	PeakMucusDate       *time.Time         `json:"peakMucusDate,omitempty"`
+	HighTemperatureDays int                `json:"highTemperatureDays"`
+	Temperatures        []TemperaturePoint `json:"temperatures"`
+	Notes               []string           `json:"notes"`
+}
@@ -7,15 +7,17 @@ import (
 )
 
 type FSFIResult struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
-	UserID       primitive.ObjectID `bson:"user" json:"user"`
-	DomainScores DomainScores       `bson:"domainScores" json:"domainScores"`
-	Responses    []interface{}      `bson:"responses" json:"responses"`
-	TotalScore   float64            `bson:"totalScore" json:"totalScore"`
-	Diagnosis    string             `bson:"diagnosis" json:"diagnosis"`
-	SubmittedAt  time.Time          `bson:"submittedAt" json:"submittedAt"`
-	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
-	UpdatedAt    time.Time          `bson:"updatedAt" json:"updatedAt"`
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID         primitive.ObjectID `bson:"user" json:"user"`
+	TestVersion    int                `bson:"testVersion" json:"testVersion"`
+	DomainScores   DomainScores       `bson:"domainScores" json:"domainScores"`
+	Responses      []interface{}      `bson:"responses" json:"responses"` // answers to items 1-19 in order
+	TotalScore     float64            `bson:"totalScore" json:"totalScore"`
+	Diagnosis      string             `bson:"diagnosis" json:"diagnosis"`
+	Recommendation string             `bson:"recommendation,omitempty" json:"recommendation,omitempty"`
+	SubmittedAt    time.Time          `bson:"submittedAt" json:"submittedAt"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
 }
 
 type DomainScores struct {
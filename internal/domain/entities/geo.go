@@ -0,0 +1,27 @@
+package entities
+
+// GeoPoint is a GeoJSON point. Coordinates are [longitude, latitude], the order
+// MongoDB's 2dsphere indexes expect.
+type GeoPoint struct {
+	Type        string    `bson:"type" json:"type"`
+	Coordinates []float64 `bson:"coordinates" json:"coordinates"`
+}
+
+func NewGeoPoint(lat, lng float64) *GeoPoint {
+	return &GeoPoint{Type: "Point", Coordinates: []float64{lng, lat}}
+}
+
+// GeoQuery filters a discovery search. With Near set, results within RadiusKm
+// are returned nearest first unless SortBy is "rating"; otherwise they are
+// ordered by rating.
+type GeoQuery struct {
+	Near      *GeoPoint
+	RadiusKm  float64
+	City      string
+	Pincode   string
+	Service   string
+	MinRating float64
+	SortBy    string // distance, rating
+	Page      int
+	Limit     int
+}
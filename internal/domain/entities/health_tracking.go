@@ -24,6 +24,9 @@ type PregnancyTracker struct {
 	LastPeriodDate time.Time          `bson:"lastPeriodDate" json:"lastPeriodDate"`
 	Weight         float64            `bson:"weight,omitempty" json:"weight,omitempty"`
 	Notes          string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	Status         string             `bson:"status,omitempty" json:"status,omitempty"` // active, postpartum
+	DeliveryDate   *time.Time         `bson:"deliveryDate,omitempty" json:"deliveryDate,omitempty"`
+	DeliveryType   string             `bson:"deliveryType,omitempty" json:"deliveryType,omitempty"` // vaginal, cesarean
 	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
 }
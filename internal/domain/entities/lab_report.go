@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LabReport is the report a lab delivers for a diagnostics booking: the PDF
+// and, optionally, the analyte values read from it. A booking has at most one
+// report; uploading again replaces it.
+type LabReport struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	BookingID     primitive.ObjectID `bson:"bookingId" json:"bookingId"`
+	UserID        primitive.ObjectID `bson:"userId" json:"userId"`
+	LabID         primitive.ObjectID `bson:"labId" json:"labId"`
+	DiagnosticsID primitive.ObjectID `bson:"diagnosticsId" json:"diagnosticsId"`
+	TestName      string             `bson:"testName" json:"testName"`
+	SampleDate    time.Time          `bson:"sampleDate" json:"sampleDate"` // the booked date; trends are plotted against it
+	FileKey       string             `bson:"fileKey" json:"-"`
+	FileName      string             `bson:"fileName" json:"fileName"`
+	FileSize      int64              `bson:"fileSize" json:"fileSize"`
+	FileURL       string             `bson:"-" json:"fileUrl,omitempty"` // signed, expiring link to the PDF
+	Analytes      []AnalyteResult    `bson:"analytes" json:"analytes"`
+	AbnormalCount int                `bson:"abnormalCount" json:"abnormalCount"` // analytes flagged low or high
+	Notes         string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt     time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// AnalyteResult is one measured value with the lab's reference range. Ranges
+// depend on the lab's method and, for hormones, the cycle phase, so they come
+// from the lab rather than a fixed table.
+type AnalyteResult struct {
+	Code    string   `bson:"code" json:"code"` // LH, FSH, AMH, TSH, TESTOSTERONE, HBA1C or the lab's own
+	Name    string   `bson:"name" json:"name"`
+	Value   float64  `bson:"value" json:"value"`
+	Unit    string   `bson:"unit" json:"unit"`
+	RefLow  *float64 `bson:"refLow,omitempty" json:"refLow,omitempty"`
+	RefHigh *float64 `bson:"refHigh,omitempty" json:"refHigh,omitempty"`
+	RefText string   `bson:"refText,omitempty" json:"refText,omitempty"` // the range as printed, e.g. by cycle phase
+	Flag    string   `bson:"flag,omitempty" json:"flag,omitempty"`       // low, high, normal; empty without a range
+}
+
+// AnalyteTrend is one analyte across a user's reports, oldest first
+type AnalyteTrend struct {
+	Code   string              `json:"code"`
+	Name   string              `json:"name"`
+	Points []AnalyteTrendPoint `json:"points"`
+}
+
+type AnalyteTrendPoint struct {
+	ReportID  primitive.ObjectID `bson:"reportId" json:"reportId"`
+	BookingID primitive.ObjectID `bson:"bookingId" json:"bookingId"`
+	TestName  string             `bson:"testName" json:"testName"`
+	Date      time.Time          `bson:"date" json:"date"`
+	Name      string             `bson:"name" json:"-"`
+	Value     float64            `bson:"value" json:"value"`
+	Unit      string             `bson:"unit" json:"unit"` // labs may report in different units
+	RefLow    *float64           `bson:"refLow,omitempty" json:"refLow,omitempty"`
+	RefHigh   *float64           `bson:"refHigh,omitempty" json:"refHigh,omitempty"`
+	Flag      string             `bson:"flag,omitempty" json:"flag,omitempty"`
+}
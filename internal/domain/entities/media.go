@@ -0,0 +1,12 @@
+package entities
+
+// StoredObject is an uploaded file. Entities store the key; the URLs are
+// signed and expire, so they are made fresh for each response.
+type StoredObject struct {
+	Key          string `json:"key"`
+	ContentType  string `json:"contentType"`
+	Size         int64  `json:"size"`
+	URL          string `json:"url"`
+	ThumbnailKey string `json:"thumbnailKey,omitempty"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+}
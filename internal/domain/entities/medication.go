@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MedicationRegimen is a medication with its dose schedule. Pill packs cycle through
+// ActiveDays of active pills followed by BreakDays of placebo pills or no pills.
+type MedicationRegimen struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID       primitive.ObjectID `bson:"userId" json:"userId"`
+	Name         string             `bson:"name" json:"name"`
+	Category     string             `bson:"category" json:"category"` // birth_control, pcos, prenatal, other
+	Dosage       string             `bson:"dosage,omitempty" json:"dosage,omitempty"`
+	ScheduleType string             `bson:"scheduleType" json:"scheduleType"` // daily, pill_pack, custom
+	Times        []string           `bson:"times" json:"times"`               // HH:MM in Timezone
+	Timezone     string             `bson:"timezone" json:"timezone"`
+	StartDate    time.Time          `bson:"startDate" json:"startDate"`
+	EndDate      *time.Time         `bson:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// Pill pack
+	ActiveDays    int        `bson:"activeDays,omitempty" json:"activeDays,omitempty"` // e.g. 21
+	BreakDays     int        `bson:"breakDays,omitempty" json:"breakDays,omitempty"`   // e.g. 7
+	BreakType     string     `bson:"breakType,omitempty" json:"breakType,omitempty"`   // placebo, pill_free
+	PackStartDate *time.Time `bson:"packStartDate,omitempty" json:"packStartDate,omitempty"`
+
+	// Custom
+	DaysOfWeek   []int `bson:"daysOfWeek,omitempty" json:"daysOfWeek,omitempty"`     // 0 = Sunday
+	IntervalDays int   `bson:"intervalDays,omitempty" json:"intervalDays,omitempty"` // every N days from StartDate
+
+	IsActive  bool      `bson:"isActive" json:"isActive"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+type DoseLog struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID      primitive.ObjectID `bson:"userId" json:"userId"`
+	RegimenID   primitive.ObjectID `bson:"regimenId" json:"regimenId"`
+	ScheduledAt time.Time          `bson:"scheduledAt" json:"scheduledAt"`
+	Status      string             `bson:"status" json:"status"` // taken, missed, skipped
+	TakenAt     *time.Time         `bson:"takenAt,omitempty" json:"takenAt,omitempty"`
+	IsPlacebo   bool               `bson:"isPlacebo,omitempty" json:"isPlacebo,omitempty"`
+	Notes       string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ScheduledDose is a single dose occurrence computed from a regimen's schedule
+type ScheduledDose struct {
+	RegimenID   primitive.ObjectID `json:"regimenId"`
+	UserID      primitive.ObjectID `json:"userId"`
+	Name        string             `json:"name"`
+	Dosage      string             `json:"dosage,omitempty"`
+	ScheduledAt time.Time          `json:"scheduledAt"`
+	IsPlacebo   bool               `json:"isPlacebo"`
+	PackDay     int                `json:"packDay,omitempty"`
+	Status      string             `json:"status"` // due, overdue, taken, missed, skipped
+}
+
+type AdherenceStats struct {
+	RegimenID     primitive.ObjectID `json:"regimenId"`
+	From          time.Time          `json:"from"`
+	To            time.Time          `json:"to"`
+	Scheduled     int                `json:"scheduled"`
+	Taken         int                `json:"taken"`
+	Missed        int                `json:"missed"`
+	Skipped       int                `json:"skipped"`
+	Unlogged      int                `json:"unlogged"`
+	AdherenceRate float64            `json:"adherenceRate"` // taken / scheduled, placebo pills excluded
+	CurrentStreak int                `json:"currentStreak"`
+}
+
+// PillPackStatus describes where a user is in their pill pack
+type PillPackStatus struct {
+	RegimenID      primitive.ObjectID `json:"regimenId"`
+	PackDay        int                `json:"packDay"`
+	InBreak        bool               `json:"inBreak"`
+	BreakType      string             `json:"breakType"`
+	NextBreakStart time.Time          `json:"nextBreakStart"`
+	NextPackStart  time.Time          `json:"nextPackStart"`
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VasomotorLog is a single hot flash or night sweat
+type VasomotorLog struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID          primitive.ObjectID `bson:"userId" json:"userId"`
+	Type            string             `bson:"type" json:"type"` // hot_flash, night_sweat
+	OccurredAt      time.Time          `bson:"occurredAt" json:"occurredAt"`
+	Severity        string             `bson:"severity" json:"severity"` // mild, moderate, severe
+	DurationMinutes int                `bson:"durationMinutes,omitempty" json:"durationMinutes,omitempty"`
+	Triggers        []string           `bson:"triggers,omitempty" json:"triggers,omitempty"`
+	Notes           string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedAt       time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt       time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+type MenopauseStatus struct {
+	Mode                  string      `json:"mode"`
+	LastPeriodDate        *time.Time  `json:"lastPeriodDate,omitempty"`
+	MonthsSinceLastPeriod int         `json:"monthsSinceLastPeriod"`
+	MenopauseReached      bool        `json:"menopauseReached"`
+	MenopauseDate         *time.Time  `json:"menopauseDate,omitempty"`
+	HotFlashesLast7Days   int         `json:"hotFlashesLast7Days"`
+	NightSweatsLast7Days  int         `json:"nightSweatsLast7Days"`
+	LatestMRS             *TestResult `json:"latestMrs,omitempty"`
+	Notes                 []string    `json:"notes"`
+}
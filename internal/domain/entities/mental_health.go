@@ -6,23 +6,33 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// MentalHealthTest is a versioned questionnaire definition. Each version is stored
+// as its own document; results record the version they were scored against.
 type MentalHealthTest struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
 	TestName      string             `bson:"testName" json:"testName"`
 	Name          string             `bson:"name" json:"name"`
+	Version       int                `bson:"version" json:"version"`
+	Category      string             `bson:"category" json:"category"` // mental_health, menopause, sexual_health, pcos
 	DisplayName   string             `bson:"displayName,omitempty" json:"displayName,omitempty"`
 	Description   string             `bson:"description,omitempty" json:"description,omitempty"`
 	Questions     []TestQuestion     `bson:"questions,omitempty" json:"questions,omitempty"`
 	Sections      []TestSection      `bson:"sections,omitempty" json:"sections,omitempty"`
 	Thresholds    []TestThreshold    `bson:"thresholds" json:"thresholds"`
+	Subscales     []TestSubscale     `bson:"subscales,omitempty" json:"subscales,omitempty"`
 	CriticalItems []interface{}      `bson:"criticalItems,omitempty" json:"criticalItems,omitempty"`
 	CriticalNote  string             `bson:"criticalNote,omitempty" json:"criticalNote,omitempty"`
 	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt     time.Time          `bson:"updatedAt" json:"updatedAt"`
+	// Set when the definition is served translated: the locale of the text and
+	// the translation keys that fell back to English
+	Locale              string   `bson:"-" json:"locale,omitempty"`
+	MissingTranslations []string `bson:"-" json:"missingTranslations,omitempty"`
 }
 
 type TestQuestion struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	Key           string             `bson:"key,omitempty" json:"key,omitempty"` // answer key, e.g. "q1"
 	QuestionText  string             `bson:"questionText" json:"questionText"`
 	ResponseType  string             `bson:"responseType" json:"responseType"` // mcq, slider, binary
 	Options       []QuestionOption   `bson:"options,omitempty" json:"options,omitempty"`
@@ -56,25 +66,43 @@ type TestThreshold struct {
 	AlertLevel     string `bson:"alertLevel" json:"alertLevel"`
 }
 
+// TestSubscale groups question keys whose answers are summed into a sub-score.
+// Factor, when set, weights the sum (e.g. FSFI domain factors).
+type TestSubscale struct {
+	Name   string   `bson:"name" json:"name"`
+	Keys   []string `bson:"keys" json:"keys"`
+	Factor float64  `bson:"factor,omitempty" json:"factor,omitempty"`
+}
+
 type Question struct {
-	ID       string   `bson:"id" json:"id"`
-	Text     string   `bson:"text" json:"text"`
-	Type     string   `bson:"type" json:"type"`
-	Options  []string `bson:"options,omitempty" json:"options,omitempty"`
+	ID      string   `bson:"id" json:"id"`
+	Text    string   `bson:"text" json:"text"`
+	Type    string   `bson:"type" json:"type"`
+	Options []string `bson:"options,omitempty" json:"options,omitempty"`
 }
 
 type TestResult struct {
-	ID             primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
-	UserID         primitive.ObjectID `bson:"userId" json:"userId"`
-	TestName       string             `bson:"testName" json:"testName"`
-	TotalScore     int                `bson:"totalScore" json:"totalScore"`
-	ObtainedScore  int                `bson:"obtainedScore" json:"obtainedScore"`
-	Score          int                `bson:"score,omitempty" json:"score,omitempty"`
-	Level          string             `bson:"level,omitempty" json:"level,omitempty"`
-	Answers       map[string]interface{} `bson:"answers,omitempty" json:"answers,omitempty"`
-	Recommendation string             `bson:"recommendation,omitempty" json:"recommendation,omitempty"`
-	TestDate       time.Time          `bson:"testDate" json:"testDate"`
-	Notes          string             `bson:"notes,omitempty" json:"notes,omitempty"`
-	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
-	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
+	ID             primitive.ObjectID     `bson:"_id,omitempty" json:"_id"`
+	UserID         primitive.ObjectID     `bson:"userId" json:"userId"`
+	TestName       string                 `bson:"testName" json:"testName"`
+	TestVersion    int                    `bson:"testVersion,omitempty" json:"testVersion,omitempty"`
+	TotalScore     int                    `bson:"totalScore" json:"totalScore"`
+	ObtainedScore  int                    `bson:"obtainedScore" json:"obtainedScore"`
+	Score          int                    `bson:"score,omitempty" json:"score,omitempty"`
+	Level          string                 `bson:"level,omitempty" json:"level,omitempty"`
+	SubScores      map[string]int         `bson:"subScores,omitempty" json:"subScores,omitempty"`
+	Answers        map[string]interface{} `bson:"answers,omitempty" json:"answers,omitempty"`
+	Recommendation string                 `bson:"recommendation,omitempty" json:"recommendation,omitempty"`
+	AlertLevel     string                 `bson:"alertLevel,omitempty" json:"alertLevel,omitempty"`
+	Critical       bool                   `bson:"critical,omitempty" json:"critical,omitempty"`
+	CriticalNote   string                 `bson:"criticalNote,omitempty" json:"criticalNote,omitempty"`
+	TestDate       time.Time              `bson:"testDate" json:"testDate"`
+	Notes          string                 `bson:"notes,omitempty" json:"notes,omitempty"`
+	// Locale the questions were shown in. Answers are stored as option values and
+	// the level and recommendation in English, so scores do not depend on it.
+	Locale string `bson:"locale,omitempty" json:"locale,omitempty"`
+	// Set once a rescreening reminder has been sent for this result
+	RescreenNotifiedAt *time.Time `bson:"rescreenNotifiedAt,omitempty" json:"rescreenNotifiedAt,omitempty"`
+	CreatedAt          time.Time  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt          time.Time  `bson:"updatedAt" json:"updatedAt"`
 }
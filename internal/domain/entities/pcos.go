@@ -6,12 +6,111 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Rotterdam criterion statuses
+const (
+	CriterionMet      = "met"
+	CriterionNotMet   = "not_met"
+	CriterionPossible = "possible"
+	CriterionUnknown  = "unknown"
+)
+
+// PCOSAssessment is a Rotterdam-criteria assessment. Responses holds the
+// optional symptom screening answers, in question order.
 type PCOSAssessment struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
-	UserID    primitive.ObjectID `bson:"userId" json:"userId"`
-	Responses []interface{}      `bson:"responses" json:"responses"`
-	Score     int                `bson:"score" json:"score"`
-	Result    string             `bson:"result" json:"result"`
-	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
-	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+	ID                   primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID               primitive.ObjectID `bson:"userId" json:"userId"`
+	Responses            []interface{}      `bson:"responses,omitempty" json:"responses,omitempty"`
+	Score                int                `bson:"score" json:"score"`
+	Result               string             `bson:"result" json:"result"`
+	QuestionnaireVersion int                `bson:"questionnaireVersion,omitempty" json:"questionnaireVersion,omitempty"`
+
+	IrregularCycles *bool                 `bson:"irregularCycles,omitempty" json:"irregularCycles,omitempty"`
+	FerrimanGallwey *FerrimanGallweyScore `bson:"ferrimanGallwey,omitempty" json:"ferrimanGallwey,omitempty"`
+	Acne            *bool                 `bson:"acne,omitempty" json:"acne,omitempty"`
+	HairThinning    *bool                 `bson:"hairThinning,omitempty" json:"hairThinning,omitempty"`
+	Labs            *AndrogenLabs         `bson:"labs,omitempty" json:"labs,omitempty"`
+
+	Criteria            RotterdamCriteria      `bson:"criteria" json:"criteria"`
+	CriteriaMet         int                    `bson:"criteriaMet" json:"criteriaMet"`
+	Phenotype           string                 `bson:"phenotype,omitempty" json:"phenotype,omitempty"`
+	Recommendations     []string               `bson:"recommendations" json:"recommendations"`
+	RecommendedTests    []string               `bson:"recommendedTests" json:"recommendedTests"`
+	RecommendedPackages []DiagnosticSuggestion `bson:"recommendedPackages,omitempty" json:"recommendedPackages,omitempty"`
+	CreatedAt           time.Time              `bson:"createdAt" json:"createdAt"`
+	UpdatedAt           time.Time              `bson:"updatedAt" json:"updatedAt"`
+}
+
+// FerrimanGallweyScore is the modified Ferriman-Gallwey hirsutism score, each
+// body area rated 0 (no terminal hair) to 4 (extensive)
+type FerrimanGallweyScore struct {
+	UpperLip     int `bson:"upperLip" json:"upperLip"`
+	Chin         int `bson:"chin" json:"chin"`
+	Chest        int `bson:"chest" json:"chest"`
+	UpperBack    int `bson:"upperBack" json:"upperBack"`
+	LowerBack    int `bson:"lowerBack" json:"lowerBack"`
+	UpperAbdomen int `bson:"upperAbdomen" json:"upperAbdomen"`
+	LowerAbdomen int `bson:"lowerAbdomen" json:"lowerAbdomen"`
+	UpperArm     int `bson:"upperArm" json:"upperArm"`
+	Thigh        int `bson:"thigh" json:"thigh"`
+}
+
+// Areas returns the area scores in the order of the published chart
+func (s FerrimanGallweyScore) Areas() []int {
+	return []int{s.UpperLip, s.Chin, s.Chest, s.UpperBack, s.LowerBack,
+		s.UpperAbdomen, s.LowerAbdomen, s.UpperArm, s.Thigh}
+}
+
+// Total returns the sum of the area scores
+func (s FerrimanGallweyScore) Total() int {
+	total := 0
+	for _, area := range s.Areas() {
+		total += area
+	}
+	return total
+}
+
+// AndrogenLabs holds blood test results for biochemical hyperandrogenism.
+// TestosteroneUpperLimit is the reporting lab's reference upper limit.
+type AndrogenLabs struct {
+	TotalTestosterone      *float64 `bson:"totalTestosterone,omitempty" json:"totalTestosterone,omitempty"`           // ng/dL
+	TestosteroneUpperLimit *float64 `bson:"testosteroneUpperLimit,omitempty" json:"testosteroneUpperLimit,omitempty"` // ng/dL
+	FreeAndrogenIndex      *float64 `bson:"freeAndrogenIndex,omitempty" json:"freeAndrogenIndex,omitempty"`           // %
+}
+
+type RotterdamCriteria struct {
+	OvulatoryDysfunction RotterdamCriterion `bson:"ovulatoryDysfunction" json:"ovulatoryDysfunction"`
+	Hyperandrogenism     RotterdamCriterion `bson:"hyperandrogenism" json:"hyperandrogenism"`
+	PolycysticOvaries    RotterdamCriterion `bson:"polycysticOvaries" json:"polycysticOvaries"`
+}
+
+// RotterdamCriterion records whether a criterion is met and the evidence used
+type RotterdamCriterion struct {
+	Status  string   `bson:"status" json:"status"` // met, not_met, possible, unknown
+	Source  string   `bson:"source,omitempty" json:"source,omitempty"`
+	Details []string `bson:"details,omitempty" json:"details,omitempty"`
+}
+
+// DiagnosticSuggestion is a diagnostics package covering recommended tests,
+// with its reference price; labs set their own prices
+type DiagnosticSuggestion struct {
+	DiagnosticID string   `bson:"diagnosticId" json:"diagnosticId"`
+	Name         string   `bson:"name" json:"name"`
+	Price        float64  `bson:"price" json:"price"`
+	Covers       []string `bson:"covers" json:"covers"`
+}
+
+// OvarianUltrasound is an uploaded pelvic ultrasound result. Follicle counts
+// are per ovary, volumes in mL.
+type OvarianUltrasound struct {
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID             primitive.ObjectID `bson:"userId" json:"userId"`
+	ScanDate           time.Time          `bson:"scanDate" json:"scanDate"`
+	Approach           string             `bson:"approach" json:"approach"`
+	LeftFollicleCount  *int               `bson:"leftFollicleCount,omitempty" json:"leftFollicleCount,omitempty"`
+	RightFollicleCount *int               `bson:"rightFollicleCount,omitempty" json:"rightFollicleCount,omitempty"`
+	LeftVolumeMl       *float64           `bson:"leftVolumeMl,omitempty" json:"leftVolumeMl,omitempty"`
+	RightVolumeMl      *float64           `bson:"rightVolumeMl,omitempty" json:"rightVolumeMl,omitempty"`
+	ReportURL          string             `bson:"reportUrl,omitempty" json:"reportUrl,omitempty"`
+	Notes              string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedAt          time.Time          `bson:"createdAt" json:"createdAt"`
 }
@@ -1 +1,21 @@
 package entities
+
+import "time"
+
+// PeriodPrediction is the calendar prediction for the next cycle. When ovulation has
+// been confirmed by a temperature shift, the next period is projected from the
+// ovulation date instead of the average cycle length. For users on a pill pack with
+// a break week, the next bleed is the withdrawal bleed of the next break.
+type PeriodPrediction struct {
+	LastPeriodStart        time.Time       `json:"lastPeriodStart"`
+	AverageCycleLength     int             `json:"averageCycleLength"`
+	NextPeriodStart        time.Time       `json:"nextPeriodStart"`
+	PredictedOvulationDate *time.Time      `json:"predictedOvulationDate,omitempty"`
+	FertileWindowStart     *time.Time      `json:"fertileWindowStart,omitempty"`
+	FertileWindowEnd       *time.Time      `json:"fertileWindowEnd,omitempty"`
+	OvulationConfirmed     bool            `json:"ovulationConfirmed"`
+	ConfirmedOvulationDate *time.Time      `json:"confirmedOvulationDate,omitempty"`
+	PillPack               *PillPackStatus `json:"pillPack,omitempty"`
+	Basis                  string          `json:"basis"` // calendar, temperature_shift, pill_pack
+	Notes                  []string        `json:"notes"`
+}
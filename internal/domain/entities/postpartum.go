@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PostpartumEntry is a single postpartum log: recovery, feeding, bleeding (lochia) or sleep
+type PostpartumEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID    primitive.ObjectID `bson:"userId" json:"userId"`
+	TrackerID primitive.ObjectID `bson:"trackerId" json:"trackerId"`
+	Type      string             `bson:"type" json:"type"` // recovery, feeding, bleeding, sleep
+	Date      time.Time          `bson:"date" json:"date"`
+
+	// Recovery
+	Symptoms  []string `bson:"symptoms,omitempty" json:"symptoms,omitempty"`
+	PainLevel *int     `bson:"painLevel,omitempty" json:"painLevel,omitempty"` // 0-10
+
+	// Feeding
+	FeedingMethod   string  `bson:"feedingMethod,omitempty" json:"feedingMethod,omitempty"` // breast, bottle, pumping
+	Side            string  `bson:"side,omitempty" json:"side,omitempty"`                   // left, right, both
+	DurationMinutes int     `bson:"durationMinutes,omitempty" json:"durationMinutes,omitempty"`
+	AmountML        float64 `bson:"amountMl,omitempty" json:"amountMl,omitempty"`
+
+	// Bleeding / lochia
+	LochiaFlow  string `bson:"lochiaFlow,omitempty" json:"lochiaFlow,omitempty"`   // none, spotting, light, moderate, heavy
+	LochiaColor string `bson:"lochiaColor,omitempty" json:"lochiaColor,omitempty"` // red, pink, brown, yellow, white
+	Clots       bool   `bson:"clots,omitempty" json:"clots,omitempty"`
+
+	// Sleep
+	SleepHours   float64 `bson:"sleepHours,omitempty" json:"sleepHours,omitempty"`
+	SleepQuality string  `bson:"sleepQuality,omitempty" json:"sleepQuality,omitempty"` // poor, fair, good
+
+	Notes     string    `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+type PostpartumSummary struct {
+	Tracker           *PregnancyTracker `json:"tracker"`
+	DaysPostpartum    int               `json:"daysPostpartum"`
+	FeedsLast24Hours  int               `json:"feedsLast24Hours"`
+	AverageSleepHours float64           `json:"averageSleepHours"`
+	LatestBleeding    *PostpartumEntry  `json:"latestBleeding,omitempty"`
+	LatestEPDS        *TestResult       `json:"latestEpds,omitempty"`
+	Warnings          []string          `json:"warnings"`
+}
@@ -1 +1,51 @@
 package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PregnancySession is a contraction-timer or kick-counter session attached to a PregnancyTracker
+type PregnancySession struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID    primitive.ObjectID `bson:"userId" json:"userId"`
+	TrackerID primitive.ObjectID `bson:"trackerId" json:"trackerId"`
+	Type      string             `bson:"type" json:"type"`     // contraction, kick
+	Status    string             `bson:"status" json:"status"` // active, completed
+	StartedAt time.Time          `bson:"startedAt" json:"startedAt"`
+	EndedAt   *time.Time         `bson:"endedAt,omitempty" json:"endedAt,omitempty"`
+	Events    []SessionEvent     `bson:"events" json:"events"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// SessionEvent is a single contraction (start and end) or a single kick (start only)
+type SessionEvent struct {
+	StartedAt time.Time  `bson:"startedAt" json:"startedAt"`
+	EndedAt   *time.Time `bson:"endedAt,omitempty" json:"endedAt,omitempty"`
+}
+
+type ContractionAnalysis struct {
+	Count                  int      `json:"count"`
+	AverageDurationSeconds float64  `json:"averageDurationSeconds"`
+	AverageIntervalMinutes float64  `json:"averageIntervalMinutes"`
+	LastDurationSeconds    float64  `json:"lastDurationSeconds"`
+	LastIntervalMinutes    float64  `json:"lastIntervalMinutes"`
+	Pattern511             bool     `json:"pattern511"`
+	Warnings               []string `json:"warnings"`
+}
+
+type KickAnalysis struct {
+	Count             int      `json:"count"`
+	ElapsedMinutes    float64  `json:"elapsedMinutes"`
+	MinutesToTenKicks *float64 `json:"minutesToTenKicks,omitempty"`
+	GoalReached       bool     `json:"goalReached"`
+	Warnings          []string `json:"warnings"`
+}
+
+type PregnancySessionSummary struct {
+	Session     *PregnancySession    `json:"session"`
+	Contraction *ContractionAnalysis `json:"contraction,omitempty"`
+	Kick        *KickAnalysis        `json:"kick,omitempty"`
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RecordShare is a patient's consent for the doctor of a booking to read the
+// chosen records until ExpiresAt or until it is revoked
+type RecordShare struct {
+	ID                primitive.ObjectID   `bson:"_id,omitempty" json:"_id"`
+	UserID            primitive.ObjectID   `bson:"userId" json:"userId"`
+	DoctorID          primitive.ObjectID   `bson:"doctorId" json:"doctorId"`
+	BookingID         primitive.ObjectID   `bson:"bookingId" json:"bookingId"`
+	TestResultIDs     []primitive.ObjectID `bson:"testResultIds" json:"testResultIds"`
+	PCOSAssessmentIDs []primitive.ObjectID `bson:"pcosAssessmentIds" json:"pcosAssessmentIds"`
+	IncludePeriods    bool                 `bson:"includePeriods" json:"includePeriods"`
+	IncludeSymptoms   bool                 `bson:"includeSymptoms" json:"includeSymptoms"`
+	ExpiresAt         time.Time            `bson:"expiresAt" json:"expiresAt"`
+	RevokedAt         *time.Time           `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+	CreatedAt         time.Time            `bson:"createdAt" json:"createdAt"`
+	UpdatedAt         time.Time            `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Active reports whether the share can still be read
+func (s *RecordShare) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RecordShareRequest is what a patient chooses to share
+type RecordShareRequest struct {
+	BookingID         string   `json:"bookingId"`
+	TestResultIDs     []string `json:"testResultIds"`
+	PCOSAssessmentIDs []string `json:"pcosAssessmentIds"`
+	IncludePeriods    bool     `json:"includePeriods"`
+	IncludeSymptoms   bool     `json:"includeSymptoms"`
+	ExpiresInHours    int      `json:"expiresInHours"`
+}
+
+// RecordAccessLog records each time a doctor read, or a patient changed, a share
+type RecordAccessLog struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	ShareID    primitive.ObjectID `bson:"shareId" json:"shareId"`
+	UserID     primitive.ObjectID `bson:"userId" json:"userId"`
+	DoctorID   primitive.ObjectID `bson:"doctorId" json:"doctorId"`
+	Action     string             `bson:"action" json:"action"` // granted, viewed_summary, revoked, denied
+	IPAddress  string             `bson:"ipAddress,omitempty" json:"ipAddress,omitempty"`
+	OccurredAt time.Time          `bson:"occurredAt" json:"occurredAt"`
+}
+
+// PreConsultationSummary is the doctor's view of the records shared for a booking
+type PreConsultationSummary struct {
+	ShareID     primitive.ObjectID  `json:"shareId"`
+	ExpiresAt   time.Time           `json:"expiresAt"`
+	Patient     SummaryPatient      `json:"patient"`
+	Booking     *Booking            `json:"booking,omitempty"`
+	Assessments []*TestResult       `json:"assessments"`
+	PCOS        []*PCOSAssessment   `json:"pcos"`
+	Cycles      *CycleSummary       `json:"cycles,omitempty"`
+	Symptoms    []*SymptomsTracking `json:"symptoms,omitempty"`
+	Highlights  []string            `json:"highlights"`
+}
+
+type SummaryPatient struct {
+	Name         string `json:"name"`
+	TrackingMode string `json:"trackingMode,omitempty"`
+}
+
+// CycleSummary condenses recent period logs
+type CycleSummary struct {
+	LastPeriodStart    time.Time      `json:"lastPeriodStart"`
+	AverageCycleLength int            `json:"averageCycleLength,omitempty"`
+	ShortestCycle      int            `json:"shortestCycle,omitempty"`
+	LongestCycle       int            `json:"longestCycle,omitempty"`
+	Cycles             []*PeriodCycle `json:"cycles"`
+}
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DueAssessment is the next scheduled rescreening for an instrument, computed
+// from the user's last result
+type DueAssessment struct {
+	TestName     string             `json:"testName"`
+	DisplayName  string             `json:"displayName,omitempty"`
+	LastResultID primitive.ObjectID `json:"lastResultId"`
+	LastTakenAt  time.Time          `json:"lastTakenAt"`
+	LastLevel    string             `json:"lastLevel,omitempty"`
+	IntervalDays int                `json:"intervalDays"`
+	DueAt        time.Time          `json:"dueAt"`
+	Due          bool               `json:"due"`
+	DaysOverdue  int                `json:"daysOverdue,omitempty"`
+}
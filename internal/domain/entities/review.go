@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Review is a patient's rating of a doctor, clinic or lab, left for one of
+// their completed bookings. Reviews are published once a moderator approves
+// them, and only approved reviews count towards the target's rating.
+type Review struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	TargetType   string             `bson:"targetType" json:"targetType"` // doctor, clinic, lab
+	TargetID     primitive.ObjectID `bson:"targetId" json:"targetId"`
+	BookingID    primitive.ObjectID `bson:"bookingId" json:"bookingId"`
+	UserID       primitive.ObjectID `bson:"userId" json:"userId"`
+	ReviewerName string             `bson:"reviewerName" json:"reviewerName"` // first name only
+	Rating       int                `bson:"rating" json:"rating"`             // 1 to 5 stars
+	Text         string             `bson:"text,omitempty" json:"text,omitempty"`
+	Tags         []string           `bson:"tags,omitempty" json:"tags,omitempty"`
+	Status       string             `bson:"status" json:"status"` // pending, approved, rejected
+	// Set by the moderator; a rejection note is shown to the reviewer
+	ModerationNote string          `bson:"moderationNote,omitempty" json:"moderationNote,omitempty"`
+	ModeratedBy    string          `bson:"moderatedBy,omitempty" json:"-"`
+	ModeratedAt    *time.Time      `bson:"moderatedAt,omitempty" json:"moderatedAt,omitempty"`
+	Response       *ReviewResponse `bson:"response,omitempty" json:"response,omitempty"`
+	CreatedAt      time.Time       `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time       `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ReviewResponse is the doctor's public reply to a review
+type ReviewResponse struct {
+	Text        string    `bson:"text" json:"text"`
+	RespondedAt time.Time `bson:"respondedAt" json:"respondedAt"`
+}
@@ -12,3 +12,11 @@ type TimeSlot struct {
 	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
 }
+
+// Doctors are booked in 30 minute slots from 09:00 to 18:00
+const (
+	ConsultationDayStartHour = 9
+	ConsultationDayEndHour   = 18
+	ConsultationSlotMinutes  = 30
+	ConsultationSlotsPerDay  = (ConsultationDayEndHour - ConsultationDayStartHour) * 60 / ConsultationSlotMinutes
+)
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// QuestionnaireTranslation holds the text of one questionnaire version in one
+// locale. Text is keyed by what scoring never changes (question keys, English
+// option labels and severities), so a translation cannot affect a score.
+type QuestionnaireTranslation struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	Name         string             `bson:"name" json:"name"`
+	Version      int                `bson:"version" json:"version"`
+	Locale       string             `bson:"locale" json:"locale"`
+	DisplayName  string             `bson:"displayName,omitempty" json:"displayName,omitempty"`
+	Description  string             `bson:"description,omitempty" json:"description,omitempty"`
+	CriticalNote string             `bson:"criticalNote,omitempty" json:"criticalNote,omitempty"`
+	// Section title and description keyed by the English section title
+	Sections map[string]SectionTranslation `bson:"sections,omitempty" json:"sections,omitempty"`
+	// Question text keyed by question key
+	Questions map[string]string `bson:"questions,omitempty" json:"questions,omitempty"`
+	// Option labels keyed by the English label
+	Options map[string]string `bson:"options,omitempty" json:"options,omitempty"`
+	// Threshold recommendations keyed by severity
+	Recommendations map[string]string `bson:"recommendations,omitempty" json:"recommendations,omitempty"`
+	CreatedAt       time.Time         `bson:"createdAt" json:"createdAt"`
+	UpdatedAt       time.Time         `bson:"updatedAt" json:"updatedAt"`
+}
+
+type SectionTranslation struct {
+	Title       string `bson:"title" json:"title"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+}
+
+// TranslationReport lists what is still untranslated in one locale
+type TranslationReport struct {
+	Locale         string           `json:"locale"`
+	Complete       bool             `json:"complete"`
+	Questionnaires []TranslationGap `json:"questionnaires"`
+	Messages       []string         `json:"messages"`
+}
+
+// TranslationGap is the untranslated text of the latest version of a questionnaire
+type TranslationGap struct {
+	Name    string   `json:"name"`
+	Version int      `json:"version"`
+	Missing []string `json:"missing"`
+}
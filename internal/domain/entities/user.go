@@ -12,11 +12,31 @@ type User struct {
 	Email        string             `bson:"email" json:"email"`
 	PhoneNumber  string             `bson:"phoneNumber" json:"phoneNumber"`
 	Password     string             `bson:"password" json:"-"`
-	ProfileImage string             `bson:"profileImage,omitempty" json:"profileImage,omitempty"`
+	ProfileImage string             `bson:"profileImage,omitempty" json:"profileImage,omitempty"` // object key
 	IsVerified   bool               `bson:"isVerified" json:"isVerified"`
 	IsActive     bool               `bson:"isActive" json:"isActive"`
-	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
-	UpdatedAt    time.Time          `bson:"updatedAt" json:"updatedAt"`
+	TrackingMode string             `bson:"trackingMode" json:"trackingMode,omitempty"` // perimenopause, postmenopause
+	Roles        []string           `bson:"roles,omitempty" json:"roles,omitempty"`     // granted by an admin, e.g. moderator
+	// Language chosen in the profile; it overrides the Accept-Language header
+	PreferredLanguage string    `bson:"preferredLanguage,omitempty" json:"preferredLanguage,omitempty"`
+	CreatedAt         time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt         time.Time `bson:"updatedAt" json:"updatedAt"`
+	// Signed URLs for the profile image, set in responses
+	ProfileImageURL string `bson:"-" json:"profileImageUrl,omitempty"`
+	ThumbnailURL    string `bson:"-" json:"thumbnailUrl,omitempty"`
+}
+
+// RoleModerator lets an account approve and reject reviews
+const RoleModerator = "moderator"
+
+// HasRole reports whether an admin granted the user a role
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
 }
 
 type OTP struct {
@@ -23,4 +23,11 @@ type BookingRepository interface {
 	
 	// Payment related
 	UpdatePaymentStatus(ctx context.Context, bookingID string, status string, razorpayOrderID, razorpayPaymentID string) error
+
+	// Calls
+	// Paid video and audio call bookings on days from from to to that are not yet
+	// completed, cancelled or a no-show
+	FindUnsettledCalls(ctx context.Context, from, to time.Time) ([]*entities.Booking, error)
+	// Marks a call completed or no_show, returning false if it was already settled
+	SettleCall(ctx context.Context, bookingID, status, noShowBy string) (bool, error)
 }
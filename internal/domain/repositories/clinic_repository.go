@@ -8,7 +8,24 @@ import (
 
 type ClinicRepository interface {
 	FindAll(ctx context.Context) ([]*entities.Clinic, error)
+	FindByID(ctx context.Context, id string) (*entities.Clinic, error)
+	Search(ctx context.Context, query entities.GeoQuery) ([]*entities.Clinic, int64, error)
+	EnsureIndexes(ctx context.Context) error
+	// Adds an approved review's stars to the rating (delta 1) or removes them (delta -1)
+	ApplyReviewRating(ctx context.Context, id string, stars, delta int) error
+	// CreateBooking returns domain.ErrDuplicateKey when another booking holds the slot
 	CreateBooking(ctx context.Context, booking *entities.ClinicBooking) error
+	FindBookingByID(ctx context.Context, id string) (*entities.ClinicBooking, error)
 	FindBookingsByUserID(ctx context.Context, userID string) ([]*entities.ClinicBooking, error)
-	UpdateBookingPayment(ctx context.Context, bookingID, paymentID, status string) error
+	// Active bookings are those dated in [from, to) that are not cancelled or
+	// expired, leaving out unpaid ones created before unpaidSince
+	FindActiveBookings(ctx context.Context, clinicID string, from, to, unpaidSince int64) ([]*entities.ClinicBooking, error)
+	CountActiveBookings(ctx context.Context, clinicID, service string, from, to, unpaidSince int64) (int64, error)
+	// ExpireUnpaidBookings marks the pending, unpaid bookings dated in [from, to)
+	// created before unpaidSince expired, releasing their slots
+	ExpireUnpaidBookings(ctx context.Context, clinicID string, from, to, unpaidSince int64) error
+	// UpdateBookingPayment confirms a pending booking, reporting false when it is no longer pending
+	UpdateBookingPayment(ctx context.Context, bookingID, paymentID, status string) (bool, error)
+	SetBookingOrder(ctx context.Context, bookingID, razorpayOrderID string) error
+	DeleteBooking(ctx context.Context, bookingID string) error
 }
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ConsultationRepository interface {
+	CreateEvent(ctx context.Context, event *entities.ConsultationEvent) error
+	// A booking's call events, oldest first
+	FindEvents(ctx context.Context, bookingID primitive.ObjectID) ([]*entities.ConsultationEvent, error)
+	// The latest event of a participant in a booking's call, or nil
+	FindLastEvent(ctx context.Context, bookingID primitive.ObjectID, role string) (*entities.ConsultationEvent, error)
+	EnsureIndexes(ctx context.Context) error
+}
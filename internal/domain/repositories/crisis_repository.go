@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+type CrisisRepository interface {
+	Create(ctx context.Context, escalation *entities.CrisisEscalation) error
+	Update(ctx context.Context, escalation *entities.CrisisEscalation) error
+}
@@ -2,13 +2,30 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type DiagnosticRepository interface {
-	FindAll(ctx context.Context) ([]*entities.Diagnostic, error)
-	CreateBooking(ctx context.Context, booking *entities.DiagnosticBooking) error
-	FindBookingsByUserID(ctx context.Context, userID string) ([]*entities.DiagnosticBooking, error)
+	FindAll(ctx context.Context) ([]*entities.Diagnostics, error)
+	FindByID(ctx context.Context, id string) (*entities.Diagnostics, error)
+	CreateBooking(ctx context.Context, booking *entities.DiagnosticsBooking) error
+	FindBookingsByUserID(ctx context.Context, userID string) ([]*entities.DiagnosticsBooking, error)
 	UpdateBookingPayment(ctx context.Context, bookingID, paymentID, status string) error
+	SetBookingOrder(ctx context.Context, bookingID, razorpayOrderID string) error
+	DeleteBooking(ctx context.Context, bookingID string) error
+	UpdateBookingStatus(ctx context.Context, bookingID, status string) error
+
+	// Home collection
+	FindBookingByID(ctx context.Context, id string) (*entities.DiagnosticsBooking, error)
+	FindHomeCollections(ctx context.Context, labID primitive.ObjectID, from, to time.Time) ([]*entities.DiagnosticsBooking, error)
+	AssignPhlebotomist(ctx context.Context, bookingID string, phlebotomistID primitive.ObjectID) error
+	// Moves a collection on from one status, reporting false if it was no longer
+	// in that status. A non-empty bookingStatus also sets the booking's status.
+	UpdateCollectionStatus(ctx context.Context, bookingID, from string, change entities.CollectionStatusChange, bookingStatus string) (bool, error)
+
+	// Rewrites documents stored before the catalog and labs were unified
+	MigrateLegacy(ctx context.Context) (int64, error)
 }
@@ -11,16 +11,24 @@ type DoctorRepository interface {
 	Create(ctx context.Context, doctor *entities.Doctor) (*entities.Doctor, error)
 	FindByID(ctx context.Context, id string) (*entities.Doctor, error)
 	FindByEmail(ctx context.Context, email string) (*entities.Doctor, error)
+	// FindByUserID returns the doctor whose profile is linked to the user's account
+	FindByUserID(ctx context.Context, userID string) (*entities.Doctor, error)
 	Update(ctx context.Context, id string, doctor *entities.Doctor) (*entities.Doctor, error)
 	Delete(ctx context.Context, id string) error
 
 	// Query operations
-	FindAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entities.Doctor, error)
+	// Search returns a page of approved doctors matching the query, the exact
+	// total and facet counts over all matches
+	Search(ctx context.Context, query entities.DoctorQuery) (*entities.DoctorSearchResult, error)
 	
 	// Availability operations
 	UpdateAvailability(ctx context.Context, doctorID string, slots []entities.TimeSlot) error
 	GetAvailability(ctx context.Context, doctorID string) ([]entities.TimeSlot, error)
-	
-	// Specialization operations
-	FindBySpecialization(ctx context.Context, specialization string) ([]*entities.Doctor, error)
+
+	// Location operations
+	SearchInClinic(ctx context.Context, query entities.GeoQuery) ([]*entities.Doctor, int64, error)
+	EnsureIndexes(ctx context.Context) error
+
+	// Adds an approved review's stars to the rating (delta 1) or removes them (delta -1)
+	ApplyReviewRating(ctx context.Context, id string, stars, delta int) error
 }
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+type FertilityRepository interface {
+	Create(ctx context.Context, log *entities.FertilityLog) error
+	FindByUserID(ctx context.Context, userID string, logType string, limit int) ([]*entities.FertilityLog, error)
+	FindByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*entities.FertilityLog, error)
+}
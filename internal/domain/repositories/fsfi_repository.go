@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+type FSFIRepository interface {
+	Create(ctx context.Context, result *entities.FSFIResult) error
+	FindByUserID(ctx context.Context, userID string, limit int) ([]*entities.FSFIResult, error)
+	FindByID(ctx context.Context, userID, id string) (*entities.FSFIResult, error)
+	FindByDateRange(ctx context.Context, userID string, from, to *time.Time, page, limit int) ([]*entities.FSFIResult, int64, error)
+	FindPrevious(ctx context.Context, userID string, before time.Time) (*entities.FSFIResult, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LabReportRepository interface {
+	Create(ctx context.Context, report *entities.LabReport) error
+	Replace(ctx context.Context, report *entities.LabReport) error
+	FindByID(ctx context.Context, id string) (*entities.LabReport, error)
+	FindByBookingID(ctx context.Context, bookingID primitive.ObjectID) (*entities.LabReport, error)
+	FindByUserID(ctx context.Context, userID string) ([]*entities.LabReport, error)
+	// One analyte's values across a user's reports, oldest first. Nil bounds
+	// are open.
+	FindAnalyteHistory(ctx context.Context, userID, code string, from, to *time.Time) ([]entities.AnalyteTrendPoint, error)
+	EnsureIndexes(ctx context.Context) error
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LabRepository interface {
+	FindByID(ctx context.Context, id string) (*entities.DiagnosticsUser, error)
+	// FindByUserID returns the approved lab linked to the user's account
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) (*entities.DiagnosticsUser, error)
+	Search(ctx context.Context, query entities.GeoQuery) ([]*entities.DiagnosticsUser, int64, error)
+	EnsureIndexes(ctx context.Context) error
+	// Adds an approved review's stars to the rating (delta 1) or removes them (delta -1)
+	ApplyReviewRating(ctx context.Context, id string, stars, delta int) error
+
+	// Phlebotomists who collect samples at home
+	CreatePhlebotomist(ctx context.Context, phlebotomist *entities.Phlebotomist) error
+	FindPhlebotomistByID(ctx context.Context, id string) (*entities.Phlebotomist, error)
+	FindPhlebotomists(ctx context.Context, labID primitive.ObjectID) ([]*entities.Phlebotomist, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MedicationRepository interface {
+	CreateRegimen(ctx context.Context, regimen *entities.MedicationRegimen) error
+	FindRegimenByID(ctx context.Context, id string) (*entities.MedicationRegimen, error)
+	FindRegimensByUserID(ctx context.Context, userID string, activeOnly bool) ([]*entities.MedicationRegimen, error)
+	FindActiveRegimens(ctx context.Context) ([]*entities.MedicationRegimen, error)
+	UpdateRegimen(ctx context.Context, regimen *entities.MedicationRegimen) error
+
+	// Dose logs are unique per regimen and scheduled time
+	UpsertDoseLog(ctx context.Context, log *entities.DoseLog) error
+	FindDoseLogs(ctx context.Context, regimenIDs []primitive.ObjectID, from, to time.Time) ([]*entities.DoseLog, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+type MenopauseRepository interface {
+	Create(ctx context.Context, log *entities.VasomotorLog) error
+	FindByUserID(ctx context.Context, userID string, logType string, limit int) ([]*entities.VasomotorLog, error)
+	FindSince(ctx context.Context, userID string, since time.Time) ([]*entities.VasomotorLog, error)
+}
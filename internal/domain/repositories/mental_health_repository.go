@@ -2,11 +2,19 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type MentalHealthRepository interface {
 	CreateResult(ctx context.Context, result *entities.TestResult) error
 	FindResultsByUserID(ctx context.Context, userID string, testName string) ([]*entities.TestResult, error)
+	FindResultsByIDs(ctx context.Context, userID string, ids []primitive.ObjectID) ([]*entities.TestResult, error)
+	FindResultsByDateRange(ctx context.Context, userID, testName string, from, to *time.Time, page, limit int) ([]*entities.TestResult, int64, error)
+	FindPreviousResult(ctx context.Context, userID, testName string, before time.Time) (*entities.TestResult, error)
+	FindLatestResults(ctx context.Context, userID string, testNames []string) ([]*entities.TestResult, error)
+	FindLatestResultsPendingReminder(ctx context.Context, testNames []string, takenBefore time.Time) ([]*entities.TestResult, error)
+	MarkRescreenNotified(ctx context.Context, resultID primitive.ObjectID, notifiedAt time.Time) error
 }
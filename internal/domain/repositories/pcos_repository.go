@@ -4,10 +4,15 @@ import (
 	"context"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type PCOSRepository interface {
 	Create(ctx context.Context, assessment *entities.PCOSAssessment) error
 	FindByUserID(ctx context.Context, userID string, limit int) ([]*entities.PCOSAssessment, error)
 	FindLatestByUserID(ctx context.Context, userID string) (*entities.PCOSAssessment, error)
+	FindByIDs(ctx context.Context, userID string, ids []primitive.ObjectID) ([]*entities.PCOSAssessment, error)
+	CreateUltrasound(ctx context.Context, ultrasound *entities.OvarianUltrasound) error
+	FindUltrasoundsByUserID(ctx context.Context, userID string, limit int) ([]*entities.OvarianUltrasound, error)
+	FindLatestUltrasound(ctx context.Context, userID string) (*entities.OvarianUltrasound, error)
 }
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+type PostpartumRepository interface {
+	Create(ctx context.Context, entry *entities.PostpartumEntry) error
+	FindByUserID(ctx context.Context, userID string, entryType string, limit int) ([]*entities.PostpartumEntry, error)
+	FindSince(ctx context.Context, userID string, since time.Time) ([]*entities.PostpartumEntry, error)
+}
@@ -2,12 +2,29 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type PregnancyRepository interface {
 	Create(ctx context.Context, tracker *entities.PregnancyTracker) error
 	FindByUserID(ctx context.Context, userID string) (*entities.PregnancyTracker, error)
 	Update(ctx context.Context, tracker *entities.PregnancyTracker) error
+
+	// Contraction timer and kick counter sessions. A user has at most one active
+	// session of each type; CreateSession returns domain.ErrDuplicateKey otherwise.
+	CreateSession(ctx context.Context, session *entities.PregnancySession) error
+	FindSessionByID(ctx context.Context, sessionID string) (*entities.PregnancySession, error)
+	FindSessionsByUserID(ctx context.Context, userID string, sessionType string, limit int) ([]*entities.PregnancySession, error)
+	FindActiveSession(ctx context.Context, userID string, sessionType string) (*entities.PregnancySession, error)
+	// The session updates below apply only while the session is active and
+	// return the updated session, or nil when it no longer matches
+	AddSessionEvent(ctx context.Context, session *entities.PregnancySession, event entities.SessionEvent) (*entities.PregnancySession, error)
+	EndContraction(ctx context.Context, sessionID primitive.ObjectID, endedAt time.Time) (*entities.PregnancySession, error)
+	CompleteSession(ctx context.Context, sessionID primitive.ObjectID, endedAt time.Time) (*entities.PregnancySession, error)
+	// CompleteActiveSessions marks every active session of the user completed
+	CompleteActiveSessions(ctx context.Context, userID primitive.ObjectID, endedAt time.Time) error
+	EnsureIndexes(ctx context.Context) error
 }
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+type QuestionnaireRepository interface {
+	Create(ctx context.Context, test *entities.MentalHealthTest) error
+	FindLatest(ctx context.Context, name string) (*entities.MentalHealthTest, error)
+	FindByVersion(ctx context.Context, name string, version int) (*entities.MentalHealthTest, error)
+	FindAllLatest(ctx context.Context, category string) ([]*entities.MentalHealthTest, error)
+	FindVersions(ctx context.Context, name string) ([]*entities.MentalHealthTest, error)
+	UpsertTranslation(ctx context.Context, translation *entities.QuestionnaireTranslation) error
+	FindTranslation(ctx context.Context, name string, version int, locale string) (*entities.QuestionnaireTranslation, error)
+	FindTranslationsByLocale(ctx context.Context, locale string) ([]*entities.QuestionnaireTranslation, error)
+}
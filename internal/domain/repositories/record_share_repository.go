@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+type RecordShareRepository interface {
+	Create(ctx context.Context, share *entities.RecordShare) error
+	FindByID(ctx context.Context, id string) (*entities.RecordShare, error)
+	FindByUserID(ctx context.Context, userID string) ([]*entities.RecordShare, error)
+	FindActiveByDoctorID(ctx context.Context, doctorID string, now time.Time) ([]*entities.RecordShare, error)
+	Update(ctx context.Context, share *entities.RecordShare) error
+	CreateAccessLog(ctx context.Context, entry *entities.RecordAccessLog) error
+	FindAccessLogs(ctx context.Context, shareID string) ([]*entities.RecordAccessLog, error)
+}
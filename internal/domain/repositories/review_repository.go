@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReviewRepository interface {
+	// Create returns domain.ErrDuplicateKey if the booking already has a review
+	Create(ctx context.Context, review *entities.Review) error
+	FindByID(ctx context.Context, id string) (*entities.Review, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]*entities.Review, error)
+	// Reviews of a target with the status, newest first, and the total
+	FindByTarget(ctx context.Context, targetType string, targetID primitive.ObjectID, status string, page, limit int) ([]*entities.Review, int64, error)
+	// Reviews with the status, oldest first, for the moderation queue
+	FindByStatus(ctx context.Context, status string, page, limit int) ([]*entities.Review, int64, error)
+	// Moves a review on from one status, returning nil if it was no longer in
+	// that status
+	UpdateStatus(ctx context.Context, id, from, to, note, moderator string) (*entities.Review, error)
+	SetResponse(ctx context.Context, id string, response *entities.ReviewResponse) error
+	// Delete removes a user's review, returning what was deleted or nil
+	Delete(ctx context.Context, id string, userID primitive.ObjectID) (*entities.Review, error)
+	EnsureIndexes(ctx context.Context) error
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -95,7 +96,7 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"success": true, "message": "OTP sent to email"})
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": message(c, i18n.MsgOTPSent)})
 }
 
 func (h *AuthHandler) VerifyOTP(c *gin.Context) {
@@ -113,7 +114,7 @@ func (h *AuthHandler) VerifyOTP(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"success": true, "message": "OTP verified"})
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": message(c, i18n.MsgOTPVerified)})
 }
 
 func (h *AuthHandler) ResetPassword(c *gin.Context) {
@@ -132,7 +133,7 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Password reset successfully"})
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": message(c, i18n.MsgPasswordReset)})
 }
 
 func (h *AuthHandler) ChangePassword(c *gin.Context) {
@@ -152,5 +153,5 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Password changed successfully"})
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": message(c, i18n.MsgPasswordChanged)})
 }
@@ -6,16 +6,20 @@ import (
 	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
 type BookingHandler struct {
 	bookingService *services.BookingService
+	shareService   *services.RecordShareService
 }
 
-func NewBookingHandler(bookingService *services.BookingService) *BookingHandler {
+func NewBookingHandler(bookingService *services.BookingService, shareService *services.RecordShareService) *BookingHandler {
 	return &BookingHandler{
 		bookingService: bookingService,
+		shareService:   shareService,
 	}
 }
 
@@ -28,6 +32,8 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 		Date        string `json:"date" binding:"required"`
 		TimeSlot    string `json:"timeSlot" binding:"required"`
 		Notes       string `json:"notes"`
+		// Optional records to share with the doctor for this booking
+		Share *entities.RecordShareRequest `json:"share"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
@@ -36,7 +42,7 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 
 	date, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid date format"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidDate)})
 		return
 	}
 
@@ -46,14 +52,28 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 		return
 	}
 
+	data := gin.H{
+		"bookingId":       booking.ID.Hex(),
+		"razorpayOrderId": booking.RazorpayOrderID,
+		"amount":          booking.Amount,
+		"status":          booking.Status,
+	}
+
+	// The booking stands even if sharing fails; the patient can share again later
+	if req.Share != nil {
+		req.Share.BookingID = booking.ID.Hex()
+		share, err := h.shareService.Grant(c.Request.Context(), userID, req.Share, c.ClientIP())
+		if err != nil {
+			data["shareError"] = err.Error()
+		} else {
+			data["shareId"] = share.ID.Hex()
+			data["shareExpiresAt"] = share.ExpiresAt
+		}
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data": gin.H{
-			"bookingId":       booking.ID.Hex(),
-			"razorpayOrderId": booking.RazorpayOrderID,
-			"amount":          booking.Amount,
-			"status":          booking.Status,
-		},
+		"data":    data,
 	})
 }
 
@@ -111,6 +131,6 @@ func (h *BookingHandler) VerifyPayment(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Payment verified successfully",
+		"message": message(c, i18n.MsgPaymentVerified),
 	})
 }
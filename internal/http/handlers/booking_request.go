@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// serverSetBookingFields are booking fields only the server may set: the price
+// comes from the catalog and the status and payment details from Razorpay
+var serverSetBookingFields = []string{
+	"id", "_id", "userId", "amount", "status", "paymentStatus", "paymentId", "razorpayOrderId", "razorpayPaymentId",
+	"testName", "homeCollection", "collectionFee", "createdAt", "updatedAt",
+}
+
+// bindBookingRequest binds a booking request body into req, rejecting a body
+// that sets any server-set field
+func bindBookingRequest(c *gin.Context, req interface{}) error {
+	var fields map[string]interface{}
+	if err := c.ShouldBindBodyWith(&fields, binding.JSON); err != nil {
+		return err
+	}
+
+	var rejected []string
+	for _, field := range serverSetBookingFields {
+		if _, ok := fields[field]; ok {
+			rejected = append(rejected, field)
+		}
+	}
+	if len(rejected) > 0 {
+		return fmt.Errorf("%s cannot be set by the client", strings.Join(rejected, ", "))
+	}
+
+	return c.ShouldBindBodyWith(req, binding.JSON)
+}
@@ -1,10 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
-	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,31 +19,86 @@ func NewClinicHandler(clinicService *services.ClinicService) *ClinicHandler {
 	}
 }
 
-func (h *ClinicHandler) GetClinics(c *gin.Context) {
-	clinics, err := h.clinicService.GetClinics(c.Request.Context())
+// SearchClinics lists clinics, nearest first when lat and lng are given
+func (h *ClinicHandler) SearchClinics(c *gin.Context) {
+	query, err := bindGeoQuery(c)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	clinics, total, err := h.clinicService.SearchClinics(c.Request.Context(), query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidSearch) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, searchPage(clinics, query, total))
+}
+
+func (h *ClinicHandler) GetClinic(c *gin.Context) {
+	clinic, err := h.clinicService.GetClinic(c.Request.Context(), c.Param("clinicId"))
+	if err != nil {
+		if errors.Is(err, services.ErrClinicNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    clinics,
+		"data":    clinic,
+	})
+}
+
+// GetAvailability lists the slots of a clinic service on a date
+func (h *ClinicHandler) GetAvailability(c *gin.Context) {
+	availability, err := h.clinicService.GetAvailability(c.Request.Context(), c.Param("clinicId"), c.Query("service"), c.Query("date"))
+	if err != nil {
+		if errors.Is(err, services.ErrClinicNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    availability,
 	})
 }
 
 func (h *ClinicHandler) CreateBooking(c *gin.Context) {
 	userID := c.GetString("userID")
 
-	var booking entities.ClinicBooking
-	if err := c.ShouldBindJSON(&booking); err != nil {
+	var req struct {
+		ClinicID string `json:"clinicId" binding:"required"`
+		Service  string `json:"service" binding:"required"`
+		Date     int64  `json:"date" binding:"required"`
+		TimeSlot string `json:"timeSlot" binding:"required"`
+	}
+	if err := bindBookingRequest(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
-	booking.UserID = userID
-
-	if err := h.clinicService.CreateBooking(c.Request.Context(), &booking); err != nil {
+	booking, err := h.clinicService.CreateBooking(c.Request.Context(), userID, req.ClinicID, req.Service, req.Date, req.TimeSlot)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrClinicNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+			return
+		case errors.Is(err, services.ErrClinicSlotUnavailable):
+			c.JSON(http.StatusConflict, gin.H{"success": false, "message": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
 		return
 	}
@@ -70,8 +126,10 @@ func (h *ClinicHandler) GetMyBookings(c *gin.Context) {
 
 func (h *ClinicHandler) VerifyPayment(c *gin.Context) {
 	var req struct {
-		BookingID string `json:"bookingId" binding:"required"`
-		PaymentID string `json:"paymentId" binding:"required"`
+		BookingID         string `json:"bookingId" binding:"required"`
+		RazorpayOrderID   string `json:"razorpayOrderId" binding:"required"`
+		RazorpayPaymentID string `json:"razorpayPaymentId" binding:"required"`
+		RazorpaySignature string `json:"razorpaySignature" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -79,13 +137,17 @@ func (h *ClinicHandler) VerifyPayment(c *gin.Context) {
 		return
 	}
 
-	if err := h.clinicService.VerifyPayment(c.Request.Context(), req.BookingID, req.PaymentID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+	if err := h.clinicService.VerifyPayment(c.Request.Context(), req.BookingID, req.RazorpayOrderID, req.RazorpayPaymentID, req.RazorpaySignature); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrClinicBookingExpired) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Payment verified successfully",
+		"message": message(c, i18n.MsgPaymentVerified),
 	})
 }
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/gin-gonic/gin"
+)
+
+type ConsultationHandler struct {
+	consultationService *services.ConsultationService
+}
+
+func NewConsultationHandler(consultationService *services.ConsultationService) *ConsultationHandler {
+	return &ConsultationHandler{
+		consultationService: consultationService,
+	}
+}
+
+// IssueToken returns an Agora token for the patient or doctor to join the
+// booking's call while it is open
+func (h *ConsultationHandler) IssueToken(c *gin.Context) {
+	token, err := h.consultationService.IssueToken(c.Request.Context(), c.GetString("userID"), c.Param("bookingId"))
+	if err != nil {
+		c.JSON(consultationErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    token,
+	})
+}
+
+// RecordEvent records the user joining or leaving the booking's call
+func (h *ConsultationHandler) RecordEvent(c *gin.Context) {
+	var req struct {
+		Type string `json:"type" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	event, err := h.consultationService.RecordEvent(c.Request.Context(), c.GetString("userID"), c.Param("bookingId"), req.Type)
+	if err != nil {
+		c.JSON(consultationErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    event,
+	})
+}
+
+func (h *ConsultationHandler) GetAttendance(c *gin.Context) {
+	attendance, err := h.consultationService.GetAttendance(c.Request.Context(), c.GetString("userID"), c.Param("bookingId"))
+	if err != nil {
+		c.JSON(consultationErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    attendance,
+	})
+}
+
+// consultationErrorStatus maps call errors; the rest are bad requests
+func consultationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrCallsNotConfigured):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, services.ErrNotCallParticipant):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrCallBookingNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrCallNotOpen), errors.Is(err, services.ErrCallClosed), errors.Is(err, services.ErrNotInCall):
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -31,10 +33,49 @@ func (h *DiagnosticHandler) GetDiagnostics(c *gin.Context) {
 	})
 }
 
-func (h *DiagnosticHandler) GetDiagnosticsUsers(c *gin.Context) {
+// SearchLabs lists diagnostic labs, nearest first when lat and lng are given
+func (h *DiagnosticHandler) SearchLabs(c *gin.Context) {
+	query, err := bindGeoQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	labs, total, err := h.diagnosticService.SearchLabs(c.Request.Context(), query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidSearch) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, searchPage(labs, query, total))
+}
+
+// GetLab returns a lab with the tests and packages it offers
+func (h *DiagnosticHandler) GetLab(c *gin.Context) {
+	lab, err := h.diagnosticService.GetLab(c.Request.Context(), c.Param("labId"))
+	if err != nil {
+		if errors.Is(err, services.ErrLabNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    lab,
+	})
+}
+
+func (h *DiagnosticHandler) GetMyBookings(c *gin.Context) {
 	userID := c.GetString("userID")
 
-	bookings, err := h.diagnosticService.GetDiagnosticsUsers(c.Request.Context(), userID)
+	bookings, err := h.diagnosticService.GetMyBookings(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
 		return
@@ -49,15 +90,40 @@ func (h *DiagnosticHandler) GetDiagnosticsUsers(c *gin.Context) {
 func (h *DiagnosticHandler) CreateBooking(c *gin.Context) {
 	userID := c.GetString("userID")
 
-	var booking entities.DiagnosticBooking
-	if err := c.ShouldBindJSON(&booking); err != nil {
+	var req struct {
+		LabID          string                   `json:"labId" binding:"required"`
+		DiagnosticsID  string                   `json:"diagnosticsId" binding:"required"`
+		Date           int64                    `json:"date" binding:"required"`
+		TimeSlot       string                   `json:"timeSlot" binding:"required"`
+		CollectionType string                   `json:"collectionType"`
+		Address        *entities.ServiceAddress `json:"address"`
+	}
+	if err := bindBookingRequest(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
-	booking.UserID = userID
+	// The address is only used for home collection
+	var address *entities.ServiceAddress
+	switch req.CollectionType {
+	case "", "lab":
+	case "home":
+		if req.Address == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "address is required for home collection"})
+			return
+		}
+		address = req.Address
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "collectionType must be lab or home"})
+		return
+	}
 
-	if err := h.diagnosticService.CreateBooking(c.Request.Context(), &booking); err != nil {
+	booking, err := h.diagnosticService.CreateBooking(c.Request.Context(), userID, req.LabID, req.DiagnosticsID, req.Date, req.TimeSlot, address)
+	if err != nil {
+		if errors.Is(err, services.ErrLabNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
 		return
 	}
@@ -70,8 +136,10 @@ func (h *DiagnosticHandler) CreateBooking(c *gin.Context) {
 
 func (h *DiagnosticHandler) VerifyPayment(c *gin.Context) {
 	var req struct {
-		BookingID string `json:"bookingId" binding:"required"`
-		PaymentID string `json:"paymentId" binding:"required"`
+		BookingID         string `json:"bookingId" binding:"required"`
+		RazorpayOrderID   string `json:"razorpayOrderId" binding:"required"`
+		RazorpayPaymentID string `json:"razorpayPaymentId" binding:"required"`
+		RazorpaySignature string `json:"razorpaySignature" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -79,13 +147,13 @@ func (h *DiagnosticHandler) VerifyPayment(c *gin.Context) {
 		return
 	}
 
-	if err := h.diagnosticService.VerifyPayment(c.Request.Context(), req.BookingID, req.PaymentID); err != nil {
+	if err := h.diagnosticService.VerifyPayment(c.Request.Context(), req.BookingID, req.RazorpayOrderID, req.RazorpayPaymentID, req.RazorpaySignature); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Payment verified successfully",
+		"message": message(c, i18n.MsgPaymentVerified),
 	})
 }
@@ -1,10 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,28 +19,51 @@ func NewDoctorHandler(doctorService *services.DoctorService) *DoctorHandler {
 	}
 }
 
+// GetDoctors searches approved doctors, best rated first unless sorted by fee
+// or experience, with facet counts over all matches
 func (h *DoctorHandler) GetDoctors(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	search := c.Query("search")
+	query, err := bindDoctorQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
 
-	doctors, total, err := h.doctorService.GetDoctors(c.Request.Context(), page, limit, search)
+	result, err := h.doctorService.SearchDoctors(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidSearch) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
-	totalPages := (int(total) + limit - 1) / limit
+	response := pageResponse(result.Doctors, query.Page, query.Limit, result.Total)
+	response["total_doctors"] = result.Total
+	response["facets"] = result.Facets
+	c.JSON(http.StatusOK, response)
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success":           true,
-		"data":              doctors,
-		"current_page":      page,
-		"total_pages":       totalPages,
-		"total_doctors":     total,
-		"has_next_page":     page < totalPages,
-		"has_previous_page": page > 1,
-	})
+// SearchInClinic lists doctors who see patients in person, nearest first when
+// lat and lng are given. service filters by specialization.
+func (h *DoctorHandler) SearchInClinic(c *gin.Context) {
+	query, err := bindGeoQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	doctors, total, err := h.doctorService.SearchInClinic(c.Request.Context(), query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidSearch) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, searchPage(doctors, query, total))
 }
 
 func (h *DoctorHandler) GetDoctorByID(c *gin.Context) {
@@ -47,7 +71,28 @@ func (h *DoctorHandler) GetDoctorByID(c *gin.Context) {
 
 	doctor, err := h.doctorService.GetDoctorByID(c.Request.Context(), doctorID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "Doctor not found"})
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": message(c, i18n.MsgDoctorNotFound)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    doctor,
+	})
+}
+
+// SetImage takes a multipart form with a JPEG, PNG or WebP image in "file" and
+// makes it the image of the doctor profile linked to the user's account
+func (h *DoctorHandler) SetImage(c *gin.Context) {
+	file, ok := imageUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	doctor, err := h.doctorService.SetImage(c.Request.Context(), c.GetString("userID"), file)
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+)
+
+// bindDoctorQuery reads the doctor search parameters: search, specialization,
+// language, sessionType, minFee, maxFee, date, minRating, sort, page and limit
+func bindDoctorQuery(c *gin.Context) (entities.DoctorQuery, error) {
+	query := entities.DoctorQuery{
+		Search:         c.Query("search"),
+		Specialization: c.Query("specialization"),
+		Language:       c.Query("language"),
+		SessionType:    c.Query("sessionType"),
+		SortBy:         c.Query("sort"),
+	}
+
+	var err error
+	if fee := c.Query("minFee"); fee != "" {
+		if query.MinFee, err = strconv.Atoi(fee); err != nil {
+			return query, errors.New("invalid minFee")
+		}
+	}
+	if fee := c.Query("maxFee"); fee != "" {
+		if query.MaxFee, err = strconv.Atoi(fee); err != nil {
+			return query, errors.New("invalid maxFee")
+		}
+	}
+	if date := c.Query("date"); date != "" {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return query, errors.New("invalid date, use YYYY-MM-DD")
+		}
+		query.AvailableOn = &day
+	}
+	if rating := c.Query("minRating"); rating != "" {
+		if query.MinRating, err = strconv.ParseFloat(rating, 64); err != nil {
+			return query, errors.New("invalid minRating")
+		}
+	}
+	if query.Page, query.Limit, err = bindPage(c); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
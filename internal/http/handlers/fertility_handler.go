@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FertilityHandler struct {
+	fertilityService *services.FertilityService
+}
+
+func NewFertilityHandler(fertilityService *services.FertilityService) *FertilityHandler {
+	return &FertilityHandler{
+		fertilityService: fertilityService,
+	}
+}
+
+func (h *FertilityHandler) AddLog(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
+		return
+	}
+
+	var log entities.FertilityLog
+	if err := c.ShouldBindJSON(&log); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	log.ID = primitive.NilObjectID
+	log.UserID = userOID
+
+	if err := h.fertilityService.AddLog(c.Request.Context(), &log); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    log,
+	})
+}
+
+func (h *FertilityHandler) GetLogs(c *gin.Context) {
+	userID := c.GetString("userID")
+	logType := c.Query("type")
+
+	// Convert string userID to ObjectID
+	_, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
+		return
+	}
+
+	logs, err := h.fertilityService.GetLogs(c.Request.Context(), userID, logType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    logs,
+	})
+}
+
+func (h *FertilityHandler) GetOvulationStatus(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	status, err := h.fertilityService.GetOvulationStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    status,
+	})
+}
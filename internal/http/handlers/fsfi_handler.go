@@ -5,22 +5,29 @@ import (
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type FSFIHandler struct {
-	fsfiService *services.FSFIService
+	fsfiService   *services.FSFIService
+	reportService *services.ReportService
 }
 
-func NewFSFIHandler(fsfiService *services.FSFIService) *FSFIHandler {
+func NewFSFIHandler(fsfiService *services.FSFIService, reportService *services.ReportService) *FSFIHandler {
 	return &FSFIHandler{
-		fsfiService: fsfiService,
+		fsfiService:   fsfiService,
+		reportService: reportService,
 	}
 }
 
 func (h *FSFIHandler) GetTest(c *gin.Context) {
-	test := h.fsfiService.GetTest()
+	test, err := h.fsfiService.GetTest(c.Request.Context(), locale(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -34,7 +41,7 @@ func (h *FSFIHandler) SubmitTest(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -46,14 +53,15 @@ func (h *FSFIHandler) SubmitTest(c *gin.Context) {
 
 	result.UserID = userOID
 
-	if err := h.fsfiService.SubmitTest(c.Request.Context(), &result); err != nil {
+	fsfiResult, err := h.fsfiService.SubmitTest(c.Request.Context(), &result)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data":    result,
+		"data":    fsfiResult,
 	})
 }
 
@@ -63,7 +71,7 @@ func (h *FSFIHandler) GetMyResults(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -78,3 +86,11 @@ func (h *FSFIHandler) GetMyResults(c *gin.Context) {
 		"data":    results,
 	})
 }
+
+// GetResultReport returns a printable PDF of one result
+func (h *FSFIHandler) GetResultReport(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	report, err := h.reportService.FSFIReport(c.Request.Context(), userID, c.Param("resultId"))
+	writePDF(c, "fsfi-"+c.Param("resultId")+".pdf", report, err)
+}
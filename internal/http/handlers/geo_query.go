@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+)
+
+// bindGeoQuery reads the discovery search parameters: lat and lng (together)
+// with radiusKm, city, pincode, service, minRating, sort, page and limit
+func bindGeoQuery(c *gin.Context) (entities.GeoQuery, error) {
+	query := entities.GeoQuery{
+		City:    c.Query("city"),
+		Pincode: c.Query("pincode"),
+		Service: c.Query("service"),
+		SortBy:  c.Query("sort"),
+	}
+
+	lat, lng := c.Query("lat"), c.Query("lng")
+	if (lat == "") != (lng == "") {
+		return query, errors.New("lat and lng must be given together")
+	}
+	if lat != "" {
+		latitude, err := strconv.ParseFloat(lat, 64)
+		if err != nil {
+			return query, errors.New("invalid lat")
+		}
+		longitude, err := strconv.ParseFloat(lng, 64)
+		if err != nil {
+			return query, errors.New("invalid lng")
+		}
+		query.Near = entities.NewGeoPoint(latitude, longitude)
+	}
+
+	var err error
+	if radius := c.Query("radiusKm"); radius != "" {
+		if query.Near == nil {
+			return query, errors.New("radiusKm needs lat and lng")
+		}
+		if query.RadiusKm, err = strconv.ParseFloat(radius, 64); err != nil {
+			return query, errors.New("invalid radiusKm")
+		}
+	}
+	if rating := c.Query("minRating"); rating != "" {
+		if query.MinRating, err = strconv.ParseFloat(rating, 64); err != nil {
+			return query, errors.New("invalid minRating")
+		}
+	}
+	if query.Page, query.Limit, err = bindPage(c); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// bindPage reads the page and limit parameters, defaulting to the first page
+// of 10
+func bindPage(c *gin.Context) (page, limit int, err error) {
+	if page, err = strconv.Atoi(c.DefaultQuery("page", "1")); err != nil || page < 1 {
+		return 0, 0, errors.New("page must be a positive number")
+	}
+	if limit, err = strconv.Atoi(c.DefaultQuery("limit", "10")); err != nil || limit < 1 || limit > 50 {
+		return 0, 0, errors.New("limit must be between 1 and 50")
+	}
+	return page, limit, nil
+}
+
+// searchPage is the response for one page of discovery results
+func searchPage(data interface{}, query entities.GeoQuery, total int64) gin.H {
+	return pageResponse(data, query.Page, query.Limit, total)
+}
+
+// pageResponse is the response for one page of a listing
+func pageResponse(data interface{}, page, limit int, total int64) gin.H {
+	totalPages := (int(total) + limit - 1) / limit
+	return gin.H{
+		"success":           true,
+		"data":              data,
+		"total":             total,
+		"current_page":      page,
+		"total_pages":       totalPages,
+		"has_next_page":     page < totalPages,
+		"has_previous_page": page > 1,
+	}
+}
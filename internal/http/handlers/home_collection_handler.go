@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/gin-gonic/gin"
+)
+
+// GetHomeCollectionAvailability reports whether a lab collects at a pincode and
+// its collection windows on a date
+func (h *DiagnosticHandler) GetHomeCollectionAvailability(c *gin.Context) {
+	availability, err := h.diagnosticService.HomeCollectionAvailability(c.Request.Context(), c.Param("labId"), c.Query("pincode"), c.Query("date"))
+	if err != nil {
+		if errors.Is(err, services.ErrLabNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    availability,
+	})
+}
+
+func (h *DiagnosticHandler) AddPhlebotomist(c *gin.Context) {
+	var req struct {
+		Name  string `json:"name" binding:"required"`
+		Phone string `json:"phone" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	phlebotomist, err := h.diagnosticService.AddPhlebotomist(c.Request.Context(), c.GetString("userID"), req.Name, req.Phone)
+	if err != nil {
+		c.JSON(homeCollectionErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    phlebotomist,
+	})
+}
+
+func (h *DiagnosticHandler) GetPhlebotomists(c *gin.Context) {
+	phlebotomists, err := h.diagnosticService.GetPhlebotomists(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		c.JSON(homeCollectionErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    phlebotomists,
+	})
+}
+
+// GetHomeCollections lists the lab's home collections on ?date=YYYY-MM-DD
+func (h *DiagnosticHandler) GetHomeCollections(c *gin.Context) {
+	bookings, err := h.diagnosticService.GetHomeCollections(c.Request.Context(), c.GetString("userID"), c.Query("date"))
+	if err != nil {
+		c.JSON(homeCollectionErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    bookings,
+	})
+}
+
+func (h *DiagnosticHandler) AssignPhlebotomist(c *gin.Context) {
+	var req struct {
+		PhlebotomistID string `json:"phlebotomistId" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	booking, err := h.diagnosticService.AssignPhlebotomist(c.Request.Context(), c.GetString("userID"), c.Param("bookingId"), req.PhlebotomistID)
+	if err != nil {
+		c.JSON(homeCollectionErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    booking,
+	})
+}
+
+func (h *DiagnosticHandler) UpdateCollectionStatus(c *gin.Context) {
+	var req struct {
+		Status string `json:"status" binding:"required"`
+		Note   string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	booking, err := h.diagnosticService.UpdateCollectionStatus(c.Request.Context(), c.GetString("userID"), c.Param("bookingId"), req.Status, req.Note)
+	if err != nil {
+		c.JSON(homeCollectionErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    booking,
+	})
+}
+
+// homeCollectionErrorStatus maps the lab-side errors; the rest are bad requests
+func homeCollectionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNotLab):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrCollectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrCollectionStatusChanged):
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
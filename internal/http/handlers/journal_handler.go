@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -83,7 +84,7 @@ func (h *JournalHandler) GetJournalByID(c *gin.Context) {
 
 	journal, err := h.journalService.GetJournalByID(c.Request.Context(), journalID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "Journal not found"})
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": message(c, i18n.MsgJournalNotFound)})
 		return
 	}
 
@@ -128,7 +129,7 @@ func (h *JournalHandler) DeleteJournal(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Journal deleted successfully",
+		"message": message(c, i18n.MsgJournalDeleted),
 	})
 }
 
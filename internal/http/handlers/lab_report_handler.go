@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// UploadReport takes a multipart form with the report PDF in "file", and
+// optionally a JSON array of analyte values in "analytes" and "notes"
+func (h *DiagnosticHandler) UploadReport(c *gin.Context) {
+	// Leave room for the form fields around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.MaxDocumentSize+1<<20)
+
+	header, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"success": false, "message": fmt.Sprintf("the report must be at most %d MB", services.MaxDocumentSize>>20)})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "a PDF file is required in the file field"})
+		return
+	}
+	report := entities.LabReport{
+		FileName: header.Filename,
+		Notes:    c.PostForm("notes"),
+	}
+	if analytes := c.PostForm("analytes"); analytes != "" {
+		if err := json.Unmarshal([]byte(analytes), &report.Analytes); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "analytes must be a JSON array: " + err.Error()})
+			return
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	saved, err := h.diagnosticService.UploadReport(c.Request.Context(), c.GetString("userID"), c.Param("bookingId"), &report, file)
+	if err != nil {
+		c.JSON(labReportErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    saved,
+	})
+}
+
+func (h *DiagnosticHandler) GetMyReports(c *gin.Context) {
+	reports, err := h.diagnosticService.GetMyReports(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    reports,
+	})
+}
+
+func (h *DiagnosticHandler) GetReport(c *gin.Context) {
+	report, err := h.diagnosticService.GetReport(c.Request.Context(), c.GetString("userID"), c.Param("reportId"))
+	if err != nil {
+		c.JSON(labReportErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// DownloadReport sends the report PDF as a download
+func (h *DiagnosticHandler) DownloadReport(c *gin.Context) {
+	report, file, err := h.diagnosticService.OpenReportFile(c.Request.Context(), c.GetString("userID"), c.Param("reportId"))
+	if err != nil {
+		c.JSON(labReportErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Disposition", `attachment; filename="`+report.FileName+`"`)
+	c.Header("Content-Length", strconv.FormatInt(report.FileSize, 10))
+	c.Header("Content-Type", "application/pdf")
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, file)
+}
+
+// GetAnalyteTrend returns one analyte's values over time, for
+// ?analyte=TSH&from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *DiagnosticHandler) GetAnalyteTrend(c *gin.Context) {
+	var from, to *time.Time
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidFromDate)})
+			return
+		}
+		from = &parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidToDate)})
+			return
+		}
+		// Include the whole end day
+		endOfDay := parsed.Add(24*time.Hour - time.Nanosecond)
+		to = &endOfDay
+	}
+
+	trend, err := h.diagnosticService.GetAnalyteTrend(c.Request.Context(), c.GetString("userID"), c.Query("analyte"), from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    trend,
+	})
+}
+
+// labReportErrorStatus maps report errors; the rest are bad requests
+func labReportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNotLab):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrLabReportNotFound), errors.Is(err, services.ErrDiagnosticsBookingNotFound):
+		return http.StatusNotFound
+	}
+	return mediaErrorStatus(err)
+}
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"github.com/anshjamwal15/hsb_backend/internal/http/middleware"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// locale returns the response language picked by the locale middleware
+func locale(c *gin.Context) string {
+	return middleware.Locale(c)
+}
+
+// message returns a user-facing message in the response language
+func message(c *gin.Context, key string) string {
+	return i18n.Message(locale(c), key)
+}
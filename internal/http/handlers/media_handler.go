@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	mediaService *services.MediaService
+}
+
+func NewMediaHandler(mediaService *services.MediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+// UploadImage takes a multipart form with a JPEG, PNG or WebP image in "file"
+// and returns its key, for community posts and profile images
+func (h *MediaHandler) UploadImage(c *gin.Context) {
+	file, ok := imageUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	object, err := h.mediaService.UploadImage(c.Request.Context(), c.GetString("userID"), file)
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    object,
+	})
+}
+
+// ServeFile sends a stored file through a signed URL from local storage
+func (h *MediaHandler) ServeFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	file, contentType, err := h.mediaService.OpenSigned(c.Request.Context(), key, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": services.ErrMediaNotFound.Error()})
+		return
+	}
+	defer file.Close()
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("Content-Type", contentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, file)
+}
+
+// imageUpload opens the image in a multipart form's "file" field, writing the
+// error response when there is none
+func imageUpload(c *gin.Context) (multipart.File, bool) {
+	// Leave room for the form around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.MaxImageSize+1<<20)
+
+	header, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"success": false, "message": fmt.Sprintf("the image must be at most %d MB", services.MaxImageSize>>20)})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "an image is required in the file field"})
+		return nil, false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return nil, false
+	}
+	return file, true
+}
+
+// mediaErrorStatus maps upload errors; the rest are bad requests
+func mediaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedMedia):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, services.ErrMediaTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, services.ErrMediaNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrNoDoctorProfile):
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
+}
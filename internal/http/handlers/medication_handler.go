@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MedicationHandler struct {
+	medicationService *services.MedicationService
+}
+
+func NewMedicationHandler(medicationService *services.MedicationService) *MedicationHandler {
+	return &MedicationHandler{
+		medicationService: medicationService,
+	}
+}
+
+func (h *MedicationHandler) CreateRegimen(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
+		return
+	}
+
+	var regimen entities.MedicationRegimen
+	if err := c.ShouldBindJSON(&regimen); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	regimen.ID = primitive.NilObjectID
+	regimen.UserID = userOID
+
+	if err := h.medicationService.CreateRegimen(c.Request.Context(), &regimen); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    regimen,
+	})
+}
+
+func (h *MedicationHandler) GetRegimens(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	regimens, err := h.medicationService.GetRegimens(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    regimens,
+	})
+}
+
+func (h *MedicationHandler) StopRegimen(c *gin.Context) {
+	userID := c.GetString("userID")
+	regimenID := c.Param("regimenId")
+
+	if err := h.medicationService.StopRegimen(c.Request.Context(), userID, regimenID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message(c, i18n.MsgMedicationStopped),
+	})
+}
+
+func (h *MedicationHandler) LogDose(c *gin.Context) {
+	userID := c.GetString("userID")
+	regimenID := c.Param("regimenId")
+
+	var req struct {
+		ScheduledAt time.Time  `json:"scheduledAt" binding:"required"`
+		Status      string     `json:"status" binding:"required"`
+		TakenAt     *time.Time `json:"takenAt"`
+		Notes       string     `json:"notes"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	log, err := h.medicationService.LogDose(c.Request.Context(), userID, regimenID, req.ScheduledAt, req.Status, req.TakenAt, req.Notes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    log,
+	})
+}
+
+func (h *MedicationHandler) GetDoses(c *gin.Context) {
+	userID := c.GetString("userID")
+	regimenID := c.Param("regimenId")
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "7"))
+
+	doses, err := h.medicationService.GetDoses(c.Request.Context(), userID, regimenID, days)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    doses,
+	})
+}
+
+func (h *MedicationHandler) GetAdherence(c *gin.Context) {
+	userID := c.GetString("userID")
+	regimenID := c.Param("regimenId")
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+
+	stats, err := h.medicationService.GetAdherence(c.Request.Context(), userID, regimenID, days)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    stats,
+	})
+}
+
+func (h *MedicationHandler) GetUpcomingDoses(c *gin.Context) {
+	userID := c.GetString("userID")
+	hours, _ := strconv.Atoi(c.DefaultQuery("hours", "24"))
+
+	doses, err := h.medicationService.GetUpcomingDoses(c.Request.Context(), userID, hours)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    doses,
+	})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MenopauseHandler struct {
+	menopauseService *services.MenopauseService
+}
+
+func NewMenopauseHandler(menopauseService *services.MenopauseService) *MenopauseHandler {
+	return &MenopauseHandler{
+		menopauseService: menopauseService,
+	}
+}
+
+func (h *MenopauseHandler) StartPerimenopause(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	status, err := h.menopauseService.StartPerimenopause(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    status,
+	})
+}
+
+func (h *MenopauseHandler) GetStatus(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	status, err := h.menopauseService.GetStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    status,
+	})
+}
+
+func (h *MenopauseHandler) StopTracking(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	if err := h.menopauseService.StopTracking(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message(c, i18n.MsgMenopauseTrackingOff),
+	})
+}
+
+func (h *MenopauseHandler) LogSymptom(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
+		return
+	}
+
+	var log entities.VasomotorLog
+	if err := c.ShouldBindJSON(&log); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	log.ID = primitive.NilObjectID
+	log.UserID = userOID
+
+	if err := h.menopauseService.LogSymptom(c.Request.Context(), &log); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    log,
+	})
+}
+
+func (h *MenopauseHandler) GetSymptoms(c *gin.Context) {
+	userID := c.GetString("userID")
+	logType := c.Query("type")
+
+	logs, err := h.menopauseService.GetSymptoms(c.Request.Context(), userID, logType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    logs,
+	})
+}
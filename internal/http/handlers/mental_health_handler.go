@@ -5,22 +5,29 @@ import (
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type MentalHealthHandler struct {
 	mentalHealthService *services.MentalHealthService
+	reportService       *services.ReportService
 }
 
-func NewMentalHealthHandler(mentalHealthService *services.MentalHealthService) *MentalHealthHandler {
+func NewMentalHealthHandler(mentalHealthService *services.MentalHealthService, reportService *services.ReportService) *MentalHealthHandler {
 	return &MentalHealthHandler{
 		mentalHealthService: mentalHealthService,
+		reportService:       reportService,
 	}
 }
 
 func (h *MentalHealthHandler) GetTests(c *gin.Context) {
-	tests := h.mentalHealthService.GetTests()
+	tests, err := h.mentalHealthService.GetTests(c.Request.Context(), c.Query("category"), locale(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -31,9 +38,9 @@ func (h *MentalHealthHandler) GetTests(c *gin.Context) {
 func (h *MentalHealthHandler) GetTestByName(c *gin.Context) {
 	testName := c.Param("testName")
 
-	test := h.mentalHealthService.GetTestByName(testName)
-	if test == nil {
-		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "Test not found"})
+	test, err := h.mentalHealthService.GetTestByName(c.Request.Context(), testName, locale(c))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": message(c, i18n.MsgTestNotFound)})
 		return
 	}
 
@@ -49,7 +56,7 @@ func (h *MentalHealthHandler) SubmitTestResults(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -60,16 +67,23 @@ func (h *MentalHealthHandler) SubmitTestResults(c *gin.Context) {
 	}
 
 	result.UserID = userOID
+	result.Locale = locale(c)
 
-	if err := h.mentalHealthService.SubmitTestResults(c.Request.Context(), &result); err != nil {
+	localized, crisis, err := h.mentalHealthService.SubmitTestResults(c.Request.Context(), &result)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	response := gin.H{
 		"success": true,
-		"data":    result,
-	})
+		"data":    localized,
+	}
+	if crisis != nil {
+		response["crisis"] = crisis
+	}
+
+	c.JSON(http.StatusCreated, response)
 }
 
 func (h *MentalHealthHandler) GetTestResults(c *gin.Context) {
@@ -79,11 +93,11 @@ func (h *MentalHealthHandler) GetTestResults(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
-	results, err := h.mentalHealthService.GetTestResults(c.Request.Context(), userOID.Hex(), testName)
+	results, err := h.mentalHealthService.GetTestResults(c.Request.Context(), userOID.Hex(), testName, locale(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
 		return
@@ -94,3 +108,11 @@ func (h *MentalHealthHandler) GetTestResults(c *gin.Context) {
 		"data":    results,
 	})
 }
+
+// GetResultReport returns a printable PDF of one result
+func (h *MentalHealthHandler) GetResultReport(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	report, err := h.reportService.AssessmentReport(c.Request.Context(), userID, c.Param("resultId"))
+	writePDF(c, "assessment-"+c.Param("resultId")+".pdf", report, err)
+}
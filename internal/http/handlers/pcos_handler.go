@@ -5,22 +5,29 @@ import (
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type PCOSHandler struct {
-	pcosService *services.PCOSService
+	pcosService   *services.PCOSService
+	reportService *services.ReportService
 }
 
-func NewPCOSHandler(pcosService *services.PCOSService) *PCOSHandler {
+func NewPCOSHandler(pcosService *services.PCOSService, reportService *services.ReportService) *PCOSHandler {
 	return &PCOSHandler{
-		pcosService: pcosService,
+		pcosService:   pcosService,
+		reportService: reportService,
 	}
 }
 
 func (h *PCOSHandler) GetQuestions(c *gin.Context) {
-	questions := h.pcosService.GetQuestions()
+	questions, err := h.pcosService.GetQuestions(c.Request.Context(), locale(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -34,7 +41,7 @@ func (h *PCOSHandler) SubmitAssessment(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -63,7 +70,7 @@ func (h *PCOSHandler) GetHistory(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -85,7 +92,7 @@ func (h *PCOSHandler) GetLatestAssessment(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -100,3 +107,55 @@ func (h *PCOSHandler) GetLatestAssessment(c *gin.Context) {
 		"data":    assessment,
 	})
 }
+
+func (h *PCOSHandler) AddUltrasound(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
+		return
+	}
+
+	var ultrasound entities.OvarianUltrasound
+	if err := c.ShouldBindJSON(&ultrasound); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	ultrasound.UserID = userOID
+
+	if err := h.pcosService.AddUltrasound(c.Request.Context(), &ultrasound); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    ultrasound,
+	})
+}
+
+func (h *PCOSHandler) GetUltrasounds(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	ultrasounds, err := h.pcosService.GetUltrasounds(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    ultrasounds,
+	})
+}
+
+// GetAssessmentReport returns a printable PDF of one assessment
+func (h *PCOSHandler) GetAssessmentReport(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	report, err := h.reportService.PCOSReport(c.Request.Context(), userID, c.Param("assessmentId"))
+	writePDF(c, "pcos-"+c.Param("assessmentId")+".pdf", report, err)
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/gin-gonic/gin"
+)
+
+// writePDF sends a generated report as a download
+func writePDF(c *gin.Context, filename string, report []byte, err error) {
+	if errors.Is(err, services.ErrReportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/pdf", report)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -25,7 +26,7 @@ func (h *PeriodHandler) GetPeriodCycle(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -47,7 +48,7 @@ func (h *PeriodHandler) AddPeriodCycle(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -76,7 +77,7 @@ func (h *PeriodHandler) ResetPeriodTracker(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -87,6 +88,28 @@ func (h *PeriodHandler) ResetPeriodTracker(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Period tracker reset successfully",
+		"message": message(c, i18n.MsgPeriodTrackerReset),
+	})
+}
+
+func (h *PeriodHandler) GetPrediction(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
+		return
+	}
+
+	prediction, err := h.periodService.GetPrediction(c.Request.Context(), userOID.Hex())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    prediction,
 	})
 }
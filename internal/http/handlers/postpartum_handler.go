@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PostpartumHandler struct {
+	postpartumService *services.PostpartumService
+}
+
+func NewPostpartumHandler(postpartumService *services.PostpartumService) *PostpartumHandler {
+	return &PostpartumHandler{
+		postpartumService: postpartumService,
+	}
+}
+
+func (h *PostpartumHandler) StartPostpartum(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req struct {
+		DeliveryDate string `json:"deliveryDate" binding:"required"`
+		DeliveryType string `json:"deliveryType"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	deliveryDate, err := time.Parse("2006-01-02", req.DeliveryDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidDate)})
+		return
+	}
+
+	tracker, err := h.postpartumService.StartPostpartum(c.Request.Context(), userID, deliveryDate, req.DeliveryType)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tracker,
+	})
+}
+
+func (h *PostpartumHandler) GetSummary(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	summary, err := h.postpartumService.GetSummary(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    summary,
+	})
+}
+
+func (h *PostpartumHandler) AddEntry(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
+		return
+	}
+
+	var entry entities.PostpartumEntry
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	entry.ID = primitive.NilObjectID
+	entry.UserID = userOID
+
+	if err := h.postpartumService.AddEntry(c.Request.Context(), &entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    entry,
+	})
+}
+
+func (h *PostpartumHandler) GetEntries(c *gin.Context) {
+	userID := c.GetString("userID")
+	entryType := c.Query("type")
+
+	// Convert string userID to ObjectID
+	_, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
+		return
+	}
+
+	entries, err := h.postpartumService.GetEntries(c.Request.Context(), userID, entryType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    entries,
+	})
+}
@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -25,7 +27,7 @@ func (h *PregnancyHandler) GetPregnancyData(c *gin.Context) {
 	// Convert string userID to ObjectID
 	_, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -47,7 +49,7 @@ func (h *PregnancyHandler) AddPregnancyEntry(c *gin.Context) {
 	// Convert string userID to ObjectID
 	userOID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidUserID)})
 		return
 	}
 
@@ -69,3 +71,99 @@ func (h *PregnancyHandler) AddPregnancyEntry(c *gin.Context) {
 		"data":    tracker,
 	})
 }
+
+func (h *PregnancyHandler) StartSession(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req struct {
+		Type string `json:"type" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	session, err := h.pregnancyService.StartSession(c.Request.Context(), userID, req.Type)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    session,
+	})
+}
+
+func (h *PregnancyHandler) RecordSessionEvent(c *gin.Context) {
+	userID := c.GetString("userID")
+	sessionID := c.Param("sessionId")
+
+	var req struct {
+		StartedAt *time.Time `json:"startedAt"`
+		EndedAt   *time.Time `json:"endedAt"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	summary, err := h.pregnancyService.RecordSessionEvent(c.Request.Context(), userID, sessionID, req.StartedAt, req.EndedAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    summary,
+	})
+}
+
+func (h *PregnancyHandler) StopSession(c *gin.Context) {
+	userID := c.GetString("userID")
+	sessionID := c.Param("sessionId")
+
+	summary, err := h.pregnancyService.StopSession(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    summary,
+	})
+}
+
+func (h *PregnancyHandler) GetSession(c *gin.Context) {
+	userID := c.GetString("userID")
+	sessionID := c.Param("sessionId")
+
+	summary, err := h.pregnancyService.GetSession(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    summary,
+	})
+}
+
+func (h *PregnancyHandler) GetSessions(c *gin.Context) {
+	userID := c.GetString("userID")
+	sessionType := c.Query("type")
+
+	summaries, err := h.pregnancyService.GetSessions(c.Request.Context(), userID, sessionType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    summaries,
+	})
+}
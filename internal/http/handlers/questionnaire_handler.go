@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+type QuestionnaireHandler struct {
+	questionnaireService *services.QuestionnaireService
+}
+
+func NewQuestionnaireHandler(questionnaireService *services.QuestionnaireService) *QuestionnaireHandler {
+	return &QuestionnaireHandler{
+		questionnaireService: questionnaireService,
+	}
+}
+
+func (h *QuestionnaireHandler) GetQuestionnaires(c *gin.Context) {
+	tests, err := h.questionnaireService.GetDefinitions(c.Request.Context(), c.Query("category"))
+	if err == nil {
+		tests, err = h.questionnaireService.LocalizeAll(c.Request.Context(), tests, locale(c))
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tests,
+	})
+}
+
+func (h *QuestionnaireHandler) GetQuestionnaire(c *gin.Context) {
+	name := c.Param("name")
+	version, _ := strconv.Atoi(c.DefaultQuery("version", "0"))
+
+	test, err := h.questionnaireService.GetDefinition(c.Request.Context(), name, version)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	test, err = h.questionnaireService.Localize(c.Request.Context(), test, locale(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    test,
+	})
+}
+
+func (h *QuestionnaireHandler) GetVersions(c *gin.Context) {
+	name := c.Param("name")
+
+	tests, err := h.questionnaireService.GetVersions(c.Request.Context(), name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tests,
+	})
+}
+
+// GetMissingTranslations reports the questionnaire text and messages not yet
+// translated into ?locale=, or into every supported language when it is omitted
+func (h *QuestionnaireHandler) GetMissingTranslations(c *gin.Context) {
+	locales := []string{}
+	if requested := c.Query("locale"); requested != "" {
+		normalized, ok := i18n.Normalize(requested)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgUnsupportedLanguage)})
+			return
+		}
+		locales = append(locales, normalized)
+	} else {
+		for _, supported := range i18n.SupportedLocales {
+			if supported != i18n.DefaultLocale {
+				locales = append(locales, supported)
+			}
+		}
+	}
+
+	reports := make([]*entities.TranslationReport, 0, len(locales))
+	for _, requested := range locales {
+		report, err := h.questionnaireService.MissingTranslations(c.Request.Context(), requested)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+			return
+		}
+		reports = append(reports, report)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    reports,
+	})
+}
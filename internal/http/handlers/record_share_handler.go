@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+)
+
+type RecordShareHandler struct {
+	shareService *services.RecordShareService
+}
+
+func NewRecordShareHandler(shareService *services.RecordShareService) *RecordShareHandler {
+	return &RecordShareHandler{
+		shareService: shareService,
+	}
+}
+
+// GrantAccess shares the chosen records with the doctor of a booking
+func (h *RecordShareHandler) GrantAccess(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req entities.RecordShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	share, err := h.shareService.Grant(c.Request.Context(), userID, &req, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    share,
+	})
+}
+
+func (h *RecordShareHandler) GetShares(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	shares, err := h.shareService.GetShares(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    shares,
+	})
+}
+
+func (h *RecordShareHandler) RevokeAccess(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	share, err := h.shareService.Revoke(c.Request.Context(), userID, c.Param("shareId"), c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    share,
+	})
+}
+
+func (h *RecordShareHandler) GetAccessLog(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	entries, err := h.shareService.GetAccessLog(c.Request.Context(), userID, c.Param("shareId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    entries,
+	})
+}
+
+// GetSharedRecords lists the active shares for the signed-in doctor
+func (h *RecordShareHandler) GetSharedRecords(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	shares, err := h.shareService.GetSharedWithDoctor(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(shareErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    shares,
+	})
+}
+
+// GetSummary returns the pre-consultation summary of a share for the signed-in doctor
+func (h *RecordShareHandler) GetSummary(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	summary, err := h.shareService.GetSummary(c.Request.Context(), userID, c.Param("shareId"), c.ClientIP())
+	if err != nil {
+		c.JSON(shareErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    summary,
+	})
+}
+
+func shareErrorStatus(err error) int {
+	if errors.Is(err, services.ErrNotDoctor) || errors.Is(err, services.ErrShareNotAccessible) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
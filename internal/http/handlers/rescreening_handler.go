@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/gin-gonic/gin"
+)
+
+type RescreeningHandler struct {
+	rescreeningService *services.RescreeningService
+}
+
+func NewRescreeningHandler(rescreeningService *services.RescreeningService) *RescreeningHandler {
+	return &RescreeningHandler{
+		rescreeningService: rescreeningService,
+	}
+}
+
+// GetDueAssessments returns the assessments the user is due to retake. Pass
+// upcoming=true to include ones that are not yet due.
+func (h *RescreeningHandler) GetDueAssessments(c *gin.Context) {
+	userID := c.GetString("userID")
+	includeUpcoming := c.Query("upcoming") == "true"
+
+	assessments, err := h.rescreeningService.GetDueAssessments(c.Request.Context(), userID, includeUpcoming)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    assessments,
+	})
+}
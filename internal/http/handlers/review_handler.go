@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	reviewService *services.ReviewService
+}
+
+func NewReviewHandler(reviewService *services.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *ReviewHandler) GetTags(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.reviewService.ReviewTags(),
+	})
+}
+
+// CreateReview reviews the doctor, clinic or lab of one of the user's
+// completed bookings
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	var req struct {
+		TargetType string   `json:"targetType" binding:"required"`
+		BookingID  string   `json:"bookingId" binding:"required"`
+		Rating     int      `json:"rating" binding:"required"`
+		Text       string   `json:"text"`
+		Tags       []string `json:"tags"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	review := entities.Review{
+		TargetType: req.TargetType,
+		Rating:     req.Rating,
+		Text:       req.Text,
+		Tags:       req.Tags,
+	}
+	created, err := h.reviewService.CreateReview(c.Request.Context(), c.GetString("userID"), req.BookingID, &review)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    created,
+	})
+}
+
+func (h *ReviewHandler) GetMyReviews(c *gin.Context) {
+	reviews, err := h.reviewService.GetMyReviews(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    reviews,
+	})
+}
+
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	if err := h.reviewService.DeleteReview(c.Request.Context(), c.GetString("userID"), c.Param("reviewId")); err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Review deleted"})
+}
+
+// GetDoctorReviews, GetClinicReviews and GetLabReviews list published
+// reviews, newest first
+func (h *ReviewHandler) GetDoctorReviews(c *gin.Context) {
+	h.getReviews(c, "doctor", c.Param("doctorId"))
+}
+
+func (h *ReviewHandler) GetClinicReviews(c *gin.Context) {
+	h.getReviews(c, "clinic", c.Param("clinicId"))
+}
+
+func (h *ReviewHandler) GetLabReviews(c *gin.Context) {
+	h.getReviews(c, "lab", c.Param("labId"))
+}
+
+func (h *ReviewHandler) getReviews(c *gin.Context, targetType, targetID string) {
+	page, limit, err := bindPage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	reviews, total, err := h.reviewService.GetReviews(c.Request.Context(), targetType, targetID, page, limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, pageResponse(reviews, page, limit, total))
+}
+
+// GetOwnDoctorReviews lists the published reviews of the doctor's own profile
+func (h *ReviewHandler) GetOwnDoctorReviews(c *gin.Context) {
+	page, limit, err := bindPage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	reviews, total, err := h.reviewService.GetDoctorReviews(c.Request.Context(), c.GetString("userID"), page, limit)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, pageResponse(reviews, page, limit, total))
+}
+
+// RespondToReview sets the doctor's reply to a review; empty text removes it
+func (h *ReviewHandler) RespondToReview(c *gin.Context) {
+	var req struct {
+		Text string `json:"text"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	review, err := h.reviewService.RespondToReview(c.Request.Context(), c.GetString("userID"), c.Param("reviewId"), req.Text)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    review,
+	})
+}
+
+// GetModerationQueue lists reviews by ?status=, pending by default, oldest first
+func (h *ReviewHandler) GetModerationQueue(c *gin.Context) {
+	page, limit, err := bindPage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	reviews, total, err := h.reviewService.GetModerationQueue(c.Request.Context(), c.GetString("userID"), c.Query("status"), page, limit)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, pageResponse(reviews, page, limit, total))
+}
+
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	var req struct {
+		Status string `json:"status" binding:"required"`
+		Note   string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	review, err := h.reviewService.ModerateReview(c.Request.Context(), c.GetString("userID"), c.Param("reviewId"), req.Status, req.Note)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    review,
+	})
+}
+
+// reviewErrorStatus maps review errors; the rest are bad requests
+func reviewErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNotModerator), errors.Is(err, services.ErrNoDoctorProfile):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrReviewNotFound), errors.Is(err, services.ErrReviewBookingNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrAlreadyReviewed), errors.Is(err, services.ErrReviewStatusChanged):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrRatingNotUpdated):
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -28,7 +30,7 @@ func (h *TimeSlotHandler) GetAvailableTimeSlots(c *gin.Context) {
 	if doctorID == "" || dateStr == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"message": "doctorId and date are required",
+			"message": message(c, i18n.MsgDoctorAndDateRequired),
 		})
 		return
 	}
@@ -38,7 +40,7 @@ func (h *TimeSlotHandler) GetAvailableTimeSlots(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"message": "Invalid date format. Use YYYY-MM-DD",
+			"message": message(c, i18n.MsgInvalidDateUseISO),
 		})
 		return
 	}
@@ -48,7 +50,7 @@ func (h *TimeSlotHandler) GetAvailableTimeSlots(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
-			"message": "Doctor not found",
+			"message": message(c, i18n.MsgDoctorNotFound),
 		})
 		return
 	}
@@ -58,7 +60,7 @@ func (h *TimeSlotHandler) GetAvailableTimeSlots(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"success": true,
 			"data":    []interface{}{},
-			"message": "Doctor is not available",
+			"message": message(c, i18n.MsgDoctorNotAvailable),
 		})
 		return
 	}
@@ -92,9 +94,9 @@ func generateTimeSlots(timing interface{}, date time.Time) []TimeSlot {
 	var slots []TimeSlot
 
 	// Default working hours if not specified
-	startHour := 9
-	endHour := 18
-	slotDuration := 30 // minutes
+	startHour := entities.ConsultationDayStartHour
+	endHour := entities.ConsultationDayEndHour
+	slotDuration := entities.ConsultationSlotMinutes
 
 	// Parse timing if available
 	if timing != nil {
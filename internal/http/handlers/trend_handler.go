@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+type TrendHandler struct {
+	trendService *services.TrendService
+}
+
+func NewTrendHandler(trendService *services.TrendService) *TrendHandler {
+	return &TrendHandler{
+		trendService: trendService,
+	}
+}
+
+// GetTrend returns the user's results for one instrument over time
+func (h *TrendHandler) GetTrend(c *gin.Context) {
+	userID := c.GetString("userID")
+	testName := c.Param("testName")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	var from, to *time.Time
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidFromDate)})
+			return
+		}
+		from = &parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgInvalidToDate)})
+			return
+		}
+		// Include the whole end day
+		endOfDay := parsed.Add(24*time.Hour - time.Nanosecond)
+		to = &endOfDay
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	trend, total, err := h.trendService.GetTrend(c.Request.Context(), userID, testName, from, to, page, limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    trend,
+		"total":   total,
+		"page":    page,
+		"pages":   totalPages,
+	})
+}
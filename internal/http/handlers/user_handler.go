@@ -1,19 +1,24 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
-	userService *services.UserService
+	userService   *services.UserService
+	reportService *services.ReportService
 }
 
-func NewUserHandler(userService *services.UserService) *UserHandler {
+func NewUserHandler(userService *services.UserService, reportService *services.ReportService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:   userService,
+		reportService: reportService,
 	}
 }
 
@@ -22,19 +27,13 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 
 	user, err := h.userService.GetProfile(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "User not found"})
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": message(c, i18n.MsgUserNotFound)})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data": gin.H{
-			"id":           user.ID.Hex(),
-			"name":         user.Name,
-			"email":        user.Email,
-			"phoneNumber":  user.PhoneNumber,
-			"profileImage": user.ProfileImage,
-		},
+		"data":    profileData(user),
 	})
 }
 
@@ -42,29 +41,71 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	userID := c.GetString("userID")
 
 	var req struct {
-		Name         string `json:"name"`
-		PhoneNumber  string `json:"phoneNumber"`
-		ProfileImage string `json:"profileImage"`
+		Name              string `json:"name"`
+		PhoneNumber       string `json:"phoneNumber"`
+		ProfileImage      string `json:"profileImage"`
+		PreferredLanguage string `json:"preferredLanguage"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
-	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, req.Name, req.PhoneNumber, req.ProfileImage)
+	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, req.Name, req.PhoneNumber, req.ProfileImage, req.PreferredLanguage)
 	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedLanguage) {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": message(c, i18n.MsgUnsupportedLanguage)})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data": gin.H{
-			"id":           user.ID.Hex(),
-			"name":         user.Name,
-			"email":        user.Email,
-			"phoneNumber":  user.PhoneNumber,
-			"profileImage": user.ProfileImage,
-		},
+		"data":    profileData(user),
 	})
 }
+
+// SetProfileImage takes a multipart form with a JPEG, PNG or WebP image in
+// "file" and makes it the profile image
+func (h *UserHandler) SetProfileImage(c *gin.Context) {
+	file, ok := imageUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	user, err := h.userService.SetProfileImage(c.Request.Context(), c.GetString("userID"), file)
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    profileData(user),
+	})
+}
+
+// GetHealthSummaryReport returns a printable PDF of cycle statistics, weight trend and symptoms
+func (h *UserHandler) GetHealthSummaryReport(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	report, err := h.reportService.HealthSummaryReport(c.Request.Context(), userID)
+	writePDF(c, "health-summary.pdf", report, err)
+}
+
+func profileData(user *entities.User) gin.H {
+	return gin.H{
+		"id":                user.ID.Hex(),
+		"name":              user.Name,
+		"email":             user.Email,
+		"phoneNumber":       user.PhoneNumber,
+		"profileImage":      user.ProfileImage,
+		"profileImageUrl":   user.ProfileImageURL,
+		"thumbnailUrl":      user.ThumbnailURL,
+		"trackingMode":      user.TrackingMode,
+		"preferredLanguage": user.PreferredLanguage,
+	}
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -23,7 +25,7 @@ func (h *UserProfileHandler) GetProfile(c *gin.Context) {
 	if userID == "" {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"success": false,
-			"message": "unauthorized",
+			"message": message(c, i18n.MsgUnauthorized),
 		})
 		return
 	}
@@ -32,7 +34,7 @@ func (h *UserProfileHandler) GetProfile(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
-			"message": "user not found",
+			"message": message(c, i18n.MsgUserNotFound),
 		})
 		return
 	}
@@ -40,11 +42,15 @@ func (h *UserProfileHandler) GetProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data": gin.H{
-			"id":           user.ID.Hex(),
-			"name":         user.Name,
-			"email":        user.Email,
-			"phoneNumber":  user.PhoneNumber,
-			"profileImage": user.ProfileImage,
+			"id":                user.ID.Hex(),
+			"name":              user.Name,
+			"email":             user.Email,
+			"phoneNumber":       user.PhoneNumber,
+			"profileImage":      user.ProfileImage,
+			"profileImageUrl":   user.ProfileImageURL,
+			"thumbnailUrl":      user.ThumbnailURL,
+			"trackingMode":      user.TrackingMode,
+			"preferredLanguage": user.PreferredLanguage,
 		},
 	})
 }
@@ -55,15 +61,16 @@ func (h *UserProfileHandler) UpdateProfile(c *gin.Context) {
 	if userID == "" {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"success": false,
-			"message": "unauthorized",
+			"message": message(c, i18n.MsgUnauthorized),
 		})
 		return
 	}
 
 	var req struct {
-		Name         string `json:"name"`
-		PhoneNumber  string `json:"phoneNumber"`
-		ProfileImage string `json:"profileImage"`
+		Name              string `json:"name"`
+		PhoneNumber       string `json:"phoneNumber"`
+		ProfileImage      string `json:"profileImage"`
+		PreferredLanguage string `json:"preferredLanguage"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -74,8 +81,15 @@ func (h *UserProfileHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, req.Name, req.PhoneNumber, req.ProfileImage)
+	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, req.Name, req.PhoneNumber, req.ProfileImage, req.PreferredLanguage)
 	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedLanguage) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"message": message(c, i18n.MsgUnsupportedLanguage),
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
 			"message": err.Error(),
@@ -86,11 +100,15 @@ func (h *UserProfileHandler) UpdateProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data": gin.H{
-			"id":           user.ID.Hex(),
-			"name":         user.Name,
-			"email":        user.Email,
-			"phoneNumber":  user.PhoneNumber,
-			"profileImage": user.ProfileImage,
+			"id":                user.ID.Hex(),
+			"name":              user.Name,
+			"email":             user.Email,
+			"phoneNumber":       user.PhoneNumber,
+			"profileImage":      user.ProfileImage,
+			"profileImageUrl":   user.ProfileImageURL,
+			"thumbnailUrl":      user.ThumbnailURL,
+			"trackingMode":      user.TrackingMode,
+			"preferredLanguage": user.PreferredLanguage,
 		},
 	})
 }
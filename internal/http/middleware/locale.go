@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"log"
+
+	"github.com/anshjamwal15/hsb_backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// LocalePreferences looks up the language a user chose in their profile
+type LocalePreferences interface {
+	PreferredLanguage(ctx context.Context, userID string) (string, error)
+}
+
+// LocaleMiddleware picks the response language from the Accept-Language header
+// and sets it in the context as "locale"
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setLocale(c, i18n.Negotiate("", c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
+
+// Context keys of the profile lookup that Locale runs on first use
+const (
+	localePreferencesKey = "localePreferences"
+	localeResolvedKey    = "localeResolved"
+)
+
+// PreferredLocaleMiddleware lets the signed-in user's profile language override
+// the Accept-Language header. The profile is only read when a handler asks for
+// the locale through Locale. It must run after AuthMiddleware.
+func PreferredLocaleMiddleware(preferences LocalePreferences) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(localePreferencesKey, preferences)
+		c.Next()
+	}
+}
+
+// Locale returns the response language. Behind PreferredLocaleMiddleware the
+// user's profile language is looked up the first time it is asked for.
+func Locale(c *gin.Context) string {
+	if value, ok := c.Get(localePreferencesKey); ok && !c.GetBool(localeResolvedKey) {
+		c.Set(localeResolvedKey, true)
+		preferred, err := value.(LocalePreferences).PreferredLanguage(c.Request.Context(), c.GetString("userID"))
+		if err != nil {
+			log.Printf("Failed to load preferred language: %v", err)
+		}
+		setLocale(c, i18n.Negotiate(preferred, c.GetHeader("Accept-Language")))
+	}
+	return c.GetString("locale")
+}
+
+func setLocale(c *gin.Context, locale string) {
+	c.Set("locale", locale)
+	c.Header("Content-Language", locale)
+	c.Header("Vary", "Accept-Language")
+}
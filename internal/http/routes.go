@@ -1,15 +1,21 @@
 package http
 
 import (
+	"context"
+	"fmt"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/anshjamwal15/hsb_backend/internal/application/services"
 	"github.com/anshjamwal15/hsb_backend/internal/config"
 	"github.com/anshjamwal15/hsb_backend/internal/http/handlers"
 	"github.com/anshjamwal15/hsb_backend/internal/http/middleware"
 	"github.com/anshjamwal15/hsb_backend/internal/infrastructure/database"
+	"github.com/anshjamwal15/hsb_backend/internal/infrastructure/notification"
 	"github.com/anshjamwal15/hsb_backend/internal/infrastructure/payment"
 	"github.com/anshjamwal15/hsb_backend/internal/infrastructure/repositories"
+	"github.com/anshjamwal15/hsb_backend/internal/infrastructure/storage"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,6 +27,7 @@ func SetupRouter(db *database.MongoDB, cfg *config.Config) *gin.Engine {
 	}
 
 	router := gin.Default()
+	router.Use(middleware.LocaleMiddleware())
 
 	// Initialize dependencies
 	deps := initializeDependencies(db, cfg)
@@ -28,6 +35,7 @@ func SetupRouter(db *database.MongoDB, cfg *config.Config) *gin.Engine {
 	// Setup all routes
 	setupDocumentation(router)
 	setupHealthCheck(router)
+	setupFileRoutes(router, deps)
 	setupAuthRoutes(router, deps)
 	setupProtectedRoutes(router, cfg.JWTSecret, deps)
 
@@ -36,21 +44,35 @@ func SetupRouter(db *database.MongoDB, cfg *config.Config) *gin.Engine {
 
 // Dependencies holds all handlers
 type Dependencies struct {
-	auth         *handlers.AuthHandler
-	user         *handlers.UserHandler
-	doctor       *handlers.DoctorHandler
-	booking      *handlers.BookingHandler
-	timeSlot     *handlers.TimeSlotHandler
-	clinic       *handlers.ClinicHandler
-	diagnostic   *handlers.DiagnosticHandler
-	period       *handlers.PeriodHandler
-	pregnancy    *handlers.PregnancyHandler
-	fsfi         *handlers.FSFIHandler
-	mentalHealth *handlers.MentalHealthHandler
-	pcos         *handlers.PCOSHandler
-	symptoms     *handlers.SymptomsHandler
-	weight       *handlers.WeightHandler
-	journal      *handlers.JournalHandler
+	auth          *handlers.AuthHandler
+	user          *handlers.UserHandler
+	doctor        *handlers.DoctorHandler
+	booking       *handlers.BookingHandler
+	recordShare   *handlers.RecordShareHandler
+	timeSlot      *handlers.TimeSlotHandler
+	clinic        *handlers.ClinicHandler
+	diagnostic    *handlers.DiagnosticHandler
+	period        *handlers.PeriodHandler
+	fertility     *handlers.FertilityHandler
+	medication    *handlers.MedicationHandler
+	pregnancy     *handlers.PregnancyHandler
+	postpartum    *handlers.PostpartumHandler
+	menopause     *handlers.MenopauseHandler
+	fsfi          *handlers.FSFIHandler
+	mentalHealth  *handlers.MentalHealthHandler
+	questionnaire *handlers.QuestionnaireHandler
+	trend         *handlers.TrendHandler
+	rescreening   *handlers.RescreeningHandler
+	pcos          *handlers.PCOSHandler
+	symptoms      *handlers.SymptomsHandler
+	weight        *handlers.WeightHandler
+	journal       *handlers.JournalHandler
+	media         *handlers.MediaHandler
+	review        *handlers.ReviewHandler
+	consultation  *handlers.ConsultationHandler
+
+	// Profile language lookup for the locale middleware
+	localePreferences middleware.LocalePreferences
 }
 
 func initializeDependencies(db *database.MongoDB, cfg *config.Config) *Dependencies {
@@ -60,6 +82,8 @@ func initializeDependencies(db *database.MongoDB, cfg *config.Config) *Dependenc
 	bookingRepo := repositories.NewBookingRepository(db.Database)
 	clinicRepo := repositories.NewClinicRepository(db.Database)
 	diagnosticRepo := repositories.NewDiagnosticRepository(db.Database)
+	labRepo := repositories.NewLabRepository(db.Database)
+	labReportRepo := repositories.NewLabReportRepository(db.Database)
 	periodRepo := repositories.NewPeriodRepository(db.Database)
 	pregnancyRepo := repositories.NewPregnancyRepository(db.Database)
 	mentalHealthRepo := repositories.NewMentalHealthRepository(db.Database)
@@ -67,44 +91,134 @@ func initializeDependencies(db *database.MongoDB, cfg *config.Config) *Dependenc
 	symptomsRepo := repositories.NewSymptomsRepository(db.Database)
 	weightRepo := repositories.NewWeightRepository(db.Database)
 	journalRepo := repositories.NewJournalRepository(db.Database)
+	postpartumRepo := repositories.NewPostpartumRepository(db.Database)
+	fertilityRepo := repositories.NewFertilityRepository(db.Database)
+	medicationRepo := repositories.NewMedicationRepository(db.Database)
+	menopauseRepo := repositories.NewMenopauseRepository(db.Database)
+	questionnaireRepo := repositories.NewQuestionnaireRepository(db.Database)
+	fsfiRepo := repositories.NewFSFIRepository(db.Database)
+	crisisRepo := repositories.NewCrisisRepository(db.Database)
+	recordShareRepo := repositories.NewRecordShareRepository(db.Database)
+	consultationRepo := repositories.NewConsultationRepository(db.Database)
+	reviewRepo := repositories.NewReviewRepository(db.Database)
 
 	// Payment client
 	razorpayClient := payment.NewRazorpayClient(cfg.RazorpayKey, cfg.RazorpaySecret)
 
+	// Notifications
+	notifier := notification.NewLogNotifier()
+
+	// Uploaded images and documents
+	store, err := newObjectStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up storage: %v", err)
+	}
+	mediaService := services.NewMediaService(store, time.Duration(cfg.StorageURLMinutes)*time.Minute)
+
 	// Services
 	authService := services.NewAuthService(userRepo, cfg.JWTSecret)
-	userService := services.NewUserService(userRepo)
-	doctorService := services.NewDoctorService(doctorRepo)
+	userService := services.NewUserService(userRepo, mediaService)
+	doctorService := services.NewDoctorService(doctorRepo, mediaService)
 	bookingService := services.NewBookingService(bookingRepo, doctorRepo, razorpayClient)
-	clinicService := services.NewClinicService(clinicRepo)
-	diagnosticService := services.NewDiagnosticService(diagnosticRepo)
-	periodService := services.NewPeriodService(periodRepo)
+	recordShareService := services.NewRecordShareService(
+		recordShareRepo, bookingRepo, doctorRepo, userRepo,
+		mentalHealthRepo, pcosRepo, periodRepo, symptomsRepo,
+	)
+	clinicService := services.NewClinicService(clinicRepo, razorpayClient, mediaService)
+	diagnosticService := services.NewDiagnosticService(diagnosticRepo, labRepo, labReportRepo, userRepo, razorpayClient, mediaService)
+	periodService := services.NewPeriodService(periodRepo, fertilityRepo, medicationRepo)
+	fertilityService := services.NewFertilityService(fertilityRepo, periodRepo)
+	medicationService := services.NewMedicationService(medicationRepo)
 	pregnancyService := services.NewPregnancyService(pregnancyRepo)
-	fsfiService := services.NewFSFIService(mentalHealthRepo)
-	mentalHealthService := services.NewMentalHealthService(mentalHealthRepo)
-	pcosService := services.NewPCOSService(pcosRepo)
+	postpartumService := services.NewPostpartumService(pregnancyRepo, postpartumRepo, mentalHealthRepo)
+	menopauseService := services.NewMenopauseService(userRepo, periodRepo, menopauseRepo, mentalHealthRepo)
+	questionnaireService := services.NewQuestionnaireService(questionnaireRepo)
+	fsfiService := services.NewFSFIService(fsfiRepo, questionnaireService)
+	crisisService := services.NewCrisisService(crisisRepo, notifier, cfg.CareTeamChannel)
+	mentalHealthService := services.NewMentalHealthService(mentalHealthRepo, questionnaireService, crisisService)
+	pcosService := services.NewPCOSService(pcosRepo, periodRepo, diagnosticRepo, questionnaireService)
+	trendService := services.NewTrendService(mentalHealthRepo, fsfiRepo, questionnaireService)
+	rescreeningService := services.NewRescreeningService(mentalHealthRepo, questionnaireService, notifier, cfg.ReminderChannel)
 	symptomsService := services.NewSymptomsService(symptomsRepo)
+	reportService := services.NewReportService(
+		userRepo, mentalHealthRepo, fsfiRepo, pcosRepo,
+		periodRepo, weightRepo, symptomsRepo, questionnaireService,
+	)
 	weightService := services.NewWeightService(weightRepo)
 	journalService := services.NewJournalService(journalRepo)
+	reviewService := services.NewReviewService(
+		reviewRepo, bookingRepo, clinicRepo, diagnosticRepo,
+		doctorRepo, labRepo, userRepo, doctorService,
+	)
+	consultationService := services.NewConsultationService(consultationRepo, bookingRepo, doctorService, cfg.AgoraAppID, cfg.AgoraAppCert)
+
+	// Seed built-in questionnaire definitions
+	seedCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := questionnaireService.SeedDefinitions(seedCtx); err != nil {
+		log.Printf("Failed to seed questionnaires: %v", err)
+	}
+
+	// Move diagnostics documents stored before labs had their own prices
+	if err := diagnosticService.MigrateLegacy(seedCtx); err != nil {
+		log.Printf("Failed to migrate diagnostics: %v", err)
+	}
+
+	// Create the 2dsphere indexes that near-me search needs, and the lab report, review, call event and pregnancy session indexes
+	for _, repo := range []interface{ EnsureIndexes(context.Context) error }{clinicRepo, labRepo, doctorRepo, labReportRepo, reviewRepo, consultationRepo, pregnancyRepo} {
+		if err := repo.EnsureIndexes(seedCtx); err != nil {
+			log.Printf("Failed to create indexes: %v", err)
+		}
+	}
+
+	// Send rescreening reminders in the background
+	go rescreeningService.Run(context.Background(), time.Duration(cfg.RescreenCheckMinutes)*time.Minute)
+
+	// Mark calls completed or no-show once they close
+	go consultationService.Run(context.Background(), time.Duration(cfg.ConsultationCheckMinutes)*time.Minute)
 
 	// Handlers
 	return &Dependencies{
-		auth:         handlers.NewAuthHandler(authService),
-		user:         handlers.NewUserHandler(userService),
-		doctor:       handlers.NewDoctorHandler(doctorService),
-		booking:      handlers.NewBookingHandler(bookingService),
-		timeSlot:     handlers.NewTimeSlotHandler(doctorService, bookingService),
-		clinic:       handlers.NewClinicHandler(clinicService),
-		diagnostic:   handlers.NewDiagnosticHandler(diagnosticService),
-		period:       handlers.NewPeriodHandler(periodService),
-		pregnancy:    handlers.NewPregnancyHandler(pregnancyService),
-		fsfi:         handlers.NewFSFIHandler(fsfiService),
-		mentalHealth: handlers.NewMentalHealthHandler(mentalHealthService),
-		pcos:         handlers.NewPCOSHandler(pcosService),
-		symptoms:     handlers.NewSymptomsHandler(symptomsService),
-		weight:       handlers.NewWeightHandler(weightService),
-		journal:      handlers.NewJournalHandler(journalService),
+		auth:          handlers.NewAuthHandler(authService),
+		user:          handlers.NewUserHandler(userService, reportService),
+		doctor:        handlers.NewDoctorHandler(doctorService),
+		booking:       handlers.NewBookingHandler(bookingService, recordShareService),
+		recordShare:   handlers.NewRecordShareHandler(recordShareService),
+		timeSlot:      handlers.NewTimeSlotHandler(doctorService, bookingService),
+		clinic:        handlers.NewClinicHandler(clinicService),
+		diagnostic:    handlers.NewDiagnosticHandler(diagnosticService),
+		period:        handlers.NewPeriodHandler(periodService),
+		fertility:     handlers.NewFertilityHandler(fertilityService),
+		medication:    handlers.NewMedicationHandler(medicationService),
+		pregnancy:     handlers.NewPregnancyHandler(pregnancyService),
+		postpartum:    handlers.NewPostpartumHandler(postpartumService),
+		menopause:     handlers.NewMenopauseHandler(menopauseService),
+		fsfi:          handlers.NewFSFIHandler(fsfiService, reportService),
+		mentalHealth:  handlers.NewMentalHealthHandler(mentalHealthService, reportService),
+		questionnaire: handlers.NewQuestionnaireHandler(questionnaireService),
+		trend:         handlers.NewTrendHandler(trendService),
+		rescreening:   handlers.NewRescreeningHandler(rescreeningService),
+		pcos:          handlers.NewPCOSHandler(pcosService, reportService),
+		symptoms:      handlers.NewSymptomsHandler(symptomsService),
+		weight:        handlers.NewWeightHandler(weightService),
+		journal:       handlers.NewJournalHandler(journalService),
+		media:         handlers.NewMediaHandler(mediaService),
+		review:        handlers.NewReviewHandler(reviewService),
+		consultation:  handlers.NewConsultationHandler(consultationService),
+
+		localePreferences: userService,
+	}
+}
+
+// newObjectStore picks the storage backend named in the config
+func newObjectStore(cfg *config.Config) (services.ObjectStore, error) {
+	switch cfg.StorageBackend {
+	case "local":
+		return storage.NewLocalStore(cfg.StorageLocalDir, cfg.StoragePublicURL, cfg.StorageSigningKey)
+	case "s3":
+		return storage.NewS3Store(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKeyID, cfg.S3SecretAccessKey, cfg.S3PathStyle)
 	}
+	return nil, fmt.Errorf("unknown storage backend %q", cfg.StorageBackend)
 }
 
 func setupDocumentation(r *gin.Engine) {
@@ -154,6 +268,12 @@ func setupHealthCheck(r *gin.Engine) {
 	})
 }
 
+// setupFileRoutes serves files from local storage. The links are signed, so
+// they work without a token, e.g. in an image tag.
+func setupFileRoutes(r *gin.Engine, deps *Dependencies) {
+	r.GET("/files/*key", deps.media.ServeFile)
+}
+
 func setupAuthRoutes(r *gin.Engine, deps *Dependencies) {
 	r.POST("/user/register", deps.auth.Register)
 	r.POST("/user/login", deps.auth.Login)
@@ -164,19 +284,27 @@ func setupAuthRoutes(r *gin.Engine, deps *Dependencies) {
 
 func setupProtectedRoutes(r *gin.Engine, jwtSecret string, deps *Dependencies) {
 	api := r.Group("/api")
-	api.Use(middleware.AuthMiddleware(jwtSecret))
+	api.Use(middleware.AuthMiddleware(jwtSecret), middleware.PreferredLocaleMiddleware(deps.localePreferences))
 
 	// User Profile
 	users := api.Group("/users")
 	{
 		users.GET("/me", deps.user.GetProfile)
 		users.PUT("/me", deps.user.UpdateProfile)
+		users.PUT("/me/profile-image", deps.user.SetProfileImage)
+		users.GET("/me/health-summary/pdf", deps.user.GetHealthSummaryReport)
 	}
 	api.POST("/user/change-password", deps.auth.ChangePassword)
 
 	// Doctors
 	api.GET("/doctors", deps.doctor.GetDoctors)
+	api.GET("/doctors/in-clinic", deps.doctor.SearchInClinic)
 	api.GET("/doctors/:doctorId", deps.doctor.GetDoctorByID)
+	api.GET("/doctors/:doctorId/reviews", deps.review.GetDoctorReviews)
+	api.PUT("/doctor/image", deps.doctor.SetImage)
+
+	// Uploads
+	api.POST("/uploads/images", deps.media.UploadImage)
 
 	// Bookings & Sessions
 	api.GET("/time-slots", deps.timeSlot.GetAvailableTimeSlots)
@@ -185,18 +313,75 @@ func setupProtectedRoutes(r *gin.Engine, jwtSecret string, deps *Dependencies) {
 	api.GET("/bookings/my-with-doctors", deps.booking.GetUserBookings)
 	api.GET("/sessions/active", deps.booking.GetActiveBookings)
 
+	// Video and audio calls of doctor bookings
+	consultations := api.Group("/consultations/:bookingId")
+	{
+		consultations.POST("/token", deps.consultation.IssueToken)
+		consultations.POST("/events", deps.consultation.RecordEvent)
+		consultations.GET("", deps.consultation.GetAttendance)
+	}
+
+	// Sharing records with a booked doctor
+	recordShares := api.Group("/record-shares")
+	{
+		recordShares.GET("", deps.recordShare.GetShares)
+		recordShares.POST("", deps.recordShare.GrantAccess)
+		recordShares.DELETE("/:shareId", deps.recordShare.RevokeAccess)
+		recordShares.GET("/:shareId/access-log", deps.recordShare.GetAccessLog)
+	}
+	api.GET("/doctor/shared-records", deps.recordShare.GetSharedRecords)
+	api.GET("/doctor/shared-records/:shareId/summary", deps.recordShare.GetSummary)
+
 	// Clinics
-	api.GET("/clinics", deps.clinic.GetClinics)
+	api.GET("/clinics", deps.clinic.SearchClinics)
+	api.GET("/clinics/:clinicId", deps.clinic.GetClinic)
+	api.GET("/clinics/:clinicId/availability", deps.clinic.GetAvailability)
+	api.GET("/clinics/:clinicId/reviews", deps.review.GetClinicReviews)
 	api.POST("/clinic-bookings", deps.clinic.CreateBooking)
 	api.GET("/clinic-bookings/my-bookings", deps.clinic.GetMyBookings)
 	api.POST("/clinic-bookings/verify-payment", deps.clinic.VerifyPayment)
 
 	// Diagnostics
 	api.GET("/public/diagnostics", deps.diagnostic.GetDiagnostics)
-	api.GET("/diagnosticsUsers", deps.diagnostic.GetDiagnosticsUsers)
+	api.GET("/labs", deps.diagnostic.SearchLabs)
+	api.GET("/labs/:labId", deps.diagnostic.GetLab)
+	api.GET("/labs/:labId/home-collection", deps.diagnostic.GetHomeCollectionAvailability)
+	api.GET("/labs/:labId/reviews", deps.review.GetLabReviews)
+	api.GET("/diagnosticsUsers", deps.diagnostic.SearchLabs) // older name for /labs
 	api.POST("/diagnostics-bookings", deps.diagnostic.CreateBooking)
+	api.GET("/diagnostics-bookings/my-bookings", deps.diagnostic.GetMyBookings)
+
+	// Home sample collection, managed by the lab linked to the user's account
+	api.GET("/lab/home-collections", deps.diagnostic.GetHomeCollections)
+	api.PUT("/lab/home-collections/:bookingId/phlebotomist", deps.diagnostic.AssignPhlebotomist)
+	api.PUT("/lab/home-collections/:bookingId/status", deps.diagnostic.UpdateCollectionStatus)
+	api.GET("/lab/phlebotomists", deps.diagnostic.GetPhlebotomists)
+	api.POST("/lab/phlebotomists", deps.diagnostic.AddPhlebotomist)
 	api.POST("/diagnostics-bookings/verify-payment", deps.diagnostic.VerifyPayment)
 
+	// Lab reports, uploaded by the lab and read by the patient
+	api.POST("/lab/bookings/:bookingId/report", deps.diagnostic.UploadReport)
+	labReports := api.Group("/lab-reports")
+	{
+		labReports.GET("", deps.diagnostic.GetMyReports)
+		labReports.GET("/trends", deps.diagnostic.GetAnalyteTrend)
+		labReports.GET("/:reportId", deps.diagnostic.GetReport)
+		labReports.GET("/:reportId/file", deps.diagnostic.DownloadReport)
+	}
+
+	// Reviews of doctors, clinics and labs, left after a completed booking
+	reviews := api.Group("/reviews")
+	{
+		reviews.GET("/tags", deps.review.GetTags)
+		reviews.POST("", deps.review.CreateReview)
+		reviews.GET("/my", deps.review.GetMyReviews)
+		reviews.DELETE("/:reviewId", deps.review.DeleteReview)
+	}
+	api.GET("/doctor/reviews", deps.review.GetOwnDoctorReviews)
+	api.PUT("/doctor/reviews/:reviewId/response", deps.review.RespondToReview)
+	api.GET("/moderation/reviews", deps.review.GetModerationQueue)
+	api.PUT("/moderation/reviews/:reviewId", deps.review.ModerateReview)
+
 	// Health Tracking
 	setupHealthTracking(api, deps)
 
@@ -219,28 +404,74 @@ func setupHealthTracking(api *gin.RouterGroup, deps *Dependencies) {
 		period.GET("", deps.period.GetPeriodCycle)
 		period.POST("", deps.period.AddPeriodCycle)
 		period.DELETE("", deps.period.ResetPeriodTracker)
+		period.GET("/prediction", deps.period.GetPrediction)
 	}
 	// Legacy routes
 	api.GET("/period-cycle", deps.period.GetPeriodCycle)
 	api.POST("/period-cycle", deps.period.AddPeriodCycle)
 	api.DELETE("/period-cycle/reset", deps.period.ResetPeriodTracker)
 
+	// Fertility (BBT, LH tests, cervical mucus, intercourse)
+	fertility := api.Group("/fertility")
+	{
+		fertility.GET("", deps.fertility.GetLogs)
+		fertility.POST("", deps.fertility.AddLog)
+		fertility.GET("/ovulation", deps.fertility.GetOvulationStatus)
+	}
+
+	// Medications (birth control, PCOS medication, prenatal vitamins)
+	medications := api.Group("/medications")
+	{
+		medications.GET("", deps.medication.GetRegimens)
+		medications.POST("", deps.medication.CreateRegimen)
+		medications.GET("/due", deps.medication.GetUpcomingDoses)
+		medications.DELETE("/:regimenId", deps.medication.StopRegimen)
+		medications.GET("/:regimenId/doses", deps.medication.GetDoses)
+		medications.POST("/:regimenId/doses", deps.medication.LogDose)
+		medications.GET("/:regimenId/adherence", deps.medication.GetAdherence)
+	}
+
 	// Pregnancy Tracker
 	pregnancy := api.Group("/pregnancy")
 	{
 		pregnancy.GET("", deps.pregnancy.GetPregnancyData)
 		pregnancy.POST("", deps.pregnancy.AddPregnancyEntry)
+		pregnancy.GET("/sessions", deps.pregnancy.GetSessions)
+		pregnancy.POST("/sessions", deps.pregnancy.StartSession)
+		pregnancy.GET("/sessions/:sessionId", deps.pregnancy.GetSession)
+		pregnancy.POST("/sessions/:sessionId/events", deps.pregnancy.RecordSessionEvent)
+		pregnancy.POST("/sessions/:sessionId/stop", deps.pregnancy.StopSession)
 	}
 	// Legacy routes
 	api.GET("/pregnancy-tracker", deps.pregnancy.GetPregnancyData)
 	api.POST("/pregnancy-tracker", deps.pregnancy.AddPregnancyEntry)
 
+	// Postpartum
+	postpartum := api.Group("/postpartum")
+	{
+		postpartum.GET("", deps.postpartum.GetSummary)
+		postpartum.POST("", deps.postpartum.StartPostpartum)
+		postpartum.GET("/entries", deps.postpartum.GetEntries)
+		postpartum.POST("/entries", deps.postpartum.AddEntry)
+	}
+
+	// Perimenopause and menopause
+	menopause := api.Group("/menopause")
+	{
+		menopause.GET("", deps.menopause.GetStatus)
+		menopause.POST("", deps.menopause.StartPerimenopause)
+		menopause.DELETE("", deps.menopause.StopTracking)
+		menopause.GET("/symptoms", deps.menopause.GetSymptoms)
+		menopause.POST("/symptoms", deps.menopause.LogSymptom)
+	}
+
 	// FSFI (Sexual Wellness)
 	fsfi := api.Group("/fsfi")
 	{
 		fsfi.GET("", deps.fsfi.GetTest)
 		fsfi.POST("", deps.fsfi.SubmitTest)
 		fsfi.GET("/results", deps.fsfi.GetMyResults)
+		fsfi.GET("/results/:resultId/pdf", deps.fsfi.GetResultReport)
 		// Legacy routes
 		fsfi.GET("/test", deps.fsfi.GetTest)
 		fsfi.POST("/submit", deps.fsfi.SubmitTest)
@@ -253,6 +484,7 @@ func setupHealthTracking(api *gin.RouterGroup, deps *Dependencies) {
 		mentalHealth.GET("", deps.mentalHealth.GetTests)
 		mentalHealth.POST("/submit", deps.mentalHealth.SubmitTestResults)
 		mentalHealth.GET("/results", deps.mentalHealth.GetTestResults)
+		mentalHealth.GET("/results/:resultId/pdf", deps.mentalHealth.GetResultReport)
 	}
 	// Legacy routes
 	api.GET("/tests", deps.mentalHealth.GetTests)
@@ -260,6 +492,19 @@ func setupHealthTracking(api *gin.RouterGroup, deps *Dependencies) {
 	api.POST("/test-results", deps.mentalHealth.SubmitTestResults)
 	api.GET("/test-results", deps.mentalHealth.GetTestResults)
 
+	// Assessment trends (any questionnaire, including FSFI)
+	api.GET("/assessments/due", deps.rescreening.GetDueAssessments)
+	api.GET("/assessments/:testName/trends", deps.trend.GetTrend)
+
+	// Questionnaire definitions
+	questionnaires := api.Group("/questionnaires")
+	{
+		questionnaires.GET("", deps.questionnaire.GetQuestionnaires)
+		questionnaires.GET("/translations/missing", deps.questionnaire.GetMissingTranslations)
+		questionnaires.GET("/:name", deps.questionnaire.GetQuestionnaire)
+		questionnaires.GET("/:name/versions", deps.questionnaire.GetVersions)
+	}
+
 	// PCOS Assessment
 	pcos := api.Group("/pcos")
 	{
@@ -267,6 +512,9 @@ func setupHealthTracking(api *gin.RouterGroup, deps *Dependencies) {
 		pcos.POST("", deps.pcos.SubmitAssessment)
 		pcos.GET("/history", deps.pcos.GetHistory)
 		pcos.GET("/latest", deps.pcos.GetLatestAssessment)
+		pcos.GET("/ultrasounds", deps.pcos.GetUltrasounds)
+		pcos.POST("/ultrasounds", deps.pcos.AddUltrasound)
+		pcos.GET("/:assessmentId/pdf", deps.pcos.GetAssessmentReport)
 	}
 	// Legacy routes
 	api.GET("/pcos-assessment/questions", deps.pcos.GetQuestions)
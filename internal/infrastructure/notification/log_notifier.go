@@ -0,0 +1,38 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+)
+
+// LogNotifier writes notifications to the application log. It is the default
+// notifier for local development and deployments without a messaging integration.
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{
+		logger: log.Default(),
+	}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification entities.Notification) error {
+	keys := make([]string, 0, len(notification.Data))
+	for key := range notification.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, key+"="+notification.Data[key])
+	}
+
+	n.logger.Printf("[notify:%s] %s: %s %s", notification.Channel, notification.Subject, notification.Body, strings.Join(fields, " "))
+	return nil
+}
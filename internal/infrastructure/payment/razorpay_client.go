@@ -78,6 +78,11 @@ func (r *RazorpayClient) CreateOrder(amount int, currency, receipt string) (stri
 
 // VerifyPaymentSignature verifies the Razorpay payment signature
 func (r *RazorpayClient) VerifyPaymentSignature(orderID, paymentID, signature string) error {
+	// Anyone could sign with an empty secret
+	if r.keySecret == "" {
+		return errors.New("razorpay is not configured")
+	}
+
 	// Create the message to verify
 	message := orderID + "|" + paymentID
 
@@ -86,8 +91,8 @@ func (r *RazorpayClient) VerifyPaymentSignature(orderID, paymentID, signature st
 	h.Write([]byte(message))
 	expectedSignature := hex.EncodeToString(h.Sum(nil))
 
-	// Compare signatures
-	if expectedSignature != signature {
+	// Compare signatures in constant time
+	if !hmac.Equal([]byte(expectedSignature), []byte(signature)) {
 		return errors.New("invalid payment signature")
 	}
 
@@ -172,6 +172,50 @@ func (r *bookingRepositoryImpl) UpdatePaymentStatus(ctx context.Context, booking
 	return nil
 }
 
+func (r *bookingRepositoryImpl) FindUnsettledCalls(ctx context.Context, from, to time.Time) ([]*entities.Booking, error) {
+	filter := bson.M{
+		"sessionType":   bson.M{"$in": []string{entities.SessionVideoCall, entities.SessionAudioCall}},
+		"paymentStatus": "paid",
+		"status":        bson.M{"$in": []string{"pending", "confirmed"}},
+		"date":          bson.M{"$gte": from, "$lte": to},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error finding calls: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var bookings []*entities.Booking
+	if err := cursor.All(ctx, &bookings); err != nil {
+		return nil, fmt.Errorf("error decoding calls: %w", err)
+	}
+
+	return bookings, nil
+}
+
+func (r *bookingRepositoryImpl) SettleCall(ctx context.Context, bookingID, status, noShowBy string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return false, fmt.Errorf("invalid booking ID format: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{"status": status, "updatedAt": time.Now()}}
+	if noShowBy != "" {
+		update["$set"].(bson.M)["noShowBy"] = noShowBy
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objID, "status": bson.M{"$in": []string{"pending", "confirmed"}}},
+		update,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error settling call: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
 func (r *bookingRepositoryImpl) Update(ctx context.Context, booking *entities.Booking) error {
 	booking.UpdatedAt = time.Now()
 	_, err := r.collection.UpdateOne(
@@ -4,10 +4,12 @@ import (
 	"context"
 	"time"
 
+	"github.com/anshjamwal15/hsb_backend/internal/domain"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type ClinicRepositoryImpl struct {
@@ -37,12 +39,83 @@ func (r *ClinicRepositoryImpl) FindAll(ctx context.Context) ([]*entities.Clinic,
 	return clinics, nil
 }
 
+func (r *ClinicRepositoryImpl) FindByID(ctx context.Context, id string) (*entities.Clinic, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var clinic entities.Clinic
+	err = r.clinicsCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&clinic)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &clinic, nil
+}
+
+// Search finds clinics by location, city, pincode, service and rating
+func (r *ClinicRepositoryImpl) Search(ctx context.Context, query entities.GeoQuery) ([]*entities.Clinic, int64, error) {
+	match := bson.M{}
+	if query.City != "" {
+		match["city"] = equalFold(query.City)
+	}
+	if query.Pincode != "" {
+		match["pincode"] = query.Pincode
+	}
+	if query.Service != "" {
+		match["$or"] = bson.A{
+			bson.M{"catalog.name": equalFold(query.Service)},
+			bson.M{"services": equalFold(query.Service)},
+		}
+	}
+	if query.MinRating > 0 {
+		match["rating"] = bson.M{"$gte": query.MinRating}
+	}
+
+	clinics := []*entities.Clinic{}
+	total, err := geoSearch(ctx, r.clinicsCollection, match, query, &clinics)
+	if err != nil {
+		return nil, 0, err
+	}
+	return clinics, total, nil
+}
+
+func (r *ClinicRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	if err := ensureGeoIndex(ctx, r.clinicsCollection); err != nil {
+		return err
+	}
+	// A slot is held by one booking at a time
+	_, err := r.bookingsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "slotHold", Value: 1}},
+		Options: options.Index().
+			SetName("slotHold_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"slotHold": bson.M{"$exists": true}}),
+	})
+	return err
+}
+
+func (r *ClinicRepositoryImpl) ApplyReviewRating(ctx context.Context, id string, stars, delta int) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	return applyReviewRating(ctx, r.clinicsCollection, objectID, stars, delta)
+}
+
 func (r *ClinicRepositoryImpl) CreateBooking(ctx context.Context, booking *entities.ClinicBooking) error {
 	now := time.Now().Unix()
 	booking.CreatedAt = now
 	booking.UpdatedAt = now
 
 	result, err := r.bookingsCollection.InsertOne(ctx, booking)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicateKey
+	}
 	if err != nil {
 		return err
 	}
@@ -51,6 +124,24 @@ func (r *ClinicRepositoryImpl) CreateBooking(ctx context.Context, booking *entit
 	return nil
 }
 
+func (r *ClinicRepositoryImpl) FindBookingByID(ctx context.Context, id string) (*entities.ClinicBooking, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var booking entities.ClinicBooking
+	err = r.bookingsCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&booking)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &booking, nil
+}
+
 func (r *ClinicRepositoryImpl) FindBookingsByUserID(ctx context.Context, userID string) ([]*entities.ClinicBooking, error) {
 	filter := bson.M{"userId": userID}
 
@@ -68,13 +159,67 @@ func (r *ClinicRepositoryImpl) FindBookingsByUserID(ctx context.Context, userID
 	return bookings, nil
 }
 
-func (r *ClinicRepositoryImpl) UpdateBookingPayment(ctx context.Context, bookingID, paymentID, status string) error {
+// activeBookingsFilter matches bookings at a clinic dated in [from, to) that
+// are not cancelled or expired, leaving out unpaid ones created before unpaidSince
+func activeBookingsFilter(clinicID string, from, to, unpaidSince int64) bson.M {
+	return bson.M{
+		"clinicId": clinicID,
+		"date":     bson.M{"$gte": from, "$lt": to},
+		"status":   bson.M{"$nin": bson.A{"cancelled", "expired"}},
+		"$nor": bson.A{bson.M{
+			"status":        "pending",
+			"paymentStatus": bson.M{"$ne": "completed"},
+			"createdAt":     bson.M{"$lt": unpaidSince},
+		}},
+	}
+}
+
+func (r *ClinicRepositoryImpl) FindActiveBookings(ctx context.Context, clinicID string, from, to, unpaidSince int64) ([]*entities.ClinicBooking, error) {
+	cursor, err := r.bookingsCollection.Find(ctx, activeBookingsFilter(clinicID, from, to, unpaidSince))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var bookings []*entities.ClinicBooking
+	if err = cursor.All(ctx, &bookings); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// CountActiveBookings counts the active bookings of one service
+func (r *ClinicRepositoryImpl) CountActiveBookings(ctx context.Context, clinicID, service string, from, to, unpaidSince int64) (int64, error) {
+	filter := activeBookingsFilter(clinicID, from, to, unpaidSince)
+	filter["service"] = equalFold(service)
+	return r.bookingsCollection.CountDocuments(ctx, filter)
+}
+
+func (r *ClinicRepositoryImpl) ExpireUnpaidBookings(ctx context.Context, clinicID string, from, to, unpaidSince int64) error {
+	filter := bson.M{
+		"clinicId":      clinicID,
+		"date":          bson.M{"$gte": from, "$lt": to},
+		"status":        "pending",
+		"paymentStatus": bson.M{"$ne": "completed"},
+		"createdAt":     bson.M{"$lt": unpaidSince},
+	}
+	update := bson.M{
+		"$set":   bson.M{"status": "expired", "updatedAt": time.Now().Unix()},
+		"$unset": bson.M{"slotHold": ""},
+	}
+
+	_, err := r.bookingsCollection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+func (r *ClinicRepositoryImpl) UpdateBookingPayment(ctx context.Context, bookingID, paymentID, status string) (bool, error) {
 	objectID, err := primitive.ObjectIDFromHex(bookingID)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	filter := bson.M{"_id": objectID}
+	filter := bson.M{"_id": objectID, "status": "pending"}
 	update := bson.M{
 		"$set": bson.M{
 			"paymentId":     paymentID,
@@ -84,6 +229,36 @@ func (r *ClinicRepositoryImpl) UpdateBookingPayment(ctx context.Context, booking
 		},
 	}
 
-	_, err = r.bookingsCollection.UpdateOne(ctx, filter, update)
+	result, err := r.bookingsCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *ClinicRepositoryImpl) SetBookingOrder(ctx context.Context, bookingID, razorpayOrderID string) error {
+	objectID, err := primitive.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"razorpayOrderId": razorpayOrderID,
+			"updatedAt":       time.Now().Unix(),
+		},
+	}
+
+	_, err = r.bookingsCollection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *ClinicRepositoryImpl) DeleteBooking(ctx context.Context, bookingID string) error {
+	objectID, err := primitive.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.bookingsCollection.DeleteOne(ctx, bson.M{"_id": objectID})
 	return err
 }
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ConsultationRepositoryImpl struct {
+	eventsCollection *mongo.Collection
+}
+
+func NewConsultationRepository(db *mongo.Database) *ConsultationRepositoryImpl {
+	return &ConsultationRepositoryImpl{
+		eventsCollection: db.Collection("consultation_events"),
+	}
+}
+
+func (r *ConsultationRepositoryImpl) CreateEvent(ctx context.Context, event *entities.ConsultationEvent) error {
+	result, err := r.eventsCollection.InsertOne(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		event.ID = oid
+	}
+	return nil
+}
+
+func (r *ConsultationRepositoryImpl) FindEvents(ctx context.Context, bookingID primitive.ObjectID) ([]*entities.ConsultationEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.eventsCollection.Find(ctx, bson.M{"bookingId": bookingID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*entities.ConsultationEvent{}
+	if err = cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *ConsultationRepositoryImpl) FindLastEvent(ctx context.Context, bookingID primitive.ObjectID, role string) (*entities.ConsultationEvent, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}})
+
+	var event entities.ConsultationEvent
+	err := r.eventsCollection.FindOne(ctx, bson.M{"bookingId": bookingID, "role": role}, opts).Decode(&event)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (r *ConsultationRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.eventsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "bookingId", Value: 1}, {Key: "role", Value: 1}, {Key: "at", Value: 1}},
+		Options: options.Index().SetName("bookingId_role_at"),
+	})
+	return err
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CrisisRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewCrisisRepository(db *mongo.Database) *CrisisRepositoryImpl {
+	return &CrisisRepositoryImpl{
+		collection: db.Collection("crisis_escalations"),
+	}
+}
+
+func (r *CrisisRepositoryImpl) Create(ctx context.Context, escalation *entities.CrisisEscalation) error {
+	now := time.Now()
+	escalation.CreatedAt = now
+	escalation.UpdatedAt = now
+
+	result, err := r.collection.InsertOne(ctx, escalation)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		escalation.ID = oid
+	}
+
+	return nil
+}
+
+func (r *CrisisRepositoryImpl) Update(ctx context.Context, escalation *entities.CrisisEscalation) error {
+	escalation.UpdatedAt = time.Now()
+
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": escalation.ID},
+		bson.M{"$set": escalation},
+	)
+	return err
+}
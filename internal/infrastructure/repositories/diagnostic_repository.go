@@ -8,6 +8,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type DiagnosticRepositoryImpl struct {
@@ -22,14 +23,17 @@ func NewDiagnosticRepository(db *mongo.Database) *DiagnosticRepositoryImpl {
 	}
 }
 
-func (r *DiagnosticRepositoryImpl) FindAll(ctx context.Context) ([]*entities.Diagnostic, error) {
-	cursor, err := r.diagnosticsCollection.Find(ctx, bson.M{})
+// FindAll returns the active tests and packages in the catalog
+func (r *DiagnosticRepositoryImpl) FindAll(ctx context.Context) ([]*entities.Diagnostics, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "testName", Value: 1}})
+
+	cursor, err := r.diagnosticsCollection.Find(ctx, bson.M{"isActive": true}, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var diagnostics []*entities.Diagnostic
+	var diagnostics []*entities.Diagnostics
 	if err = cursor.All(ctx, &diagnostics); err != nil {
 		return nil, err
 	}
@@ -37,8 +41,26 @@ func (r *DiagnosticRepositoryImpl) FindAll(ctx context.Context) ([]*entities.Dia
 	return diagnostics, nil
 }
 
-func (r *DiagnosticRepositoryImpl) CreateBooking(ctx context.Context, booking *entities.DiagnosticBooking) error {
-	now := time.Now().Unix()
+func (r *DiagnosticRepositoryImpl) FindByID(ctx context.Context, id string) (*entities.Diagnostics, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostic entities.Diagnostics
+	err = r.diagnosticsCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&diagnostic)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &diagnostic, nil
+}
+
+func (r *DiagnosticRepositoryImpl) CreateBooking(ctx context.Context, booking *entities.DiagnosticsBooking) error {
+	now := time.Now()
 	booking.CreatedAt = now
 	booking.UpdatedAt = now
 
@@ -47,20 +69,26 @@ func (r *DiagnosticRepositoryImpl) CreateBooking(ctx context.Context, booking *e
 		return err
 	}
 
-	booking.ID = result.InsertedID.(primitive.ObjectID).Hex()
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		booking.ID = oid
+	}
 	return nil
 }
 
-func (r *DiagnosticRepositoryImpl) FindBookingsByUserID(ctx context.Context, userID string) ([]*entities.DiagnosticBooking, error) {
-	filter := bson.M{"userId": userID}
+func (r *DiagnosticRepositoryImpl) FindBookingsByUserID(ctx context.Context, userID string) ([]*entities.DiagnosticsBooking, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}})
 
-	cursor, err := r.bookingsCollection.Find(ctx, filter)
+	cursor, err := r.bookingsCollection.Find(ctx, bson.M{"userId": userOID}, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var bookings []*entities.DiagnosticBooking
+	var bookings []*entities.DiagnosticsBooking
 	if err = cursor.All(ctx, &bookings); err != nil {
 		return nil, err
 	}
@@ -74,16 +102,209 @@ func (r *DiagnosticRepositoryImpl) UpdateBookingPayment(ctx context.Context, boo
 		return err
 	}
 
-	filter := bson.M{"_id": objectID}
 	update := bson.M{
 		"$set": bson.M{
-			"paymentId":     paymentID,
-			"paymentStatus": status,
-			"status":        "confirmed",
-			"updatedAt":     time.Now().Unix(),
+			"razorpayPaymentId": paymentID,
+			"paymentStatus":     status,
+			"status":            "confirmed",
+			"updatedAt":         time.Now(),
+		},
+	}
+
+	_, err = r.bookingsCollection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *DiagnosticRepositoryImpl) SetBookingOrder(ctx context.Context, bookingID, razorpayOrderID string) error {
+	objectID, err := primitive.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"razorpayOrderId": razorpayOrderID,
+			"updatedAt":       time.Now(),
 		},
 	}
 
-	_, err = r.bookingsCollection.UpdateOne(ctx, filter, update)
+	_, err = r.bookingsCollection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
 	return err
 }
+
+func (r *DiagnosticRepositoryImpl) DeleteBooking(ctx context.Context, bookingID string) error {
+	objectID, err := primitive.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.bookingsCollection.DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
+
+func (r *DiagnosticRepositoryImpl) UpdateBookingStatus(ctx context.Context, bookingID, status string) error {
+	objectID, err := primitive.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":    status,
+			"updatedAt": time.Now(),
+		},
+	}
+
+	_, err = r.bookingsCollection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *DiagnosticRepositoryImpl) FindBookingByID(ctx context.Context, id string) (*entities.DiagnosticsBooking, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var booking entities.DiagnosticsBooking
+	err = r.bookingsCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&booking)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &booking, nil
+}
+
+// FindHomeCollections returns a lab's home collections with a window starting
+// in [from, to), earliest first
+func (r *DiagnosticRepositoryImpl) FindHomeCollections(ctx context.Context, labID primitive.ObjectID, from, to time.Time) ([]*entities.DiagnosticsBooking, error) {
+	filter := bson.M{
+		"labId":                      labID,
+		"collectionType":             "home",
+		"status":                     bson.M{"$ne": "cancelled"},
+		"homeCollection.windowStart": bson.M{"$gte": from, "$lt": to},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "homeCollection.windowStart", Value: 1}})
+
+	cursor, err := r.bookingsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var bookings []*entities.DiagnosticsBooking
+	if err = cursor.All(ctx, &bookings); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+func (r *DiagnosticRepositoryImpl) AssignPhlebotomist(ctx context.Context, bookingID string, phlebotomistID primitive.ObjectID) error {
+	objectID, err := primitive.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"homeCollection.phlebotomistId": phlebotomistID,
+			"updatedAt":                     time.Now(),
+		},
+	}
+
+	_, err = r.bookingsCollection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *DiagnosticRepositoryImpl) UpdateCollectionStatus(ctx context.Context, bookingID, from string, change entities.CollectionStatusChange, bookingStatus string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return false, err
+	}
+
+	set := bson.M{
+		"homeCollection.status": change.Status,
+		"updatedAt":             change.At,
+	}
+	if bookingStatus != "" {
+		set["status"] = bookingStatus
+	}
+	update := bson.M{
+		"$set":  set,
+		"$push": bson.M{"homeCollection.history": change},
+	}
+
+	result, err := r.bookingsCollection.UpdateOne(ctx, bson.M{"_id": objectID, "homeCollection.status": from}, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// MigrateLegacy rewrites catalog entries and bookings stored in the old shape,
+// with string IDs and Unix times, into the current one. Documents already
+// migrated are not matched, so it is safe to run on every start. It returns the
+// number of documents rewritten.
+func (r *DiagnosticRepositoryImpl) MigrateLegacy(ctx context.Context) (int64, error) {
+	catalog, err := r.diagnosticsCollection.UpdateMany(ctx,
+		bson.M{"testName": bson.M{"$exists": false}, "name": bson.M{"$exists": true}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"testName":  "$name",
+				"testPrice": bson.M{"$ifNull": bson.A{"$price", 0}},
+				"kind": bson.M{"$cond": bson.A{
+					bson.M{"$gt": bson.A{bson.M{"$size": bson.M{"$ifNull": bson.A{"$tests", bson.A{}}}}, 1}},
+					"package",
+					"test",
+				}},
+				"isActive":  true,
+				"createdAt": unixToDate("$createdAt"),
+				"updatedAt": "$$NOW",
+			}}},
+			{{Key: "$unset", Value: bson.A{"name", "price"}}},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	bookings, err := r.bookingsCollection.UpdateMany(ctx,
+		bson.M{"diagnosticId": bson.M{"$exists": true}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"userId":            toObjectID("$userId"),
+				"diagnosticsId":     toObjectID("$diagnosticId"),
+				"date":              unixToDate("$date"),
+				"time":              "$timeSlot",
+				"razorpayPaymentId": "$paymentId",
+				"createdAt":         unixToDate("$createdAt"),
+				"updatedAt":         unixToDate("$updatedAt"),
+			}}},
+			{{Key: "$unset", Value: bson.A{"diagnosticId", "timeSlot", "paymentId"}}},
+		},
+	)
+	if err != nil {
+		return catalog.ModifiedCount, err
+	}
+
+	return catalog.ModifiedCount + bookings.ModifiedCount, nil
+}
+
+// unixToDate converts a field holding Unix seconds to a date, leaving other
+// values as they are
+func unixToDate(field string) bson.M {
+	return bson.M{"$cond": bson.A{
+		bson.M{"$isNumber": field},
+		bson.M{"$toDate": bson.M{"$multiply": bson.A{field, 1000}}},
+		field,
+	}}
+}
+
+// toObjectID converts a field holding a hex ID to an ObjectID, leaving values
+// that are not valid IDs as they are
+func toObjectID(field string) bson.M {
+	return bson.M{"$convert": bson.M{"input": field, "to": "objectId", "onError": field, "onNull": field}}
+}
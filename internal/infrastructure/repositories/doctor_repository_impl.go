@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"math"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -108,17 +110,50 @@ func (r *doctorRepositoryImpl) FindByEmail(ctx context.Context, email string) (*
 	return &doctor, nil
 }
 
+// FindByUserID finds the doctor whose profile is linked to a user account
+func (r *doctorRepositoryImpl) FindByUserID(ctx context.Context, userID string) (*entities.Doctor, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	var doctor entities.Doctor
+	err = r.collection().FindOne(ctx, bson.M{
+		"userId":    userOID,
+		"isDeleted": bson.M{"$ne": true},
+	}).Decode(&doctor)
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find doctor by user: %w", err)
+	}
+
+	return &doctor, nil
+}
+
 // Update updates a doctor
 func (r *doctorRepositoryImpl) Update(ctx context.Context, id string, doctor *entities.Doctor) (*entities.Doctor, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid doctor ID format: %w", err)
+	}
 	doctor.UpdatedAt = time.Now()
 
+	// _id cannot be changed, so it is left out of the fields set, and the
+	// rating is only changed by reviews
+	fields := *doctor
+	fields.ID = ""
+	fields.Rating = nil
+	fields.TotalReviews = nil
 	update := bson.M{
-		"$set": doctor,
+		"$set": &fields,
 	}
 
 	result := r.collection().FindOneAndUpdate(
 		ctx,
-		bson.M{"_id": id, "isDeleted": false},
+		bson.M{"_id": objectID, "isDeleted": bson.M{"$ne": true}},
 		update,
 		options.FindOneAndUpdate().SetReturnDocument(options.After),
 	)
@@ -157,38 +192,208 @@ func (r *doctorRepositoryImpl) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// FindAll finds all doctors with optional filters
-func (r *doctorRepositoryImpl) FindAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*entities.Doctor, error) {
-	// Start with base filter
-	filter := bson.M{"isDeleted": false, "isApproved": true}
-
-	// Apply additional filters
-	for key, value := range filters {
-		filter[key] = value
+// Search finds approved doctors matching the query in one aggregation: a page
+// of them in the query's order, the total and the facet counts
+func (r *doctorRepositoryImpl) Search(ctx context.Context, query entities.DoctorQuery) (*entities.DoctorSearchResult, error) {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: doctorSearchMatch(query)}}}
+	if query.AvailableOn != nil {
+		pipeline = append(pipeline, freeSlotStages(*query.AvailableOn)...)
 	}
 
-	opts := options.Find()
-	if limit > 0 {
-		opts.SetLimit(int64(limit))
-	}
-	if offset > 0 {
-		opts.SetSkip(int64(offset))
+	results := bson.A{}
+	sort := ratingSort
+	switch query.SortBy {
+	case "fee":
+		results = append(results, bson.M{"$addFields": bson.M{"sortFee": sortFee(query.SessionType)}})
+		sort = append(bson.D{{Key: "sortFee", Value: 1}}, ratingSort...)
+	case "experience":
+		results = append(results, bson.M{"$addFields": bson.M{"experienceYears": experienceYears}})
+		sort = append(bson.D{{Key: "experienceYears", Value: -1}}, ratingSort...)
 	}
+	results = append(results,
+		bson.M{"$sort": sort},
+		bson.M{"$skip": int64((query.Page - 1) * query.Limit)},
+		bson.M{"$limit": int64(query.Limit)},
+		bson.M{"$project": bson.M{"sortFee": 0, "experienceYears": 0}},
+	)
+
+	byCount := bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"results": results,
+		"total":   bson.A{bson.M{"$count": "count"}},
+		"specializations": bson.A{
+			bson.M{"$group": bson.M{"_id": "$specialization", "count": bson.M{"$sum": 1}}},
+			byCount,
+		},
+		"languages": bson.A{
+			bson.M{"$unwind": "$languages"},
+			bson.M{"$group": bson.M{"_id": "$languages", "count": bson.M{"$sum": 1}}},
+			byCount,
+		},
+		"sessionTypes": bson.A{
+			bson.M{"$project": bson.M{"sessionTypes": offeredSessionTypes()}},
+			bson.M{"$unwind": "$sessionTypes"},
+			bson.M{"$group": bson.M{"_id": "$sessionTypes", "count": bson.M{"$sum": 1}}},
+			byCount,
+		},
+	}}})
 
-	cursor, err := r.collection().Find(ctx, filter, opts)
+	cursor, err := r.collection().Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find doctors: %w", err)
+		return nil, fmt.Errorf("failed to search doctors: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var doctors []*entities.Doctor
-	if err := cursor.All(ctx, &doctors); err != nil {
+	var page []struct {
+		Results []*entities.Doctor `bson:"results"`
+		Total   []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		entities.DoctorFacets `bson:",inline"`
+	}
+	if err := cursor.All(ctx, &page); err != nil {
 		return nil, fmt.Errorf("failed to decode doctors: %w", err)
 	}
 
-	return doctors, nil
+	result := &entities.DoctorSearchResult{
+		Doctors: []*entities.Doctor{},
+		Facets: entities.DoctorFacets{
+			Specializations: []entities.FacetCount{},
+			Languages:       []entities.FacetCount{},
+			SessionTypes:    []entities.FacetCount{},
+		},
+	}
+	if len(page) == 0 || len(page[0].Total) == 0 {
+		return result, nil
+	}
+	result.Doctors = page[0].Results
+	result.Total = page[0].Total[0].Count
+	result.Facets = page[0].DoctorFacets
+	return result, nil
 }
 
+// Fee field of each session type
+var sessionFeeFields = map[string]string{
+	entities.SessionVideoCall: "consultationFees.videoCall",
+	entities.SessionAudioCall: "consultationFees.audioCall",
+	entities.SessionInClinic:  "consultationFees.inClinic",
+}
+
+// doctorSearchMatch filters approved doctors by the query. A session type is
+// offered when its fee is set.
+func doctorSearchMatch(query entities.DoctorQuery) bson.M {
+	match := bson.M{
+		"isApproved": true,
+		"isDeleted":  bson.M{"$ne": true},
+	}
+	var and bson.A
+	if query.Search != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(query.Search), Options: "i"}
+		and = append(and, bson.M{"$or": bson.A{bson.M{"name": pattern}, bson.M{"specialization": pattern}}})
+	}
+	if query.Specialization != "" {
+		match["specialization"] = equalFold(query.Specialization)
+	}
+	if query.Language != "" {
+		match["languages"] = equalFold(query.Language)
+	}
+	if query.MinRating > 0 {
+		match["rating"] = bson.M{"$gte": query.MinRating}
+	}
+	if query.AvailableOn != nil {
+		match["isAvailable"] = bson.M{"$ne": false}
+	}
+
+	fee := bson.M{"$gt": 0}
+	if query.MinFee > 0 {
+		fee["$gte"] = query.MinFee
+	}
+	if query.MaxFee > 0 {
+		fee["$lte"] = query.MaxFee
+	}
+	if query.SessionType != "" {
+		match[sessionFeeFields[query.SessionType]] = fee
+	} else if len(fee) > 1 {
+		anyFee := bson.A{}
+		for _, field := range sessionFeeFields {
+			anyFee = append(anyFee, bson.M{field: fee})
+		}
+		and = append(and, bson.M{"$or": anyFee})
+	}
+
+	if len(and) > 0 {
+		match["$and"] = and
+	}
+	return match
+}
+
+// freeSlotStages keep the doctors with fewer bookings on the day than there
+// are slots in it
+func freeSlotStages(day time.Time) []bson.D {
+	return []bson.D{
+		{{Key: "$lookup", Value: bson.M{
+			"from": "bookings",
+			"let":  bson.M{"doctorId": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$expr":  bson.M{"$eq": bson.A{"$doctorId", "$$doctorId"}},
+					"date":   bson.M{"$gte": day, "$lt": day.AddDate(0, 0, 1)},
+					"status": bson.M{"$nin": bson.A{"cancelled", "rejected"}},
+				}},
+				bson.M{"$count": "count"},
+			},
+			"as": "bookedSlots",
+		}}},
+		{{Key: "$match", Value: bson.M{"$expr": bson.M{"$lt": bson.A{
+			bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$bookedSlots.count", 0}}, 0}},
+			entities.ConsultationSlotsPerDay,
+		}}}}},
+		{{Key: "$project", Value: bson.M{"bookedSlots": 0}}},
+	}
+}
+
+// sortFee is the session type's fee, or without one the lowest fee the doctor
+// offers. Doctors offering nothing sort last.
+func sortFee(sessionType string) interface{} {
+	if sessionType != "" {
+		return "$" + sessionFeeFields[sessionType]
+	}
+	fees := bson.A{}
+	for _, field := range sessionFeeFields {
+		fees = append(fees, "$"+field)
+	}
+	return bson.M{"$ifNull": bson.A{
+		bson.M{"$min": bson.M{"$filter": bson.M{
+			"input": fees,
+			"cond":  bson.M{"$gt": bson.A{"$$this", 0}},
+		}}},
+		math.MaxInt32,
+	}}
+}
+
+// offeredSessionTypes lists the session types whose fee is set
+func offeredSessionTypes() bson.M {
+	types := bson.A{}
+	for sessionType, field := range sessionFeeFields {
+		types = append(types, bson.M{"$cond": bson.A{
+			bson.M{"$gt": bson.A{"$" + field, 0}},
+			bson.A{sessionType},
+			bson.A{},
+		}})
+	}
+	return bson.M{"$concatArrays": types}
+}
+
+// experienceYears reads the first number in experience, such as 15 in
+// "15 years", or 0 without one
+var experienceYears = bson.M{"$let": bson.M{
+	"vars": bson.M{"found": bson.M{"$regexFind": bson.M{
+		"input": bson.M{"$ifNull": bson.A{"$experience", ""}},
+		"regex": "[0-9]+",
+	}}},
+	"in": bson.M{"$convert": bson.M{"input": "$$found.match", "to": "int", "onError": 0, "onNull": 0}},
+}}
+
 // UpdateAvailability updates a doctor's availability
 func (r *doctorRepositoryImpl) UpdateAvailability(ctx context.Context, doctorID string, slots []entities.TimeSlot) error {
 	// First, delete existing slots for this doctor
@@ -239,24 +444,54 @@ func (r *doctorRepositoryImpl) GetAvailability(ctx context.Context, doctorID str
 	return slots, nil
 }
 
-// FindBySpecialization finds doctors by specialization
-func (r *doctorRepositoryImpl) FindBySpecialization(ctx context.Context, specialization string) ([]*entities.Doctor, error) {
-	filter := bson.M{
-		"specialization": specialization,
-		"isDeleted":     false,
-		"isApproved":    true,
+// SearchInClinic finds approved doctors who see patients in person, by
+// location, city, pincode, specialization and rating
+func (r *doctorRepositoryImpl) SearchInClinic(ctx context.Context, query entities.GeoQuery) ([]*entities.Doctor, int64, error) {
+	match := bson.M{
+		"isApproved":                true,
+		"isDeleted":                 bson.M{"$ne": true},
+		"consultationFees.inClinic": bson.M{"$gt": 0},
+	}
+	if query.City != "" {
+		match["city"] = equalFold(query.City)
+	}
+	if query.Pincode != "" {
+		match["pincode"] = query.Pincode
+	}
+	if query.Service != "" {
+		match["specialization"] = equalFold(query.Service)
+	}
+	if query.MinRating > 0 {
+		match["rating"] = bson.M{"$gte": query.MinRating}
 	}
 
-	cursor, err := r.collection().Find(ctx, filter)
+	doctors := []*entities.Doctor{}
+	total, err := geoSearch(ctx, r.collection(), match, query, &doctors)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find doctors by specialization: %w", err)
+		return nil, 0, fmt.Errorf("failed to search doctors: %w", err)
 	}
-	defer cursor.Close(ctx)
+	return doctors, total, nil
+}
 
-	var doctors []*entities.Doctor
-	if err := cursor.All(ctx, &doctors); err != nil {
-		return nil, fmt.Errorf("failed to decode doctors: %w", err)
+func (r *doctorRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	if err := ensureGeoIndex(ctx, r.collection()); err != nil {
+		return err
 	}
+	// An account is linked to at most one doctor profile
+	_, err := r.collection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}},
+		Options: options.Index().
+			SetName("userId_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"userId": bson.M{"$exists": true}}),
+	})
+	return err
+}
 
-	return doctors, nil
+func (r *doctorRepositoryImpl) ApplyReviewRating(ctx context.Context, id string, stars, delta int) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid doctor ID format: %w", err)
+	}
+	return applyReviewRating(ctx, r.collection(), objectID, stars, delta)
 }
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FertilityRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewFertilityRepository(db *mongo.Database) *FertilityRepositoryImpl {
+	return &FertilityRepositoryImpl{
+		collection: db.Collection("fertility_logs"),
+	}
+}
+
+func (r *FertilityRepositoryImpl) Create(ctx context.Context, log *entities.FertilityLog) error {
+	now := time.Now()
+	log.CreatedAt = now
+	log.UpdatedAt = now
+
+	// Ensure Date is set
+	if log.Date.IsZero() {
+		log.Date = now
+	}
+
+	result, err := r.collection.InsertOne(ctx, log)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		log.ID = oid
+	}
+
+	return nil
+}
+
+func (r *FertilityRepositoryImpl) FindByUserID(ctx context.Context, userID string, logType string, limit int) ([]*entities.FertilityLog, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID}
+	if logType != "" {
+		filter["type"] = logType
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []*entities.FertilityLog
+	if err = cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+func (r *FertilityRepositoryImpl) FindByDateRange(ctx context.Context, userID string, start, end time.Time) ([]*entities.FertilityLog, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{
+		"userId": userOID,
+		"date": bson.M{
+			"$gte": start,
+			"$lte": end,
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []*entities.FertilityLog
+	if err = cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
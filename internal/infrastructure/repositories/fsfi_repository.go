@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FSFIRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewFSFIRepository(db *mongo.Database) *FSFIRepositoryImpl {
+	return &FSFIRepositoryImpl{
+		collection: db.Collection("fsfi_results"),
+	}
+}
+
+func (r *FSFIRepositoryImpl) Create(ctx context.Context, result *entities.FSFIResult) error {
+	now := time.Now()
+	result.CreatedAt = now
+	result.UpdatedAt = now
+
+	// Ensure SubmittedAt is set
+	if result.SubmittedAt.IsZero() {
+		result.SubmittedAt = now
+	}
+
+	res, err := r.collection.InsertOne(ctx, result)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		result.ID = oid
+	}
+
+	return nil
+}
+
+func (r *FSFIRepositoryImpl) FindByUserID(ctx context.Context, userID string, limit int) ([]*entities.FSFIResult, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"user": userOID}
+	opts := options.Find().SetSort(bson.D{{Key: "submittedAt", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*entities.FSFIResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// FindByDateRange returns one page of a user's FSFI results, newest first, optionally
+// limited to results submitted between from and to
+func (r *FSFIRepositoryImpl) FindByDateRange(ctx context.Context, userID string, from, to *time.Time, page, limit int) ([]*entities.FSFIResult, int64, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter := bson.M{"user": userOID}
+	if dateFilter := dateRangeFilter(from, to); len(dateFilter) > 0 {
+		filter["submittedAt"] = dateFilter
+	}
+
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSort(bson.D{{Key: "submittedAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*entities.FSFIResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// FindPrevious returns the user's latest FSFI result submitted before the given time
+// FindByID returns the user's result with the given ID, or nil if there is none
+func (r *FSFIRepositoryImpl) FindByID(ctx context.Context, userID, id string) (*entities.FSFIResult, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result entities.FSFIResult
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid, "user": userOID}).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (r *FSFIRepositoryImpl) FindPrevious(ctx context.Context, userID string, before time.Time) (*entities.FSFIResult, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"user": userOID, "submittedAt": bson.M{"$lt": before}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "submittedAt", Value: -1}, {Key: "_id", Value: -1}})
+
+	var result entities.FSFIResult
+	err = r.collection.FindOne(ctx, filter, opts).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ensureGeoIndex creates the 2dsphere index on geoLocation that $geoNear needs.
+// Creating an index that already exists is a no-op.
+func ensureGeoIndex(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "geoLocation", Value: "2dsphere"}},
+		Options: options.Index().SetName("geoLocation_2dsphere"),
+	})
+	return err
+}
+
+// equalFold matches a string field exactly, ignoring case
+func equalFold(value string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"}
+}
+
+// Best rated first; among equal ratings, the one with more reviews first
+var ratingSort = bson.D{{Key: "rating", Value: -1}, {Key: "totalReviews", Value: -1}, {Key: "_id", Value: 1}}
+
+// geoSearch runs a discovery search over a collection and decodes one page of
+// results into out, returning the total number of matches. match holds the
+// collection's own filters. Near searches put each document's distance from
+// the point, in kilometres, in distanceKm.
+func geoSearch(ctx context.Context, collection *mongo.Collection, match bson.M, query entities.GeoQuery, out interface{}) (int64, error) {
+	var pipeline mongo.Pipeline
+	if query.Near != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$geoNear", Value: bson.M{
+			"near":               query.Near,
+			"key":                "geoLocation",
+			"distanceField":      "distanceKm",
+			"distanceMultiplier": 0.001,
+			"maxDistance":        query.RadiusKm * 1000,
+			"spherical":          true,
+			"query":              match,
+		}}})
+		if query.SortBy == "rating" {
+			pipeline = append(pipeline, bson.D{{Key: "$sort", Value: ratingSort}})
+		}
+	} else {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$match", Value: match}},
+			bson.D{{Key: "$sort", Value: ratingSort}},
+		)
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"results": bson.A{
+			bson.M{"$skip": int64((query.Page - 1) * query.Limit)},
+			bson.M{"$limit": int64(query.Limit)},
+		},
+		"total": bson.A{bson.M{"$count": "count"}},
+	}}})
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var page []struct {
+		Results bson.RawValue `bson:"results"`
+		Total   []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err := cursor.All(ctx, &page); err != nil {
+		return 0, err
+	}
+	if len(page) == 0 {
+		return 0, nil
+	}
+	if err := page[0].Results.Unmarshal(out); err != nil {
+		return 0, err
+	}
+	if len(page[0].Total) == 0 {
+		return 0, nil
+	}
+	return page[0].Total[0].Count, nil
+}
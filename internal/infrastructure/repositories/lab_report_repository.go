@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LabReportRepositoryImpl struct {
+	reportsCollection *mongo.Collection
+}
+
+func NewLabReportRepository(db *mongo.Database) *LabReportRepositoryImpl {
+	return &LabReportRepositoryImpl{
+		reportsCollection: db.Collection("lab_reports"),
+	}
+}
+
+func (r *LabReportRepositoryImpl) Create(ctx context.Context, report *entities.LabReport) error {
+	now := time.Now()
+	report.CreatedAt = now
+	report.UpdatedAt = now
+
+	result, err := r.reportsCollection.InsertOne(ctx, report)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		report.ID = oid
+	}
+	return nil
+}
+
+// Replace overwrites a report with a new upload, keeping its ID and CreatedAt
+func (r *LabReportRepositoryImpl) Replace(ctx context.Context, report *entities.LabReport) error {
+	report.UpdatedAt = time.Now()
+
+	_, err := r.reportsCollection.ReplaceOne(ctx, bson.M{"_id": report.ID}, report)
+	return err
+}
+
+func (r *LabReportRepositoryImpl) FindByID(ctx context.Context, id string) (*entities.LabReport, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var report entities.LabReport
+	err = r.reportsCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&report)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (r *LabReportRepositoryImpl) FindByBookingID(ctx context.Context, bookingID primitive.ObjectID) (*entities.LabReport, error) {
+	var report entities.LabReport
+	err := r.reportsCollection.FindOne(ctx, bson.M{"bookingId": bookingID}).Decode(&report)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// FindByUserID returns the user's reports, most recent sample first
+func (r *LabReportRepositoryImpl) FindByUserID(ctx context.Context, userID string) ([]*entities.LabReport, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "sampleDate", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.reportsCollection.Find(ctx, bson.M{"userId": userOID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reports []*entities.LabReport
+	if err = cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (r *LabReportRepositoryImpl) FindAnalyteHistory(ctx context.Context, userID, code string, from, to *time.Time) ([]entities.AnalyteTrendPoint, error) {
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	match := bson.M{"userId": userOID, "analytes.code": code}
+	if from != nil || to != nil {
+		dateRange := bson.M{}
+		if from != nil {
+			dateRange["$gte"] = *from
+		}
+		if to != nil {
+			dateRange["$lte"] = *to
+		}
+		match["sampleDate"] = dateRange
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$analytes"}},
+		{{Key: "$match", Value: bson.M{"analytes.code": code}}},
+		{{Key: "$sort", Value: bson.D{{Key: "sampleDate", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":       0,
+			"reportId":  "$_id",
+			"bookingId": 1,
+			"testName":  1,
+			"date":      "$sampleDate",
+			"name":      "$analytes.name",
+			"value":     "$analytes.value",
+			"unit":      "$analytes.unit",
+			"refLow":    "$analytes.refLow",
+			"refHigh":   "$analytes.refHigh",
+			"flag":      "$analytes.flag",
+		}}},
+	}
+
+	cursor, err := r.reportsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var points []entities.AnalyteTrendPoint
+	if err = cursor.All(ctx, &points); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// EnsureIndexes keeps one report per booking and indexes analyte lookups
+func (r *LabReportRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.reportsCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "bookingId", Value: 1}},
+			Options: options.Index().SetName("bookingId_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "analytes.code", Value: 1}, {Key: "sampleDate", Value: 1}},
+			Options: options.Index().SetName("userId_analytes_sampleDate"),
+		},
+	})
+	return err
+}
@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LabRepositoryImpl struct {
+	labsCollection          *mongo.Collection
+	phlebotomistsCollection *mongo.Collection
+}
+
+func NewLabRepository(db *mongo.Database) *LabRepositoryImpl {
+	return &LabRepositoryImpl{
+		labsCollection:          db.Collection("diagnostics_users"),
+		phlebotomistsCollection: db.Collection("phlebotomists"),
+	}
+}
+
+// FindByID returns an approved lab that has not been deleted
+func (r *LabRepositoryImpl) FindByID(ctx context.Context, id string) (*entities.DiagnosticsUser, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var lab entities.DiagnosticsUser
+	err = r.labsCollection.FindOne(ctx, bson.M{
+		"_id":        objectID,
+		"isApproved": true,
+		"isDeleted":  bson.M{"$ne": true},
+	}).Decode(&lab)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lab, nil
+}
+
+// FindByUserID returns the approved lab linked to a user account
+func (r *LabRepositoryImpl) FindByUserID(ctx context.Context, userID primitive.ObjectID) (*entities.DiagnosticsUser, error) {
+	var lab entities.DiagnosticsUser
+	err := r.labsCollection.FindOne(ctx, bson.M{
+		"userId":     userID,
+		"isApproved": true,
+		"isDeleted":  bson.M{"$ne": true},
+	}).Decode(&lab)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lab, nil
+}
+
+// Search finds approved labs by location, city, pincode, test and rating
+func (r *LabRepositoryImpl) Search(ctx context.Context, query entities.GeoQuery) ([]*entities.DiagnosticsUser, int64, error) {
+	match := bson.M{
+		"isApproved": true,
+		"isDeleted":  bson.M{"$ne": true},
+	}
+	if query.City != "" {
+		match["city"] = equalFold(query.City)
+	}
+	if query.Pincode != "" {
+		match["pincode"] = query.Pincode
+	}
+	if query.Service != "" {
+		match["offerings"] = bson.M{"$elemMatch": bson.M{
+			"testName":    equalFold(query.Service),
+			"isAvailable": true,
+		}}
+	}
+	if query.MinRating > 0 {
+		match["rating"] = bson.M{"$gte": query.MinRating}
+	}
+
+	labs := []*entities.DiagnosticsUser{}
+	total, err := geoSearch(ctx, r.labsCollection, match, query, &labs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return labs, total, nil
+}
+
+func (r *LabRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	if err := ensureGeoIndex(ctx, r.labsCollection); err != nil {
+		return err
+	}
+	// An account manages at most one lab
+	_, err := r.labsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}},
+		Options: options.Index().
+			SetName("userId_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"userId": bson.M{"$exists": true}}),
+	})
+	return err
+}
+
+func (r *LabRepositoryImpl) ApplyReviewRating(ctx context.Context, id string, stars, delta int) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	return applyReviewRating(ctx, r.labsCollection, objectID, stars, delta)
+}
+
+func (r *LabRepositoryImpl) CreatePhlebotomist(ctx context.Context, phlebotomist *entities.Phlebotomist) error {
+	now := time.Now()
+	phlebotomist.CreatedAt = now
+	phlebotomist.UpdatedAt = now
+
+	result, err := r.phlebotomistsCollection.InsertOne(ctx, phlebotomist)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		phlebotomist.ID = oid
+	}
+	return nil
+}
+
+func (r *LabRepositoryImpl) FindPhlebotomistByID(ctx context.Context, id string) (*entities.Phlebotomist, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var phlebotomist entities.Phlebotomist
+	err = r.phlebotomistsCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&phlebotomist)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &phlebotomist, nil
+}
+
+func (r *LabRepositoryImpl) FindPhlebotomists(ctx context.Context, labID primitive.ObjectID) ([]*entities.Phlebotomist, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.phlebotomistsCollection.Find(ctx, bson.M{"labId": labID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	phlebotomists := []*entities.Phlebotomist{}
+	if err = cursor.All(ctx, &phlebotomists); err != nil {
+		return nil, err
+	}
+
+	return phlebotomists, nil
+}
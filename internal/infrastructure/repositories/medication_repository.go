@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MedicationRepositoryImpl struct {
+	regimensCollection *mongo.Collection
+	dosesCollection    *mongo.Collection
+}
+
+func NewMedicationRepository(db *mongo.Database) *MedicationRepositoryImpl {
+	return &MedicationRepositoryImpl{
+		regimensCollection: db.Collection("medication_regimens"),
+		dosesCollection:    db.Collection("medication_doses"),
+	}
+}
+
+func (r *MedicationRepositoryImpl) CreateRegimen(ctx context.Context, regimen *entities.MedicationRegimen) error {
+	now := time.Now()
+	regimen.CreatedAt = now
+	regimen.UpdatedAt = now
+
+	result, err := r.regimensCollection.InsertOne(ctx, regimen)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		regimen.ID = oid
+	}
+
+	return nil
+}
+
+func (r *MedicationRepositoryImpl) FindRegimenByID(ctx context.Context, id string) (*entities.MedicationRegimen, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var regimen entities.MedicationRegimen
+	err = r.regimensCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&regimen)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &regimen, nil
+}
+
+func (r *MedicationRepositoryImpl) FindRegimensByUserID(ctx context.Context, userID string, activeOnly bool) ([]*entities.MedicationRegimen, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID}
+	if activeOnly {
+		filter["isActive"] = true
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	cursor, err := r.regimensCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var regimens []*entities.MedicationRegimen
+	if err = cursor.All(ctx, &regimens); err != nil {
+		return nil, err
+	}
+
+	return regimens, nil
+}
+
+func (r *MedicationRepositoryImpl) FindActiveRegimens(ctx context.Context) ([]*entities.MedicationRegimen, error) {
+	cursor, err := r.regimensCollection.Find(ctx, bson.M{"isActive": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var regimens []*entities.MedicationRegimen
+	if err = cursor.All(ctx, &regimens); err != nil {
+		return nil, err
+	}
+
+	return regimens, nil
+}
+
+func (r *MedicationRepositoryImpl) UpdateRegimen(ctx context.Context, regimen *entities.MedicationRegimen) error {
+	regimen.UpdatedAt = time.Now()
+
+	_, err := r.regimensCollection.UpdateByID(ctx, regimen.ID, bson.M{"$set": regimen})
+	return err
+}
+
+func (r *MedicationRepositoryImpl) UpsertDoseLog(ctx context.Context, log *entities.DoseLog) error {
+	now := time.Now()
+	log.UpdatedAt = now
+
+	filter := bson.M{"regimenId": log.RegimenID, "scheduledAt": log.ScheduledAt}
+	update := bson.M{
+		"$set": bson.M{
+			"userId":    log.UserID,
+			"status":    log.Status,
+			"takenAt":   log.TakenAt,
+			"isPlacebo": log.IsPlacebo,
+			"notes":     log.Notes,
+			"updatedAt": now,
+		},
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	return r.dosesCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(log)
+}
+
+func (r *MedicationRepositoryImpl) FindDoseLogs(ctx context.Context, regimenIDs []primitive.ObjectID, from, to time.Time) ([]*entities.DoseLog, error) {
+	filter := bson.M{
+		"regimenId": bson.M{"$in": regimenIDs},
+		"scheduledAt": bson.M{
+			"$gte": from,
+			"$lte": to,
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "scheduledAt", Value: -1}})
+
+	cursor, err := r.dosesCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []*entities.DoseLog
+	if err = cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MenopauseRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewMenopauseRepository(db *mongo.Database) *MenopauseRepositoryImpl {
+	return &MenopauseRepositoryImpl{
+		collection: db.Collection("vasomotor_logs"),
+	}
+}
+
+func (r *MenopauseRepositoryImpl) Create(ctx context.Context, log *entities.VasomotorLog) error {
+	now := time.Now()
+	log.CreatedAt = now
+	log.UpdatedAt = now
+
+	// Ensure OccurredAt is set
+	if log.OccurredAt.IsZero() {
+		log.OccurredAt = now
+	}
+
+	result, err := r.collection.InsertOne(ctx, log)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		log.ID = oid
+	}
+
+	return nil
+}
+
+func (r *MenopauseRepositoryImpl) FindByUserID(ctx context.Context, userID string, logType string, limit int) ([]*entities.VasomotorLog, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID}
+	if logType != "" {
+		filter["type"] = logType
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "occurredAt", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []*entities.VasomotorLog
+	if err = cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+func (r *MenopauseRepositoryImpl) FindSince(ctx context.Context, userID string, since time.Time) ([]*entities.VasomotorLog, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID, "occurredAt": bson.M{"$gte": since}}
+	opts := options.Find().SetSort(bson.D{{Key: "occurredAt", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []*entities.VasomotorLog
+	if err = cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
@@ -70,3 +70,159 @@ func (r *MentalHealthRepositoryImpl) FindResultsByUserID(ctx context.Context, us
 
 	return results, nil
 }
+
+// FindResultsByDateRange returns one page of a user's results for a test, newest first,
+// optionally limited to results taken between from and to
+func (r *MentalHealthRepositoryImpl) FindResultsByDateRange(ctx context.Context, userID, testName string, from, to *time.Time, page, limit int) ([]*entities.TestResult, int64, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter := bson.M{"userId": userOID, "testName": testName}
+	if dateFilter := dateRangeFilter(from, to); len(dateFilter) > 0 {
+		filter["testDate"] = dateFilter
+	}
+
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSort(bson.D{{Key: "testDate", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*entities.TestResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// FindPreviousResult returns the user's latest result for a test taken before the given time
+func (r *MentalHealthRepositoryImpl) FindPreviousResult(ctx context.Context, userID, testName string, before time.Time) (*entities.TestResult, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID, "testName": testName, "testDate": bson.M{"$lt": before}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "testDate", Value: -1}, {Key: "_id", Value: -1}})
+
+	var result entities.TestResult
+	err = r.collection.FindOne(ctx, filter, opts).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// FindResultsByIDs returns the user's results with the given IDs, newest first.
+// IDs that belong to another user are ignored.
+func (r *MentalHealthRepositoryImpl) FindResultsByIDs(ctx context.Context, userID string, ids []primitive.ObjectID) ([]*entities.TestResult, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID, "_id": bson.M{"$in": ids}}
+	opts := options.Find().SetSort(bson.D{{Key: "testDate", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*entities.TestResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// FindLatestResults returns the user's most recent result for each of the named tests
+func (r *MentalHealthRepositoryImpl) FindLatestResults(ctx context.Context, userID string, testNames []string) ([]*entities.TestResult, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.findLatestResults(ctx, bson.M{"userId": userOID, "testName": bson.M{"$in": testNames}}, nil)
+}
+
+// FindLatestResultsPendingReminder returns, across all users, the most recent result
+// for each of the named tests that was taken before the given time and has not yet
+// triggered a rescreening reminder
+func (r *MentalHealthRepositoryImpl) FindLatestResultsPendingReminder(ctx context.Context, testNames []string, takenBefore time.Time) ([]*entities.TestResult, error) {
+	return r.findLatestResults(ctx,
+		bson.M{"testName": bson.M{"$in": testNames}},
+		bson.M{"testDate": bson.M{"$lt": takenBefore}, "rescreenNotifiedAt": bson.M{"$exists": false}},
+	)
+}
+
+func (r *MentalHealthRepositoryImpl) findLatestResults(ctx context.Context, match, latestMatch bson.M) ([]*entities.TestResult, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "testDate", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"userId": "$userId", "testName": "$testName"},
+			"doc": bson.M{"$first": "$$ROOT"},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$doc"}}},
+	}
+	if latestMatch != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: latestMatch}})
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*entities.TestResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *MentalHealthRepositoryImpl) MarkRescreenNotified(ctx context.Context, resultID primitive.ObjectID, notifiedAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": resultID},
+		bson.M{"$set": bson.M{"rescreenNotifiedAt": notifiedAt, "updatedAt": time.Now()}},
+	)
+	return err
+}
+
+func dateRangeFilter(from, to *time.Time) bson.M {
+	filter := bson.M{}
+	if from != nil {
+		filter["$gte"] = *from
+	}
+	if to != nil {
+		filter["$lte"] = *to
+	}
+	return filter
+}
@@ -12,12 +12,14 @@ import (
 )
 
 type PCOSRepositoryImpl struct {
-	collection *mongo.Collection
+	collection            *mongo.Collection
+	ultrasoundsCollection *mongo.Collection
 }
 
 func NewPCOSRepository(db *mongo.Database) *PCOSRepositoryImpl {
 	return &PCOSRepositoryImpl{
-		collection: db.Collection("pcos_assessments"),
+		collection:            db.Collection("pcos_assessments"),
+		ultrasoundsCollection: db.Collection("ovarian_ultrasounds"),
 	}
 }
 
@@ -83,3 +85,90 @@ func (r *PCOSRepositoryImpl) FindLatestByUserID(ctx context.Context, userID stri
 
 	return &assessment, nil
 }
+
+// FindByIDs returns the user's assessments with the given IDs, newest first.
+// IDs that belong to another user are ignored.
+func (r *PCOSRepositoryImpl) FindByIDs(ctx context.Context, userID string, ids []primitive.ObjectID) ([]*entities.PCOSAssessment, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID, "_id": bson.M{"$in": ids}}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var assessments []*entities.PCOSAssessment
+	if err = cursor.All(ctx, &assessments); err != nil {
+		return nil, err
+	}
+
+	return assessments, nil
+}
+
+func (r *PCOSRepositoryImpl) CreateUltrasound(ctx context.Context, ultrasound *entities.OvarianUltrasound) error {
+	ultrasound.CreatedAt = time.Now()
+
+	result, err := r.ultrasoundsCollection.InsertOne(ctx, ultrasound)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		ultrasound.ID = oid
+	}
+
+	return nil
+}
+
+func (r *PCOSRepositoryImpl) FindUltrasoundsByUserID(ctx context.Context, userID string, limit int) ([]*entities.OvarianUltrasound, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID}
+	opts := options.Find().SetSort(bson.D{{Key: "scanDate", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.ultrasoundsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ultrasounds []*entities.OvarianUltrasound
+	if err = cursor.All(ctx, &ultrasounds); err != nil {
+		return nil, err
+	}
+
+	return ultrasounds, nil
+}
+
+func (r *PCOSRepositoryImpl) FindLatestUltrasound(ctx context.Context, userID string) (*entities.OvarianUltrasound, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID}
+	opts := options.FindOne().SetSort(bson.D{{Key: "scanDate", Value: -1}})
+
+	var ultrasound entities.OvarianUltrasound
+	err = r.ultrasoundsCollection.FindOne(ctx, filter, opts).Decode(&ultrasound)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ultrasound, nil
+}
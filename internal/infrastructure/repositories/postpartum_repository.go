@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PostpartumRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewPostpartumRepository(db *mongo.Database) *PostpartumRepositoryImpl {
+	return &PostpartumRepositoryImpl{
+		collection: db.Collection("postpartum_entries"),
+	}
+}
+
+func (r *PostpartumRepositoryImpl) Create(ctx context.Context, entry *entities.PostpartumEntry) error {
+	now := time.Now()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	// Ensure Date is set
+	if entry.Date.IsZero() {
+		entry.Date = now
+	}
+
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		entry.ID = oid
+	}
+
+	return nil
+}
+
+func (r *PostpartumRepositoryImpl) FindByUserID(ctx context.Context, userID string, entryType string, limit int) ([]*entities.PostpartumEntry, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID}
+	if entryType != "" {
+		filter["type"] = entryType
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*entities.PostpartumEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *PostpartumRepositoryImpl) FindSince(ctx context.Context, userID string, since time.Time) ([]*entities.PostpartumEntry, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID, "date": bson.M{"$gte": since}}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*entities.PostpartumEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -4,19 +4,23 @@ import (
 	"context"
 	"time"
 
+	"github.com/anshjamwal15/hsb_backend/internal/domain"
 	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type PregnancyRepositoryImpl struct {
-	collection *mongo.Collection
+	collection         *mongo.Collection
+	sessionsCollection *mongo.Collection
 }
 
 func NewPregnancyRepository(db *mongo.Database) *PregnancyRepositoryImpl {
 	return &PregnancyRepositoryImpl{
-		collection: db.Collection("pregnancy_trackers"),
+		collection:         db.Collection("pregnancy_trackers"),
+		sessionsCollection: db.Collection("pregnancy_sessions"),
 	}
 }
 
@@ -67,6 +71,9 @@ func (r *PregnancyRepositoryImpl) Update(ctx context.Context, tracker *entities.
 			"lastPeriodDate": tracker.LastPeriodDate,
 			"weight":         tracker.Weight,
 			"notes":          tracker.Notes,
+			"status":         tracker.Status,
+			"deliveryDate":   tracker.DeliveryDate,
+			"deliveryType":   tracker.DeliveryType,
 			"updatedAt":      tracker.UpdatedAt,
 		},
 	}
@@ -74,3 +81,178 @@ func (r *PregnancyRepositoryImpl) Update(ctx context.Context, tracker *entities.
 	_, err := r.collection.UpdateByID(ctx, tracker.ID, update)
 	return err
 }
+
+func (r *PregnancyRepositoryImpl) CreateSession(ctx context.Context, session *entities.PregnancySession) error {
+	now := time.Now()
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	result, err := r.sessionsCollection.InsertOne(ctx, session)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicateKey
+	}
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		session.ID = oid
+	}
+
+	return nil
+}
+
+func (r *PregnancyRepositoryImpl) FindSessionByID(ctx context.Context, sessionID string) (*entities.PregnancySession, error) {
+	objectID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var session entities.PregnancySession
+	err = r.sessionsCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&session)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *PregnancyRepositoryImpl) FindSessionsByUserID(ctx context.Context, userID string, sessionType string, limit int) ([]*entities.PregnancySession, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID}
+	if sessionType != "" {
+		filter["type"] = sessionType
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "startedAt", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.sessionsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []*entities.PregnancySession
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (r *PregnancyRepositoryImpl) FindActiveSession(ctx context.Context, userID string, sessionType string) (*entities.PregnancySession, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": userOID, "type": sessionType, "status": "active"}
+
+	var session entities.PregnancySession
+	err = r.sessionsCollection.FindOne(ctx, filter).Decode(&session)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// AddSessionEvent pushes an event onto an active session, keeping the events
+// in start order. A contraction is only added while none is in progress.
+func (r *PregnancyRepositoryImpl) AddSessionEvent(ctx context.Context, session *entities.PregnancySession, event entities.SessionEvent) (*entities.PregnancySession, error) {
+	filter := bson.M{"_id": session.ID, "status": "active"}
+	if session.Type == "contraction" {
+		filter["events"] = bson.M{"$not": bson.M{"$elemMatch": bson.M{"endedAt": nil}}}
+	}
+
+	update := bson.M{
+		"$push": bson.M{"events": bson.M{
+			"$each": []entities.SessionEvent{event},
+			"$sort": bson.M{"startedAt": 1},
+		}},
+		"$set": bson.M{"updatedAt": time.Now()},
+	}
+
+	return r.updateSession(ctx, filter, update)
+}
+
+// EndContraction ends the contraction in progress, if it started by endedAt
+func (r *PregnancyRepositoryImpl) EndContraction(ctx context.Context, sessionID primitive.ObjectID, endedAt time.Time) (*entities.PregnancySession, error) {
+	filter := bson.M{
+		"_id":    sessionID,
+		"status": "active",
+		"events": bson.M{"$elemMatch": bson.M{
+			"endedAt":   nil,
+			"startedAt": bson.M{"$lte": endedAt},
+		}},
+	}
+	update := bson.M{"$set": bson.M{
+		"events.$.endedAt": endedAt,
+		"updatedAt":        time.Now(),
+	}}
+
+	return r.updateSession(ctx, filter, update)
+}
+
+// CompleteSession marks an active session completed
+func (r *PregnancyRepositoryImpl) CompleteSession(ctx context.Context, sessionID primitive.ObjectID, endedAt time.Time) (*entities.PregnancySession, error) {
+	filter := bson.M{"_id": sessionID, "status": "active"}
+	update := bson.M{"$set": bson.M{
+		"status":    "completed",
+		"endedAt":   endedAt,
+		"updatedAt": time.Now(),
+	}}
+
+	return r.updateSession(ctx, filter, update)
+}
+
+// CompleteActiveSessions marks all of a user's active sessions completed
+func (r *PregnancyRepositoryImpl) CompleteActiveSessions(ctx context.Context, userID primitive.ObjectID, endedAt time.Time) error {
+	_, err := r.sessionsCollection.UpdateMany(ctx,
+		bson.M{"userId": userID, "status": "active"},
+		bson.M{"$set": bson.M{
+			"status":    "completed",
+			"endedAt":   endedAt,
+			"updatedAt": time.Now(),
+		}},
+	)
+	return err
+}
+
+func (r *PregnancyRepositoryImpl) updateSession(ctx context.Context, filter, update bson.M) (*entities.PregnancySession, error) {
+	var session entities.PregnancySession
+	err := r.sessionsCollection.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&session)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// EnsureIndexes creates the index that keeps a user to one active session of each type
+func (r *PregnancyRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.sessionsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}, {Key: "type", Value: 1}},
+		Options: options.Index().
+			SetName("userId_type_active_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": "active"}),
+	})
+	return err
+}
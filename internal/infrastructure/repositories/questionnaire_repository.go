@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type QuestionnaireRepositoryImpl struct {
+	collection             *mongo.Collection
+	translationsCollection *mongo.Collection
+}
+
+func NewQuestionnaireRepository(db *mongo.Database) *QuestionnaireRepositoryImpl {
+	return &QuestionnaireRepositoryImpl{
+		collection:             db.Collection("questionnaires"),
+		translationsCollection: db.Collection("questionnaire_translations"),
+	}
+}
+
+func (r *QuestionnaireRepositoryImpl) Create(ctx context.Context, test *entities.MentalHealthTest) error {
+	now := time.Now()
+	test.CreatedAt = now
+	test.UpdatedAt = now
+
+	result, err := r.collection.InsertOne(ctx, test)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		test.ID = oid
+	}
+
+	return nil
+}
+
+func (r *QuestionnaireRepositoryImpl) FindLatest(ctx context.Context, name string) (*entities.MentalHealthTest, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	var test entities.MentalHealthTest
+	err := r.collection.FindOne(ctx, bson.M{"name": name}, opts).Decode(&test)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &test, nil
+}
+
+func (r *QuestionnaireRepositoryImpl) FindByVersion(ctx context.Context, name string, version int) (*entities.MentalHealthTest, error) {
+	var test entities.MentalHealthTest
+	err := r.collection.FindOne(ctx, bson.M{"name": name, "version": version}).Decode(&test)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &test, nil
+}
+
+// FindAllLatest returns the highest version of every questionnaire, optionally
+// restricted to one category
+func (r *QuestionnaireRepositoryImpl) FindAllLatest(ctx context.Context, category string) ([]*entities.MentalHealthTest, error) {
+	match := bson.M{}
+	if category != "" {
+		match["category"] = category
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}, {Key: "version", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$name", "doc": bson.M{"$first": "$$ROOT"}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$doc"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "category", Value: 1}, {Key: "name", Value: 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tests []*entities.MentalHealthTest
+	if err = cursor.All(ctx, &tests); err != nil {
+		return nil, err
+	}
+
+	return tests, nil
+}
+
+func (r *QuestionnaireRepositoryImpl) FindVersions(ctx context.Context, name string) ([]*entities.MentalHealthTest, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"name": name}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tests []*entities.MentalHealthTest
+	if err = cursor.All(ctx, &tests); err != nil {
+		return nil, err
+	}
+
+	return tests, nil
+}
+
+// UpsertTranslation stores the translation of a questionnaire version into a
+// locale, replacing the text of any existing one
+func (r *QuestionnaireRepositoryImpl) UpsertTranslation(ctx context.Context, translation *entities.QuestionnaireTranslation) error {
+	now := time.Now()
+	translation.UpdatedAt = now
+
+	filter := bson.M{"name": translation.Name, "version": translation.Version, "locale": translation.Locale}
+	update := bson.M{
+		"$set": bson.M{
+			"displayName":     translation.DisplayName,
+			"description":     translation.Description,
+			"criticalNote":    translation.CriticalNote,
+			"sections":        translation.Sections,
+			"questions":       translation.Questions,
+			"options":         translation.Options,
+			"recommendations": translation.Recommendations,
+			"updatedAt":       now,
+		},
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	return r.translationsCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(translation)
+}
+
+func (r *QuestionnaireRepositoryImpl) FindTranslation(ctx context.Context, name string, version int, locale string) (*entities.QuestionnaireTranslation, error) {
+	var translation entities.QuestionnaireTranslation
+	err := r.translationsCollection.FindOne(ctx, bson.M{"name": name, "version": version, "locale": locale}).Decode(&translation)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &translation, nil
+}
+
+func (r *QuestionnaireRepositoryImpl) FindTranslationsByLocale(ctx context.Context, locale string) ([]*entities.QuestionnaireTranslation, error) {
+	cursor, err := r.translationsCollection.Find(ctx, bson.M{"locale": locale})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var translations []*entities.QuestionnaireTranslation
+	if err = cursor.All(ctx, &translations); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
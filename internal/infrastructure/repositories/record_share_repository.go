@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RecordShareRepositoryImpl struct {
+	collection     *mongo.Collection
+	logsCollection *mongo.Collection
+}
+
+func NewRecordShareRepository(db *mongo.Database) *RecordShareRepositoryImpl {
+	return &RecordShareRepositoryImpl{
+		collection:     db.Collection("record_shares"),
+		logsCollection: db.Collection("record_access_logs"),
+	}
+}
+
+func (r *RecordShareRepositoryImpl) Create(ctx context.Context, share *entities.RecordShare) error {
+	now := time.Now()
+	share.CreatedAt = now
+	share.UpdatedAt = now
+
+	result, err := r.collection.InsertOne(ctx, share)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		share.ID = oid
+	}
+
+	return nil
+}
+
+func (r *RecordShareRepositoryImpl) FindByID(ctx context.Context, id string) (*entities.RecordShare, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var share entities.RecordShare
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&share)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
+
+func (r *RecordShareRepositoryImpl) FindByUserID(ctx context.Context, userID string) ([]*entities.RecordShare, error) {
+	// Convert string userID to ObjectID
+	userOID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	return r.find(ctx, bson.M{"userId": userOID}, opts)
+}
+
+func (r *RecordShareRepositoryImpl) FindActiveByDoctorID(ctx context.Context, doctorID string, now time.Time) ([]*entities.RecordShare, error) {
+	doctorOID, err := primitive.ObjectIDFromHex(doctorID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{
+		"doctorId":  doctorOID,
+		"expiresAt": bson.M{"$gt": now},
+		"revokedAt": bson.M{"$exists": false},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "expiresAt", Value: 1}})
+	return r.find(ctx, filter, opts)
+}
+
+func (r *RecordShareRepositoryImpl) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*entities.RecordShare, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var shares []*entities.RecordShare
+	if err = cursor.All(ctx, &shares); err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
+
+func (r *RecordShareRepositoryImpl) Update(ctx context.Context, share *entities.RecordShare) error {
+	share.UpdatedAt = time.Now()
+
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": share.ID},
+		bson.M{"$set": share},
+	)
+	return err
+}
+
+func (r *RecordShareRepositoryImpl) CreateAccessLog(ctx context.Context, entry *entities.RecordAccessLog) error {
+	entry.OccurredAt = time.Now()
+
+	result, err := r.logsCollection.InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		entry.ID = oid
+	}
+
+	return nil
+}
+
+func (r *RecordShareRepositoryImpl) FindAccessLogs(ctx context.Context, shareID string) ([]*entities.RecordAccessLog, error) {
+	shareOID, err := primitive.ObjectIDFromHex(shareID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "occurredAt", Value: -1}})
+	cursor, err := r.logsCollection.Find(ctx, bson.M{"shareId": shareOID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*entities.RecordAccessLog
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
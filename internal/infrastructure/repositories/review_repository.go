@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain"
+	"github.com/anshjamwal15/hsb_backend/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReviewRepositoryImpl struct {
+	reviewsCollection *mongo.Collection
+}
+
+func NewReviewRepository(db *mongo.Database) *ReviewRepositoryImpl {
+	return &ReviewRepositoryImpl{
+		reviewsCollection: db.Collection("reviews"),
+	}
+}
+
+func (r *ReviewRepositoryImpl) Create(ctx context.Context, review *entities.Review) error {
+	now := time.Now()
+	review.CreatedAt = now
+	review.UpdatedAt = now
+
+	result, err := r.reviewsCollection.InsertOne(ctx, review)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicateKey
+	}
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		review.ID = oid
+	}
+	return nil
+}
+
+func (r *ReviewRepositoryImpl) FindByID(ctx context.Context, id string) (*entities.Review, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var review entities.Review
+	err = r.reviewsCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&review)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// FindByUserID returns the user's reviews, newest first
+func (r *ReviewRepositoryImpl) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]*entities.Review, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.reviewsCollection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []*entities.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *ReviewRepositoryImpl) FindByTarget(ctx context.Context, targetType string, targetID primitive.ObjectID, status string, page, limit int) ([]*entities.Review, int64, error) {
+	filter := bson.M{"targetType": targetType, "targetId": targetID, "status": status}
+	return r.findPage(ctx, filter, bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}, page, limit)
+}
+
+func (r *ReviewRepositoryImpl) FindByStatus(ctx context.Context, status string, page, limit int) ([]*entities.Review, int64, error) {
+	return r.findPage(ctx, bson.M{"status": status}, bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}, page, limit)
+}
+
+func (r *ReviewRepositoryImpl) findPage(ctx context.Context, filter bson.M, sort bson.D, page, limit int) ([]*entities.Review, int64, error) {
+	opts := options.Find().SetSort(sort).SetSkip(int64((page - 1) * limit)).SetLimit(int64(limit))
+
+	cursor, err := r.reviewsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	reviews := []*entities.Review{}
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.reviewsCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+func (r *ReviewRepositoryImpl) UpdateStatus(ctx context.Context, id, from, to, note, moderator string) (*entities.Review, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":      to,
+			"moderatedBy": moderator,
+			"moderatedAt": now,
+			"updatedAt":   now,
+		},
+	}
+	if note != "" {
+		update["$set"].(bson.M)["moderationNote"] = note
+	} else {
+		update["$unset"] = bson.M{"moderationNote": ""}
+	}
+
+	var review entities.Review
+	err = r.reviewsCollection.FindOneAndUpdate(ctx,
+		bson.M{"_id": objectID, "status": from},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&review)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *ReviewRepositoryImpl) SetResponse(ctx context.Context, id string, response *entities.ReviewResponse) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"updatedAt": time.Now()}}
+	if response != nil {
+		update["$set"].(bson.M)["response"] = response
+	} else {
+		update["$unset"] = bson.M{"response": ""}
+	}
+
+	_, err = r.reviewsCollection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *ReviewRepositoryImpl) Delete(ctx context.Context, id string, userID primitive.ObjectID) (*entities.Review, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var review entities.Review
+	err = r.reviewsCollection.FindOneAndDelete(ctx, bson.M{"_id": objectID, "userId": userID}).Decode(&review)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// EnsureIndexes keeps one review per booking and indexes the listings
+func (r *ReviewRepositoryImpl) EnsureIndexes(ctx context.Context) error {
+	_, err := r.reviewsCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "targetType", Value: 1}, {Key: "bookingId", Value: 1}},
+			Options: options.Index().SetName("targetType_bookingId_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "targetType", Value: 1}, {Key: "targetId", Value: 1}, {Key: "status", Value: 1}, {Key: "createdAt", Value: -1}},
+			Options: options.Index().SetName("target_status_createdAt"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}},
+			Options: options.Index().SetName("status_createdAt"),
+		},
+		{
+			Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}},
+			Options: options.Index().SetName("userId_createdAt"),
+		},
+	})
+	return err
+}
+
+// applyReviewRating adds a review's stars to a target's rating (delta 1) or
+// takes them away (delta -1) in one update, keeping the running sum beside the
+// rounded average. Targets rated before reviews existed start from their
+// stored rating and count.
+func applyReviewRating(ctx context.Context, collection *mongo.Collection, id primitive.ObjectID, stars, delta int) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"ratingSum": bson.M{"$add": bson.A{
+				bson.M{"$ifNull": bson.A{"$ratingSum", bson.M{"$multiply": bson.A{
+					bson.M{"$ifNull": bson.A{"$rating", 0}},
+					bson.M{"$ifNull": bson.A{"$totalReviews", 0}},
+				}}}},
+				stars * delta,
+			}},
+			"totalReviews": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$totalReviews", 0}}, delta}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"rating": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$totalReviews", 0}},
+				bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$ratingSum", "$totalReviews"}}, 1}},
+				0,
+			}},
+		}}},
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id}, pipeline)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrRecordNotFound
+	}
+	return nil
+}
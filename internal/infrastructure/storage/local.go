@@ -0,0 +1,153 @@
+// Package storage keeps uploaded images and documents, on the local
+// filesystem or in an S3-compatible bucket. Objects are addressed by slash
+// separated keys such as "profiles/<userId>/<id>.jpg".
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anshjamwal15/hsb_backend/internal/domain"
+)
+
+// LocalStore keeps objects under a directory. Its signed URLs point at the
+// API's /files route, which checks the signature before serving the file.
+type LocalStore struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+}
+
+func NewLocalStore(root, baseURL, signingKey string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalStore{
+		root:       root,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		signingKey: []byte(signingKey),
+	}, nil
+}
+
+// Put writes the object through a temporary file so readers never see a
+// partial one
+func (s *LocalStore) Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+func (s *LocalStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, domain.ErrRecordNotFound
+	}
+	return file, err
+}
+
+// Delete removes an object; removing one that does not exist is not an error
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL returns a /files URL for the object that stops working after expiry
+func (s *LocalStore) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+	return s.baseURL + "/files/" + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// VerifySignedURL checks the expires and signature parameters of a URL made by
+// SignedURL
+func (s *LocalStore) VerifySignedURL(key, expires, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return errors.New("invalid expiry")
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return errors.New("invalid signature")
+	}
+	if time.Now().Unix() > expiresAt {
+		return errors.New("link has expired")
+	}
+	return nil
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path maps a key to a file under the root, rejecting keys that would leave it
+func (s *LocalStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// validateKey accepts relative, clean, slash separated keys
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	return nil
+}
+
+// escapeKey escapes each segment of a key for use in a URL path
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
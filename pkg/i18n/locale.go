@@ -0,0 +1,94 @@
+// Package i18n picks the language of a response and translates the API's
+// user-facing messages. Questionnaire text is translated separately, per
+// questionnaire version.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used when nothing the client asks for is supported, and is
+// the fallback for any text missing in another locale
+const DefaultLocale = "en"
+
+// SupportedLocales are the locales the API can answer in: English, Hindi,
+// Tamil and Bengali
+var SupportedLocales = []string{"en", "hi", "ta", "bn"}
+
+// Normalize reduces a language tag such as "hi-IN" or "TA_in" to a supported
+// locale, reporting false when neither the tag nor its base language is supported
+func Normalize(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, "_", "-")))
+	if base, _, found := strings.Cut(tag, "-"); found {
+		tag = base
+	}
+	for _, locale := range SupportedLocales {
+		if locale == tag {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate picks the locale for a response. A supported profile preference
+// wins; otherwise the Accept-Language entries are tried in order of quality,
+// each by its full tag and then its base language, before the default.
+func Negotiate(preferred, acceptLanguage string) string {
+	if locale, ok := Normalize(preferred); ok {
+		return locale
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if tag == "*" {
+			return DefaultLocale
+		}
+		if locale, ok := Normalize(tag); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header,
+// highest quality first, dropping tags with q=0
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.TrimSpace(key) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			quality = q
+		}
+		if quality == 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.tag
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package i18n
+
+import "sort"
+
+// Keys of the user-facing messages in the catalog
+const (
+	MsgInvalidUserID         = "invalid_user_id"
+	MsgUnauthorized          = "unauthorized"
+	MsgUserNotFound          = "user_not_found"
+	MsgDoctorNotFound        = "doctor_not_found"
+	MsgDoctorNotAvailable    = "doctor_not_available"
+	MsgDoctorAndDateRequired = "doctor_and_date_required"
+	MsgTestNotFound          = "test_not_found"
+	MsgJournalNotFound       = "journal_not_found"
+	MsgJournalDeleted        = "journal_deleted"
+	MsgInvalidDate           = "invalid_date"
+	MsgInvalidDateUseISO     = "invalid_date_use_iso"
+	MsgInvalidFromDate       = "invalid_from_date"
+	MsgInvalidToDate         = "invalid_to_date"
+	MsgPaymentVerified       = "payment_verified"
+	MsgPeriodTrackerReset    = "period_tracker_reset"
+	MsgPasswordReset         = "password_reset"
+	MsgPasswordChanged       = "password_changed"
+	MsgOTPSent               = "otp_sent"
+	MsgOTPVerified           = "otp_verified"
+	MsgMenopauseTrackingOff  = "menopause_tracking_off"
+	MsgMedicationStopped     = "medication_stopped"
+	MsgCrisisCritical        = "crisis_critical"
+	MsgCrisisHigh            = "crisis_high"
+	MsgHelplineTeleMANAS     = "helpline_tele_manas"
+	MsgHelplineEmergency     = "helpline_emergency"
+	MsgUnsupportedLanguage   = "unsupported_language"
+)
+
+// messages holds every message in every supported locale. English is
+// complete; a key missing from another locale falls back to English.
+var messages = map[string]map[string]string{
+	"en": {
+		MsgInvalidUserID:         "Invalid user ID",
+		MsgUnauthorized:          "unauthorized",
+		MsgUserNotFound:          "User not found",
+		MsgDoctorNotFound:        "Doctor not found",
+		MsgDoctorNotAvailable:    "Doctor is not available",
+		MsgDoctorAndDateRequired: "doctorId and date are required",
+		MsgTestNotFound:          "Test not found",
+		MsgJournalNotFound:       "Journal not found",
+		MsgJournalDeleted:        "Journal deleted successfully",
+		MsgInvalidDate:           "Invalid date format",
+		MsgInvalidDateUseISO:     "Invalid date format. Use YYYY-MM-DD",
+		MsgInvalidFromDate:       "Invalid from date format",
+		MsgInvalidToDate:         "Invalid to date format",
+		MsgPaymentVerified:       "Payment verified successfully",
+		MsgPeriodTrackerReset:    "Period tracker reset successfully",
+		MsgPasswordReset:         "Password reset successfully",
+		MsgPasswordChanged:       "Password changed successfully",
+		MsgOTPSent:               "OTP sent to email",
+		MsgOTPVerified:           "OTP verified",
+		MsgMenopauseTrackingOff:  "Menopause tracking turned off",
+		MsgMedicationStopped:     "Medication stopped successfully",
+		MsgCrisisCritical:        "Your answers suggest you may be going through a very difficult time. Please reach out for support now.",
+		MsgCrisisHigh:            "Your results suggest you need support soon. Please book a consultation, and if you feel unsafe, call a helpline now.",
+		MsgHelplineTeleMANAS:     "Free national mental health helpline, also reachable at 1-800-891-4416",
+		MsgHelplineEmergency:     "Call if you are in immediate danger",
+		MsgUnsupportedLanguage:   "Unsupported language. Choose one of en, hi, ta or bn",
+	},
+	"hi": {
+		MsgInvalidUserID:         "अमान्य उपयोगकर्ता आईडी",
+		MsgUnauthorized:          "अनधिकृत",
+		MsgUserNotFound:          "उपयोगकर्ता नहीं मिला",
+		MsgDoctorNotFound:        "डॉक्टर नहीं मिले",
+		MsgDoctorNotAvailable:    "डॉक्टर उपलब्ध नहीं हैं",
+		MsgDoctorAndDateRequired: "doctorId और date आवश्यक हैं",
+		MsgTestNotFound:          "परीक्षण नहीं मिला",
+		MsgJournalNotFound:       "जर्नल नहीं मिला",
+		MsgJournalDeleted:        "जर्नल सफलतापूर्वक हटा दिया गया",
+		MsgInvalidDate:           "तारीख़ का प्रारूप अमान्य है",
+		MsgInvalidDateUseISO:     "तारीख़ का प्रारूप अमान्य है। YYYY-MM-DD का उपयोग करें",
+		MsgInvalidFromDate:       "प्रारंभ तारीख़ का प्रारूप अमान्य है",
+		MsgInvalidToDate:         "अंतिम तारीख़ का प्रारूप अमान्य है",
+		MsgPaymentVerified:       "भुगतान सफलतापूर्वक सत्यापित हो गया",
+		MsgPeriodTrackerReset:    "पीरियड ट्रैकर सफलतापूर्वक रीसेट हो गया",
+		MsgPasswordReset:         "पासवर्ड सफलतापूर्वक रीसेट हो गया",
+		MsgPasswordChanged:       "पासवर्ड सफलतापूर्वक बदल दिया गया",
+		MsgOTPSent:               "OTP ईमेल पर भेज दिया गया",
+		MsgOTPVerified:           "OTP सत्यापित हो गया",
+		MsgMenopauseTrackingOff:  "मेनोपॉज़ ट्रैकिंग बंद कर दी गई",
+		MsgMedicationStopped:     "दवा सफलतापूर्वक बंद कर दी गई",
+		MsgCrisisCritical:        "आपके उत्तरों से लगता है कि आप शायद बहुत कठिन समय से गुज़र रहे हैं। कृपया अभी सहायता लें।",
+		MsgCrisisHigh:            "आपके परिणामों से लगता है कि आपको जल्द ही सहायता की ज़रूरत है। कृपया परामर्श बुक करें, और अगर आप असुरक्षित महसूस करें तो अभी हेल्पलाइन पर कॉल करें।",
+		MsgHelplineTeleMANAS:     "निःशुल्क राष्ट्रीय मानसिक स्वास्थ्य हेल्पलाइन, 1-800-891-4416 पर भी उपलब्ध",
+		MsgHelplineEmergency:     "अगर आप तत्काल ख़तरे में हैं तो कॉल करें",
+		MsgUnsupportedLanguage:   "यह भाषा समर्थित नहीं है। en, hi, ta या bn में से कोई एक चुनें",
+	},
+	"ta": {
+		MsgInvalidUserID:         "தவறான பயனர் ஐடி",
+		MsgUnauthorized:          "அங்கீகாரம் இல்லை",
+		MsgUserNotFound:          "பயனர் கிடைக்கவில்லை",
+		MsgDoctorNotFound:        "மருத்துவர் கிடைக்கவில்லை",
+		MsgDoctorNotAvailable:    "மருத்துவர் இப்போது இல்லை",
+		MsgDoctorAndDateRequired: "doctorId மற்றும் date தேவை",
+		MsgTestNotFound:          "பரிசோதனை கிடைக்கவில்லை",
+		MsgJournalNotFound:       "குறிப்பேடு கிடைக்கவில்லை",
+		MsgJournalDeleted:        "குறிப்பேடு வெற்றிகரமாக நீக்கப்பட்டது",
+		MsgInvalidDate:           "தவறான தேதி வடிவம்",
+		MsgInvalidDateUseISO:     "தவறான தேதி வடிவம். YYYY-MM-DD வடிவத்தைப் பயன்படுத்தவும்",
+		MsgInvalidFromDate:       "தொடக்கத் தேதியின் வடிவம் தவறானது",
+		MsgInvalidToDate:         "முடிவுத் தேதியின் வடிவம் தவறானது",
+		MsgPaymentVerified:       "கட்டணம் வெற்றிகரமாகச் சரிபார்க்கப்பட்டது",
+		MsgPeriodTrackerReset:    "மாதவிடாய் டிராக்கர் வெற்றிகரமாக மீட்டமைக்கப்பட்டது",
+		MsgPasswordReset:         "கடவுச்சொல் வெற்றிகரமாக மீட்டமைக்கப்பட்டது",
+		MsgPasswordChanged:       "கடவுச்சொல் வெற்றிகரமாக மாற்றப்பட்டது",
+		MsgOTPSent:               "OTP மின்னஞ்சலுக்கு அனுப்பப்பட்டது",
+		MsgOTPVerified:           "OTP சரிபார்க்கப்பட்டது",
+		MsgMenopauseTrackingOff:  "மாதவிடாய் நிறுத்தக் கண்காணிப்பு நிறுத்தப்பட்டது",
+		MsgMedicationStopped:     "மருந்து வெற்றிகரமாக நிறுத்தப்பட்டது",
+		MsgCrisisCritical:        "நீங்கள் மிகவும் கடினமான நேரத்தைக் கடந்து கொண்டிருக்கலாம் என உங்கள் பதில்கள் காட்டுகின்றன. தயவுசெய்து இப்போதே உதவியை நாடுங்கள்.",
+		MsgCrisisHigh:            "உங்களுக்கு விரைவில் உதவி தேவை என உங்கள் முடிவுகள் காட்டுகின்றன. தயவுசெய்து ஆலோசனைக்கு முன்பதிவு செய்யுங்கள்; பாதுகாப்பற்றதாக உணர்ந்தால் இப்போதே உதவி எண்ணை அழையுங்கள்.",
+		MsgHelplineTeleMANAS:     "இலவச தேசிய மனநல உதவி எண், 1-800-891-4416 என்ற எண்ணிலும் அழைக்கலாம்",
+		MsgHelplineEmergency:     "நீங்கள் உடனடி ஆபத்தில் இருந்தால் அழையுங்கள்",
+		MsgUnsupportedLanguage:   "இந்த மொழி ஆதரிக்கப்படவில்லை. en, hi, ta அல்லது bn ஆகியவற்றில் ஒன்றைத் தேர்ந்தெடுக்கவும்",
+	},
+	"bn": {
+		MsgInvalidUserID:         "ব্যবহারকারীর আইডি সঠিক নয়",
+		MsgUnauthorized:          "অননুমোদিত",
+		MsgUserNotFound:          "ব্যবহারকারী পাওয়া যায়নি",
+		MsgDoctorNotFound:        "ডাক্তার পাওয়া যায়নি",
+		MsgDoctorNotAvailable:    "ডাক্তার এখন উপলব্ধ নেই",
+		MsgDoctorAndDateRequired: "doctorId এবং date প্রয়োজন",
+		MsgTestNotFound:          "পরীক্ষা পাওয়া যায়নি",
+		MsgJournalNotFound:       "জার্নাল পাওয়া যায়নি",
+		MsgJournalDeleted:        "জার্নাল সফলভাবে মুছে ফেলা হয়েছে",
+		MsgInvalidDate:           "তারিখের ফরম্যাট সঠিক নয়",
+		MsgInvalidDateUseISO:     "তারিখের ফরম্যাট সঠিক নয়। YYYY-MM-DD ব্যবহার করুন",
+		MsgInvalidFromDate:       "শুরুর তারিখের ফরম্যাট সঠিক নয়",
+		MsgInvalidToDate:         "শেষের তারিখের ফরম্যাট সঠিক নয়",
+		MsgPaymentVerified:       "পেমেন্ট সফলভাবে যাচাই করা হয়েছে",
+		MsgPeriodTrackerReset:    "পিরিয়ড ট্র্যাকার সফলভাবে রিসেট করা হয়েছে",
+		MsgPasswordReset:         "পাসওয়ার্ড সফলভাবে রিসেট করা হয়েছে",
+		MsgPasswordChanged:       "পাসওয়ার্ড সফলভাবে পরিবর্তন করা হয়েছে",
+		MsgOTPSent:               "OTP ইমেলে পাঠানো হয়েছে",
+		MsgOTPVerified:           "OTP যাচাই করা হয়েছে",
+		MsgMenopauseTrackingOff:  "মেনোপজ ট্র্যাকিং বন্ধ করা হয়েছে",
+		MsgMedicationStopped:     "ওষুধ সফলভাবে বন্ধ করা হয়েছে",
+		MsgCrisisCritical:        "আপনার উত্তর থেকে মনে হচ্ছে আপনি হয়তো খুব কঠিন সময়ের মধ্য দিয়ে যাচ্ছেন। অনুগ্রহ করে এখনই সাহায্য নিন।",
+		MsgCrisisHigh:            "আপনার ফলাফল থেকে মনে হচ্ছে আপনার শীঘ্রই সহায়তা প্রয়োজন। অনুগ্রহ করে একটি পরামর্শ বুক করুন, আর নিজেকে অনিরাপদ মনে হলে এখনই হেল্পলাইনে ফোন করুন।",
+		MsgHelplineTeleMANAS:     "বিনামূল্যে জাতীয় মানসিক স্বাস্থ্য হেল্পলাইন, 1-800-891-4416 নম্বরেও পাওয়া যায়",
+		MsgHelplineEmergency:     "আপনি তাৎক্ষণিক বিপদে থাকলে ফোন করুন",
+		MsgUnsupportedLanguage:   "এই ভাষা সমর্থিত নয়। en, hi, ta বা bn-এর মধ্যে একটি বেছে নিন",
+	},
+}
+
+// Message returns the message for key in locale, falling back to English and
+// then to the key itself
+func Message(locale, key string) string {
+	if text, ok := messages[locale][key]; ok {
+		return text
+	}
+	if text, ok := messages[DefaultLocale][key]; ok {
+		return text
+	}
+	return key
+}
+
+// MissingMessages lists the message keys that have no translation in locale
+func MissingMessages(locale string) []string {
+	missing := []string{}
+	for key := range messages[DefaultLocale] {
+		if _, ok := messages[locale][key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,96 @@
+// Package imaging makes thumbnails of uploaded images. It uses the standard
+// library's JPEG, PNG and GIF decoders, so no image tools are needed.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	_ "image/png" // registers the PNG decoder
+)
+
+// MaxPixels bounds the images that are decoded, so an upload claiming huge
+// dimensions cannot exhaust memory
+const MaxPixels = 50_000_000
+
+var ErrTooManyPixels = errors.New("image dimensions are too large")
+
+// Thumbnail decodes an image and returns a JPEG whose longer side is at most
+// maxSide pixels. Smaller images are not enlarged. Transparent areas become
+// white.
+func Thumbnail(data []byte, maxSide int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, errors.New("image is empty")
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	width, height := fit(src.Bounds().Dx(), src.Bounds().Dy(), maxSide)
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, shrink(src, width, height), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// fit scales width and height down so the longer side is at most maxSide
+func fit(width, height, maxSide int) (int, int) {
+	if width <= maxSide && height <= maxSide {
+		return width, height
+	}
+	if width >= height {
+		return maxSide, max(1, height*maxSide/width)
+	}
+	return max(1, width*maxSide/height), maxSide
+}
+
+// shrink resizes by averaging the block of source pixels under each target
+// pixel, composited onto white
+func shrink(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcHeight/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcWidth/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcWidth/width)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					// Premultiplied, so adding the missing alpha as white composites onto white
+					white := 0xffff - uint64(pa)
+					r += uint64(pr) + white
+					g += uint64(pg) + white
+					b += uint64(pb) + white
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
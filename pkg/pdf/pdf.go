@@ -0,0 +1,289 @@
+// Package pdf writes simple flowing text reports as PDF. It uses the standard
+// Helvetica fonts that every PDF reader provides, so nothing is embedded and no
+// external tools are needed.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A4 in points
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	margin       = 50.0
+	contentWidth = pageWidth - 2*margin
+	footerHeight = 30.0
+)
+
+const (
+	bodySize    = 10.0
+	headingSize = 13.0
+	titleSize   = 18.0
+	lineFactor  = 1.4
+)
+
+// Document is a report laid out top to bottom, adding pages as needed
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64 // distance of the cursor from the top of the page
+}
+
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.addPage()
+	return d
+}
+
+func (d *Document) addPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+
+	d.page.WriteString("0.45 g\n")
+	d.text(margin, margin, 8, false, d.title)
+	d.page.WriteString("0 g\n")
+	d.line(margin, margin+6, pageWidth-margin, margin+6)
+	d.y = margin + 26
+}
+
+// ensure starts a new page unless height points fit above the footer
+func (d *Document) ensure(height float64) {
+	if d.y+height > pageHeight-margin-footerHeight {
+		d.addPage()
+	}
+}
+
+// Title writes the report title
+func (d *Document) Title(text string) {
+	d.ensure(titleSize * 2)
+	d.y += titleSize
+	d.text(margin, d.y, titleSize, true, text)
+	d.y += titleSize * 0.8
+}
+
+// Heading starts a section
+func (d *Document) Heading(text string) {
+	d.ensure(headingSize*2 + bodySize*lineFactor*2)
+	d.y += headingSize * 1.2
+	d.text(margin, d.y, headingSize, true, text)
+	d.y += 4
+	d.line(margin, d.y, pageWidth-margin, d.y)
+	d.y += headingSize * 0.6
+}
+
+// Paragraph writes wrapped body text
+func (d *Document) Paragraph(text string) {
+	d.writeLines(margin, contentWidth, bodySize, false, wrap(text, bodySize, false, contentWidth))
+	d.y += bodySize * 0.5
+}
+
+// Note writes wrapped small grey text, for disclaimers
+func (d *Document) Note(text string) {
+	d.page.WriteString("0.45 g\n")
+	d.writeLines(margin, contentWidth, 8, false, wrap(text, 8, false, contentWidth))
+	d.page.WriteString("0 g\n")
+}
+
+// Field writes a bold label with its value beside it
+func (d *Document) Field(label, value string) {
+	const labelWidth = 160.0
+	lines := wrap(value, bodySize, false, contentWidth-labelWidth)
+	d.ensure(bodySize * lineFactor)
+	d.text(margin, d.y+bodySize, bodySize, true, label)
+	d.writeLines(margin+labelWidth, contentWidth-labelWidth, bodySize, false, lines)
+}
+
+// Bullet writes an indented list item
+func (d *Document) Bullet(text string) {
+	const indent = 14.0
+	lines := wrap(text, bodySize, false, contentWidth-indent)
+	d.ensure(bodySize * lineFactor)
+	d.text(margin+4, d.y+bodySize, bodySize, false, "•")
+	d.writeLines(margin+indent, contentWidth-indent, bodySize, false, lines)
+}
+
+// Item writes a question with its answer indented in bold below it
+func (d *Document) Item(question, answer string) {
+	const indent = 14.0
+	questionLines := wrap(question, bodySize, false, contentWidth)
+	d.ensure(bodySize * lineFactor * float64(len(questionLines)+1))
+	d.writeLines(margin, contentWidth, bodySize, false, questionLines)
+	d.writeLines(margin+indent, contentWidth-indent, bodySize, true, wrap(answer, bodySize, true, contentWidth-indent))
+	d.y += bodySize * 0.4
+}
+
+// Table writes rows under a bold header row. Widths are relative column widths;
+// cells that do not fit are shortened.
+func (d *Document) Table(headers []string, widths []float64, rows [][]string) {
+	total := 0.0
+	for _, width := range widths {
+		total += width
+	}
+	columns := make([]float64, len(widths))
+	for i, width := range widths {
+		columns[i] = width / total * contentWidth
+	}
+
+	rowHeight := bodySize * 1.6
+	writeRow := func(cells []string, bold bool) {
+		d.ensure(rowHeight)
+		x := margin
+		for i, cell := range cells {
+			if i >= len(columns) {
+				break
+			}
+			d.text(x+2, d.y+bodySize, bodySize, bold, truncate(cell, bodySize, bold, columns[i]-6))
+			x += columns[i]
+		}
+		d.y += rowHeight
+	}
+
+	writeRow(headers, true)
+	d.line(margin, d.y-rowHeight*0.3, pageWidth-margin, d.y-rowHeight*0.3)
+	for _, row := range rows {
+		writeRow(row, false)
+	}
+	d.y += bodySize * 0.5
+}
+
+// LineChart plots values left to right in a framed box, labelling the range
+// on the y axis and the first and last points on the x axis
+func (d *Document) LineChart(values []float64, firstLabel, lastLabel string) {
+	if len(values) == 0 {
+		return
+	}
+	const height = 120.0
+	const axis = 40.0
+	d.ensure(height + bodySize*3)
+
+	top := d.y + 4
+	left := margin + axis
+	width := contentWidth - axis
+
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = min(low, value)
+		high = max(high, value)
+	}
+	span := high - low
+	if span == 0 {
+		span = 1
+		low -= 0.5
+		high += 0.5
+	}
+
+	fmt.Fprintf(d.page, "0.6 G %.2f %.2f %.2f %.2f re S 0 G\n", left, pageHeight-top-height, width, height)
+	d.text(margin, top+8, 8, false, fmt.Sprintf("%.1f", high))
+	d.text(margin, top+height, 8, false, fmt.Sprintf("%.1f", low))
+
+	point := func(i int) (float64, float64) {
+		x := left + width/2
+		if len(values) > 1 {
+			x = left + 6 + float64(i)*(width-12)/float64(len(values)-1)
+		}
+		y := top + 6 + (high-values[i])/span*(height-12)
+		return x, pageHeight - y
+	}
+	x, y := point(0)
+	fmt.Fprintf(d.page, "1.2 w %.2f %.2f m\n", x, y)
+	for i := 1; i < len(values); i++ {
+		x, y = point(i)
+		fmt.Fprintf(d.page, "%.2f %.2f l\n", x, y)
+	}
+	d.page.WriteString("S 1 w\n")
+	for i := range values {
+		x, y = point(i)
+		fmt.Fprintf(d.page, "%.2f %.2f 3 3 re f\n", x-1.5, y-1.5)
+	}
+
+	d.y = top + height + bodySize*1.4
+	d.text(left, d.y, 8, false, firstLabel)
+	d.text(pageWidth-margin-textWidth(lastLabel, 8, false), d.y, 8, false, lastLabel)
+	d.y += bodySize
+}
+
+// Space moves the cursor down
+func (d *Document) Space(points float64) {
+	d.y += points
+}
+
+func (d *Document) writeLines(x, width, size float64, bold bool, lines []string) {
+	for _, line := range lines {
+		d.ensure(size * lineFactor)
+		d.y += size * lineFactor
+		d.text(x, d.y-size*(lineFactor-1), size, bold, line)
+	}
+}
+
+// text draws a single line with its baseline y points from the top of the page
+func (d *Document) text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, pageHeight-y, escape(encode(text)))
+}
+
+func (d *Document) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(d.page, "0.5 w %.2f %.2f m %.2f %.2f l S 1 w\n", x1, pageHeight-y1, x2, pageHeight-y2)
+}
+
+// Bytes renders the document, numbering the pages
+func (d *Document) Bytes() ([]byte, error) {
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	const firstPageObject = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (hsb_backend) /CreationDate (D:%s) >>",
+		escape(encode(d.title)), time.Now().UTC().Format("20060102150405Z")))
+
+	for i, page := range d.pages {
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		fmt.Fprintf(page, "0.45 g BT /F1 8.0 Tf %.2f %.2f Td (%s) Tj ET 0 g\n",
+			pageWidth-margin-textWidth(footer, 8, false), margin-20, footer)
+
+		var content bytes.Buffer
+		writer := zlib.NewWriter(&content)
+		if _, err := writer.Write(page.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPageObject+2*i+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes(), nil
+}